@@ -0,0 +1,378 @@
+// Package auth menangani autentikasi API key beserta scope, rate limit, dan kuota harian per key.
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"multiplescrape/ratelimit"
+)
+
+// Scope yang dikenal.
+const (
+	ScopeRead  = "read"
+	ScopeAdmin = "admin"
+)
+
+// APIKey adalah satu entri di file API key.
+// Isi salah satu: Key (plaintext) atau KeySHA256 (hex SHA-256 dari key).
+type APIKey struct {
+	Name               string   `json:"name"`
+	Key                string   `json:"key,omitempty"`
+	KeySHA256          string   `json:"key_sha256,omitempty"`
+	Scopes             []string `json:"scopes"`
+	RateLimitPerMinute int      `json:"rate_limit_per_minute,omitempty"`
+	DailyQuota         int      `json:"daily_quota,omitempty"`
+	Disabled           bool     `json:"disabled,omitempty"`
+}
+
+// HasScope mengecek apakah key memiliki scope tertentu. Scope admin mencakup read.
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// KeyUsage adalah statistik pemakaian satu key untuk endpoint monitoring.
+type KeyUsage struct {
+	Name          string    `json:"name"`
+	Scopes        []string  `json:"scopes"`
+	TotalRequests int64     `json:"total_requests"`
+	RequestsToday int       `json:"requests_today"`
+	DailyQuota    int       `json:"daily_quota"`
+	Throttled     int64     `json:"throttled"`
+	LastUsed      time.Time `json:"last_used,omitempty"`
+}
+
+type keyState struct {
+	key    APIKey
+	hash   []byte
+	bucket *ratelimit.Bucket
+
+	total     int64
+	today     int
+	day       string
+	throttled int64
+	lastUsed  time.Time
+}
+
+// Decision adalah hasil pemeriksaan satu request terhadap key.
+// Status berisi kode HTTP yang sesuai jika request ditolak.
+type Decision struct {
+	Key       *APIKey
+	Allowed   bool
+	Status    int
+	Reason    string
+	RateLimit ratelimit.Result
+}
+
+// savedUsage adalah pemakaian satu key yang disimpan ke file usage, diindeks dengan hash key.
+type savedUsage struct {
+	Total     int64     `json:"total"`
+	Day       string    `json:"day"`
+	Today     int       `json:"today"`
+	Throttled int64     `json:"throttled"`
+	LastUsed  time.Time `json:"last_used"`
+}
+
+// KeyStore memuat API key dari file JSON dan menghitung pemakaiannya di memori.
+// Pemakaian (termasuk kuota harian) disimpan berkala ke file usage di samping file key,
+// sehingga restart tidak mengosongkan kuota; yang hilang paling banyak pemakaian sejak simpan terakhir.
+type KeyStore struct {
+	mu                sync.Mutex
+	path              string
+	usagePath         string
+	defaultRateLimit  int
+	defaultDailyQuota int
+	keys              []*keyState
+	saved             map[string]savedUsage // pemakaian dari file untuk key yang belum dimuat
+	dirty             bool
+}
+
+// usageFile mengembalikan lokasi file usage untuk file key, misalnya api_keys.json -> api_keys.usage.json.
+func usageFile(path string) string {
+	if path == "" {
+		return ""
+	}
+	return strings.TrimSuffix(path, filepath.Ext(path)) + ".usage.json"
+}
+
+// NewKeyStore membuat store dan langsung memuat file key. File yang belum ada dianggap kosong.
+func NewKeyStore(path string, defaultRateLimit, defaultDailyQuota int) (*KeyStore, error) {
+	s := &KeyStore{
+		path:              path,
+		usagePath:         usageFile(path),
+		defaultRateLimit:  defaultRateLimit,
+		defaultDailyQuota: defaultDailyQuota,
+	}
+	if err := s.loadUsage(); err != nil {
+		return nil, err
+	}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// loadUsage membaca file usage. File yang belum ada berarti belum ada pemakaian.
+func (s *KeyStore) loadUsage() error {
+	if s.usagePath == "" {
+		return nil
+	}
+	raw, err := os.ReadFile(s.usagePath)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(raw, &s.saved); err != nil {
+			return fmt.Errorf("file usage API key %s tidak valid: %w", s.usagePath, err)
+		}
+	case os.IsNotExist(err):
+	default:
+		return fmt.Errorf("gagal membaca file usage API key %s: %w", s.usagePath, err)
+	}
+	return nil
+}
+
+// SaveUsage menulis pemakaian semua key ke file usage jika ada perubahan sejak simpan terakhir.
+func (s *KeyStore) SaveUsage() error {
+	s.mu.Lock()
+	if s.usagePath == "" || !s.dirty {
+		s.mu.Unlock()
+		return nil
+	}
+	usage := make(map[string]savedUsage, len(s.keys))
+	for hash, saved := range s.saved {
+		usage[hash] = saved
+	}
+	for _, st := range s.keys {
+		usage[hex.EncodeToString(st.hash)] = savedUsage{
+			Total:     st.total,
+			Day:       st.day,
+			Today:     st.today,
+			Throttled: st.throttled,
+			LastUsed:  st.lastUsed,
+		}
+	}
+	s.dirty = false
+	s.mu.Unlock()
+
+	raw, err := json.MarshalIndent(usage, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.usagePath), 0o755); err != nil {
+		return err
+	}
+	tmp := s.usagePath + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.usagePath)
+}
+
+// StartPersist menyimpan pemakaian key secara berkala di background.
+// Fungsi yang dikembalikan menghentikannya dan menyimpan sekali lagi.
+func (s *KeyStore) StartPersist(interval time.Duration) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.SaveUsage(); err != nil {
+					log.Printf("Gagal menyimpan pemakaian API key: %v", err)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return func() {
+		close(stop)
+		<-done
+		if err := s.SaveUsage(); err != nil {
+			log.Printf("Gagal menyimpan pemakaian API key: %v", err)
+		}
+	}
+}
+
+// Reload membaca ulang file key. Statistik pemakaian key yang masih ada dipertahankan.
+func (s *KeyStore) Reload() error {
+	var entries []APIKey
+	raw, err := os.ReadFile(s.path)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(raw, &entries); err != nil {
+			return fmt.Errorf("file API key %s tidak valid: %w", s.path, err)
+		}
+	case os.IsNotExist(err):
+		// Belum ada key, semua request dianggap anonim
+	default:
+		return fmt.Errorf("gagal membaca file API key %s: %w", s.path, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous := make(map[string]*keyState, len(s.keys))
+	for _, st := range s.keys {
+		previous[hex.EncodeToString(st.hash)] = st
+	}
+
+	keys := make([]*keyState, 0, len(entries))
+	for i, entry := range entries {
+		hash, err := entryHash(entry)
+		if err != nil {
+			return fmt.Errorf("API key #%d (%s): %w", i+1, entry.Name, err)
+		}
+		if entry.RateLimitPerMinute == 0 {
+			entry.RateLimitPerMinute = s.defaultRateLimit
+		}
+		if entry.DailyQuota == 0 {
+			entry.DailyQuota = s.defaultDailyQuota
+		}
+		entry.Key = ""
+
+		id := hex.EncodeToString(hash)
+		st, ok := previous[id]
+		if !ok {
+			st = &keyState{hash: hash}
+			if saved, found := s.saved[id]; found {
+				st.total, st.day, st.today = saved.Total, saved.Day, saved.Today
+				st.throttled, st.lastUsed = saved.Throttled, saved.LastUsed
+				delete(s.saved, id)
+			}
+		}
+		st.key = entry
+		if entry.RateLimitPerMinute > 0 {
+			st.bucket = ratelimit.PerMinute(entry.RateLimitPerMinute)
+		} else {
+			st.bucket = nil
+		}
+		keys = append(keys, st)
+	}
+	s.keys = keys
+	return nil
+}
+
+func entryHash(entry APIKey) ([]byte, error) {
+	switch {
+	case entry.Key != "":
+		sum := sha256.Sum256([]byte(entry.Key))
+		return sum[:], nil
+	case entry.KeySHA256 != "":
+		hash, err := hex.DecodeString(entry.KeySHA256)
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("key_sha256 harus hex SHA-256")
+		}
+		return hash, nil
+	default:
+		return nil, fmt.Errorf("key atau key_sha256 wajib diisi")
+	}
+}
+
+func (s *KeyStore) find(raw string) *keyState {
+	sum := sha256.Sum256([]byte(raw))
+	for _, st := range s.keys {
+		if subtle.ConstantTimeCompare(sum[:], st.hash) == 1 {
+			return st
+		}
+	}
+	return nil
+}
+
+// Authorize memeriksa key mentah: valid, aktif, punya scope, belum melewati rate limit dan kuota.
+// Request yang diizinkan langsung dihitung sebagai pemakaian.
+func (s *KeyStore) Authorize(raw, scope string) Decision {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.find(raw)
+	if st == nil || st.key.Disabled {
+		return Decision{Status: http.StatusUnauthorized, Reason: "API key tidak valid."}
+	}
+	key := st.key
+	decision := Decision{Key: &key}
+	if !key.HasScope(scope) {
+		decision.Status = http.StatusForbidden
+		decision.Reason = fmt.Sprintf("API key tidak memiliki scope '%s'.", scope)
+		return decision
+	}
+
+	today := time.Now().UTC().Format("2006-01-02")
+	if st.day != today {
+		st.day = today
+		st.today = 0
+	}
+	s.dirty = true
+	if key.DailyQuota > 0 && st.today >= key.DailyQuota {
+		st.throttled++
+		decision.Status = http.StatusTooManyRequests
+		decision.Reason = "Kuota harian API key sudah habis."
+		return decision
+	}
+	if st.bucket != nil {
+		decision.RateLimit = st.bucket.Take(1)
+		if !decision.RateLimit.Allowed {
+			st.throttled++
+			decision.Status = http.StatusTooManyRequests
+			decision.Reason = "Rate limit API key terlampaui."
+			return decision
+		}
+	}
+
+	st.total++
+	st.today++
+	st.lastUsed = time.Now()
+	decision.Allowed = true
+	return decision
+}
+
+// Len mengembalikan jumlah key yang dimuat.
+func (s *KeyStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.keys)
+}
+
+// Usage mengembalikan statistik semua key, diurutkan berdasarkan nama.
+func (s *KeyStore) Usage() []KeyUsage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	today := time.Now().UTC().Format("2006-01-02")
+	usage := make([]KeyUsage, 0, len(s.keys))
+	for _, st := range s.keys {
+		requestsToday := st.today
+		if st.day != today {
+			requestsToday = 0
+		}
+		usage = append(usage, KeyUsage{
+			Name:          st.key.Name,
+			Scopes:        st.key.Scopes,
+			TotalRequests: st.total,
+			RequestsToday: requestsToday,
+			DailyQuota:    st.key.DailyQuota,
+			Throttled:     st.throttled,
+			LastUsed:      st.lastUsed,
+		})
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Name < usage[j].Name })
+	return usage
+}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeKeys(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "api_keys.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAuthorizeScopesAndQuota(t *testing.T) {
+	hashed := sha256.Sum256([]byte("admin-key"))
+	path := writeKeys(t, `[
+		{"name": "django", "key": "read-key", "scopes": ["read"], "daily_quota": 2},
+		{"name": "ops", "key_sha256": "`+hex.EncodeToString(hashed[:])+`", "scopes": ["admin"]},
+		{"name": "old", "key": "disabled-key", "scopes": ["read"], "disabled": true}
+	]`)
+	store, err := NewKeyStore(path, 0, 0)
+	if err != nil {
+		t.Fatalf("NewKeyStore gagal: %v", err)
+	}
+	if n := store.Len(); n != 3 {
+		t.Errorf("jumlah key didapat %d, seharusnya 3", n)
+	}
+
+	if d := store.Authorize("salah", ScopeRead); d.Allowed || d.Status != http.StatusUnauthorized {
+		t.Errorf("key tidak dikenal seharusnya 401, didapat %+v", d)
+	}
+	if d := store.Authorize("disabled-key", ScopeRead); d.Allowed {
+		t.Error("key nonaktif seharusnya ditolak")
+	}
+	if d := store.Authorize("read-key", ScopeAdmin); d.Status != http.StatusForbidden {
+		t.Errorf("scope read tidak boleh mengakses admin, didapat %+v", d)
+	}
+	if d := store.Authorize("admin-key", ScopeRead); !d.Allowed {
+		t.Errorf("scope admin seharusnya mencakup read, didapat %+v", d)
+	}
+
+	for i := 0; i < 2; i++ {
+		if d := store.Authorize("read-key", ScopeRead); !d.Allowed {
+			t.Fatalf("request ke-%d seharusnya diizinkan: %+v", i+1, d)
+		}
+	}
+	if d := store.Authorize("read-key", ScopeRead); d.Status != http.StatusTooManyRequests {
+		t.Errorf("kuota harian seharusnya habis, didapat %+v", d)
+	}
+
+	for _, usage := range store.Usage() {
+		if usage.Name == "django" && (usage.RequestsToday != 2 || usage.Throttled != 1) {
+			t.Errorf("statistik django salah: %+v", usage)
+		}
+	}
+}
+
+func TestAuthorizeRateLimit(t *testing.T) {
+	path := writeKeys(t, `[{"name": "bot", "key": "bot-key", "scopes": ["read"], "rate_limit_per_minute": 3}]`)
+	store, err := NewKeyStore(path, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if d := store.Authorize("bot-key", ScopeRead); !d.Allowed {
+			t.Fatalf("burst ke-%d seharusnya diizinkan", i+1)
+		}
+	}
+	d := store.Authorize("bot-key", ScopeRead)
+	if d.Allowed || d.Status != http.StatusTooManyRequests || d.RateLimit.RetryAfter <= 0 {
+		t.Errorf("request keempat seharusnya terkena rate limit, didapat %+v", d)
+	}
+}
+
+func TestUsageSurvivesRestart(t *testing.T) {
+	path := writeKeys(t, `[{"name": "django", "key": "read-key", "scopes": ["read"], "daily_quota": 2}]`)
+	store, err := NewKeyStore(path, 0, 0)
+	if err != nil {
+		t.Fatalf("NewKeyStore gagal: %v", err)
+	}
+	store.Authorize("read-key", ScopeRead)
+	store.Authorize("read-key", ScopeRead)
+	store.StartPersist(time.Hour)()
+
+	restarted, err := NewKeyStore(path, 0, 0)
+	if err != nil {
+		t.Fatalf("NewKeyStore setelah restart gagal: %v", err)
+	}
+	if d := restarted.Authorize("read-key", ScopeRead); d.Allowed || d.Status != http.StatusTooManyRequests {
+		t.Errorf("kuota harian seharusnya tetap habis setelah restart, didapat %+v", d)
+	}
+	if usage := restarted.Usage(); usage[0].TotalRequests != 2 || usage[0].Throttled != 1 {
+		t.Errorf("statistik pemakaian seharusnya dimuat dari file usage, didapat %+v", usage[0])
+	}
+}
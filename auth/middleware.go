@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"multiplescrape/ratelimit"
+)
+
+// ContextKeyName adalah key gin.Context yang berisi nama API key yang dipakai.
+const ContextKeyName = "api_key_name"
+
+// Options mengatur dari mana API key dibaca dan apakah key wajib.
+type Options struct {
+	Header     string
+	QueryParam string
+	Required   bool
+}
+
+// ExtractKey membaca API key dari header, lalu dari query parameter.
+func ExtractKey(c *gin.Context, opts Options) string {
+	if raw := c.GetHeader(opts.Header); raw != "" {
+		return raw
+	}
+	if opts.QueryParam != "" {
+		return c.Query(opts.QueryParam)
+	}
+	return ""
+}
+
+// Abort menghentikan request sesuai keputusan Authorize.
+func Abort(c *gin.Context, d Decision) {
+	if d.Status == http.StatusTooManyRequests && d.RateLimit.Limit > 0 {
+		ratelimit.WriteHeaders(c.Writer.Header(), d.RateLimit)
+	}
+	c.AbortWithStatusJSON(d.Status, gin.H{"error": d.Reason})
+}
+
+// Middleware memvalidasi API key untuk scope tertentu. Jika key tidak dikirim dan
+// tidak wajib, request diteruskan sebagai anonim.
+func Middleware(store *KeyStore, opts Options, scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw := ExtractKey(c, opts)
+		if raw == "" {
+			if opts.Required {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "API key wajib dikirim lewat header " + opts.Header + "."})
+				return
+			}
+			c.Next()
+			return
+		}
+
+		decision := store.Authorize(raw, scope)
+		if !decision.Allowed {
+			Abort(c, decision)
+			return
+		}
+		if decision.RateLimit.Limit > 0 {
+			ratelimit.WriteHeaders(c.Writer.Header(), decision.RateLimit)
+		}
+		c.Set(ContextKeyName, decision.Key.Name)
+		c.Next()
+	}
+}
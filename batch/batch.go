@@ -0,0 +1,239 @@
+// Package batch menjalankan banyak lookup (detail anime, detail episode, pencarian) dalam satu request.
+// Operasi yang sama hanya dijalankan sekali dan semua request batch berbagi jumlah slot yang sama,
+// sehingga satu klien tidak bisa membanjiri situs sumber lewat satu request besar.
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Jenis operasi yang didukung.
+const (
+	OpAnimeDetail   = "anime-detail"
+	OpEpisodeDetail = "episode-detail"
+	OpSearch        = "search"
+)
+
+// Ops berisi semua jenis operasi, untuk pesan error dan dokumentasi.
+var Ops = []string{OpAnimeDetail, OpEpisodeDetail, OpSearch}
+
+// Operation adalah satu lookup di dalam batch.
+type Operation struct {
+	// ID opsional dari klien, dikembalikan apa adanya agar hasil mudah dicocokkan.
+	ID    string `json:"id,omitempty" example:"card-1"`
+	Op    string `json:"op" example:"anime-detail" enums:"anime-detail,episode-detail,search"`
+	Slug  string `json:"slug,omitempty" example:"naruto"`
+	URL   string `json:"url,omitempty" example:"https://gomunime.co/naruto-episode-1/"`
+	Query string `json:"query,omitempty" example:"naruto"`
+	Page  int    `json:"page,omitempty" example:"1"`
+}
+
+// Request adalah body POST /api/v1/batch.
+type Request struct {
+	Operations []Operation `json:"operations"`
+}
+
+// Result adalah hasil satu operasi. Response berisi body yang sama dengan endpoint tunggalnya.
+type Result struct {
+	ID              string          `json:"id,omitempty" example:"card-1"`
+	Op              string          `json:"op" example:"anime-detail"`
+	Status          int             `json:"status" example:"200"`
+	ConfidenceScore *float64        `json:"confidence_score,omitempty" example:"1"`
+	Response        json.RawMessage `json:"response,omitempty" swaggertype:"object"`
+	Error           string          `json:"error,omitempty"`
+	// Deduplicated bernilai true jika hasil diambil dari operasi identik sebelumnya di batch yang sama.
+	Deduplicated bool `json:"deduplicated,omitempty"`
+}
+
+// Summary merangkum hasil batch.
+type Summary struct {
+	Total     int `json:"total" example:"30"`
+	Unique    int `json:"unique" example:"28"`
+	Succeeded int `json:"succeeded" example:"27"`
+	Failed    int `json:"failed" example:"3"`
+}
+
+// Response adalah response POST /api/v1/batch.
+type Response struct {
+	Results []Result `json:"results"`
+	Summary Summary  `json:"summary"`
+	Message string   `json:"message"`
+	Source  string   `json:"source"`
+}
+
+// Validate mengecek field wajib untuk jenis operasinya.
+func (op Operation) Validate() error {
+	switch op.Op {
+	case OpAnimeDetail:
+		if strings.TrimSpace(op.Slug) == "" {
+			return errors.New("anime-detail membutuhkan 'slug'")
+		}
+	case OpEpisodeDetail:
+		if (strings.TrimSpace(op.Slug) == "") == (strings.TrimSpace(op.URL) == "") {
+			return errors.New("episode-detail membutuhkan salah satu dari 'slug' atau 'url'")
+		}
+	case OpSearch:
+		if strings.TrimSpace(op.Query) == "" {
+			return errors.New("search membutuhkan 'query'")
+		}
+		if op.Page < 0 {
+			return errors.New("'page' harus berupa angka positif")
+		}
+	default:
+		return fmt.Errorf("op '%s' tidak dikenal, gunakan salah satu dari: %s", op.Op, strings.Join(Ops, ", "))
+	}
+	return nil
+}
+
+// Key mengembalikan identitas operasi untuk menghapus duplikat. ID klien tidak ikut dihitung.
+func (op Operation) Key() string {
+	return strings.Join([]string{
+		op.Op,
+		strings.ToLower(strings.TrimSpace(op.Slug)),
+		strings.TrimSpace(op.URL),
+		strings.ToLower(strings.TrimSpace(op.Query)),
+		strconv.Itoa(op.Page),
+	}, "|")
+}
+
+// Executor menjalankan satu operasi yang sudah valid dan mengembalikan status HTTP beserta body JSON-nya.
+type Executor func(ctx context.Context, op Operation) (status int, body []byte)
+
+// Runner membatasi jumlah operasi yang berjalan bersamaan untuk semua request batch.
+type Runner struct {
+	slots    chan struct{}
+	inFlight int64
+	total    int64
+}
+
+// NewRunner membuat runner dengan jumlah slot bersama.
+func NewRunner(concurrency int) *Runner {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &Runner{slots: make(chan struct{}, concurrency)}
+}
+
+// outcome adalah hasil mentah satu operasi unik.
+type outcome struct {
+	status int
+	body   []byte
+}
+
+// Run menjalankan semua operasi dan mengembalikan hasil dengan urutan yang sama. Operasi yang tidak valid
+// mendapat status 400 dan operasi yang gagal tidak menggagalkan operasi lain.
+func (r *Runner) Run(ctx context.Context, ops []Operation, exec Executor) ([]Result, Summary) {
+	outcomes := make(map[string]*outcome)
+	var wg sync.WaitGroup
+	for _, op := range ops {
+		if op.Validate() != nil {
+			continue
+		}
+		key := op.Key()
+		if _, seen := outcomes[key]; seen {
+			continue
+		}
+		out := &outcome{}
+		outcomes[key] = out
+		wg.Add(1)
+		go func(op Operation, out *outcome) {
+			defer wg.Done()
+			out.status, out.body = r.execute(ctx, op, exec)
+		}(op, out)
+	}
+	wg.Wait()
+
+	results := make([]Result, len(ops))
+	summary := Summary{Total: len(ops), Unique: len(outcomes)}
+	used := make(map[string]bool, len(outcomes))
+	for i, op := range ops {
+		result := Result{ID: op.ID, Op: op.Op}
+		if err := op.Validate(); err != nil {
+			result.Status = http.StatusBadRequest
+			result.Error = err.Error()
+			summary.Unique++
+		} else {
+			key := op.Key()
+			out := outcomes[key]
+			result.Deduplicated = used[key]
+			used[key] = true
+			fillResult(&result, out.status, out.body)
+		}
+		if result.Status >= 200 && result.Status < 300 {
+			summary.Succeeded++
+		} else {
+			summary.Failed++
+		}
+		results[i] = result
+	}
+	return results, summary
+}
+
+// execute menunggu slot bersama lalu menjalankan operasi. Panic di executor diubah menjadi status 500
+// agar tidak menjatuhkan seluruh batch.
+func (r *Runner) execute(ctx context.Context, op Operation, exec Executor) (status int, body []byte) {
+	select {
+	case r.slots <- struct{}{}:
+	case <-ctx.Done():
+		return http.StatusServiceUnavailable, errorBody("Request dibatalkan sebelum operasi dijalankan.")
+	}
+	atomic.AddInt64(&r.inFlight, 1)
+	atomic.AddInt64(&r.total, 1)
+	defer func() {
+		atomic.AddInt64(&r.inFlight, -1)
+		<-r.slots
+		if rec := recover(); rec != nil {
+			log.Printf("Operasi batch %s panik: %v", op.Key(), rec)
+			status, body = http.StatusInternalServerError, errorBody("Terjadi kesalahan internal.")
+		}
+	}()
+	return exec(ctx, op)
+}
+
+// Stats mengembalikan jumlah operasi yang sedang dan sudah berjalan untuk monitoring.
+func (r *Runner) Stats() map[string]interface{} {
+	return map[string]interface{}{
+		"concurrency": cap(r.slots),
+		"in_flight":   atomic.LoadInt64(&r.inFlight),
+		"executed":    atomic.LoadInt64(&r.total),
+	}
+}
+
+// fillResult mengisi status, confidence score, dan response atau error dari body JSON endpoint tunggal.
+func fillResult(result *Result, status int, body []byte) {
+	result.Status = status
+	var parsed struct {
+		ConfidenceScore *float64 `json:"confidence_score"`
+		Error           string   `json:"error"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		if status < 300 {
+			result.Status = http.StatusBadGateway
+		}
+		result.Error = "Response operasi bukan JSON yang valid."
+		return
+	}
+	result.ConfidenceScore = parsed.ConfidenceScore
+	if status >= 200 && status < 300 {
+		result.Response = json.RawMessage(body)
+		return
+	}
+	result.Error = parsed.Error
+	if result.Error == "" {
+		result.Error = http.StatusText(status)
+	}
+}
+
+func errorBody(message string) []byte {
+	body, _ := json.Marshal(map[string]string{"error": message})
+	return body
+}
@@ -0,0 +1,136 @@
+package batch
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunDeduplicatesAndKeepsOrder(t *testing.T) {
+	var calls int32
+	exec := func(_ context.Context, op Operation) (int, []byte) {
+		atomic.AddInt32(&calls, 1)
+		switch op.Op {
+		case OpAnimeDetail:
+			if op.Slug == "hilang" {
+				return http.StatusNotFound, []byte(`{"error":"Anime dengan slug 'hilang' tidak ditemukan."}`)
+			}
+			return http.StatusOK, []byte(`{"confidence_score":0.8,"data":{"judul":"Naruto"}}`)
+		}
+		return http.StatusOK, []byte(`{"confidence_score":1,"data":[]}`)
+	}
+	ops := []Operation{
+		{ID: "a", Op: OpAnimeDetail, Slug: "naruto"},
+		{ID: "b", Op: OpAnimeDetail, Slug: "hilang"},
+		{ID: "c", Op: OpAnimeDetail, Slug: "Naruto "},
+		{ID: "d", Op: OpSearch},
+		{ID: "e", Op: "unknown"},
+		{ID: "f", Op: OpSearch, Query: "one piece", Page: 2},
+	}
+
+	results, summary := NewRunner(2).Run(context.Background(), ops, exec)
+	if atomic.LoadInt32(&calls) != 3 {
+		t.Errorf("operasi identik seharusnya dijalankan sekali, didapat %d panggilan", calls)
+	}
+	for i, op := range ops {
+		if results[i].ID != op.ID || results[i].Op != op.Op {
+			t.Errorf("hasil ke-%d seharusnya milik %s, didapat %+v", i, op.ID, results[i])
+		}
+	}
+	if r := results[0]; r.Status != 200 || r.ConfidenceScore == nil || *r.ConfidenceScore != 0.8 || !strings.Contains(string(r.Response), "Naruto") {
+		t.Errorf("hasil sukses tidak sesuai: %+v", r)
+	}
+	if r := results[1]; r.Status != 404 || r.Error == "" || r.Response != nil {
+		t.Errorf("kegagalan satu operasi seharusnya dilaporkan per item: %+v", r)
+	}
+	if r := results[2]; !r.Deduplicated || r.Status != 200 || results[0].Deduplicated {
+		t.Errorf("slug yang sama (beda huruf/spasi) seharusnya ditandai deduplicated: %+v", r)
+	}
+	if results[3].Status != 400 || !strings.Contains(results[3].Error, "query") || results[4].Status != 400 {
+		t.Errorf("operasi tidak valid seharusnya 400: %+v %+v", results[3], results[4])
+	}
+	want := Summary{Total: 6, Unique: 5, Succeeded: 3, Failed: 3}
+	if summary != want {
+		t.Errorf("ringkasan didapat %+v, seharusnya %+v", summary, want)
+	}
+}
+
+func TestRunnerSharesSlotsAcrossBatches(t *testing.T) {
+	var inFlight, maxInFlight int32
+	exec := func(_ context.Context, _ Operation) (int, []byte) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		return http.StatusOK, []byte(`{}`)
+	}
+	runner := NewRunner(3)
+
+	var wg sync.WaitGroup
+	for b := 0; b < 3; b++ {
+		wg.Add(1)
+		go func(b int) {
+			defer wg.Done()
+			var ops []Operation
+			for i := 0; i < 4; i++ {
+				ops = append(ops, Operation{Op: OpSearch, Query: strings.Repeat("x", b+1), Page: i + 1})
+			}
+			runner.Run(context.Background(), ops, exec)
+		}(b)
+	}
+	wg.Wait()
+	if max := atomic.LoadInt32(&maxInFlight); max > 3 {
+		t.Errorf("operasi bersamaan dari semua batch seharusnya paling banyak 3, didapat %d", max)
+	}
+	if stats := runner.Stats(); stats["executed"] != int64(12) || stats["in_flight"] != int64(0) {
+		t.Errorf("statistik runner tidak sesuai: %v", stats)
+	}
+}
+
+func TestRunRecoversPanicsAndRejectsInvalidJSON(t *testing.T) {
+	exec := func(_ context.Context, op Operation) (int, []byte) {
+		if op.Slug == "panik" {
+			panic("boom")
+		}
+		return http.StatusOK, []byte("bukan json")
+	}
+	results, _ := NewRunner(1).Run(context.Background(), []Operation{
+		{Op: OpEpisodeDetail, Slug: "panik"},
+		{Op: OpEpisodeDetail, URL: "https://gomunime.co/naruto-episode-1/"},
+		{Op: OpEpisodeDetail, Slug: "a", URL: "https://gomunime.co/a/"},
+	}, exec)
+
+	if results[0].Status != http.StatusInternalServerError {
+		t.Errorf("panic seharusnya menjadi 500, didapat %+v", results[0])
+	}
+	if results[1].Status != http.StatusBadGateway || results[1].Response != nil {
+		t.Errorf("body bukan JSON seharusnya menjadi 502, didapat %+v", results[1])
+	}
+	if results[2].Status != http.StatusBadRequest {
+		t.Errorf("slug dan url sekaligus seharusnya ditolak, didapat %+v", results[2])
+	}
+}
+
+func TestRunStopsWhenContextCancelled(t *testing.T) {
+	var calls int32
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	runner := NewRunner(1)
+	runner.slots <- struct{}{} // slot penuh, sehingga operasi harus menunggu
+	results, _ := runner.Run(ctx, []Operation{{Op: OpAnimeDetail, Slug: "naruto"}}, func(context.Context, Operation) (int, []byte) {
+		atomic.AddInt32(&calls, 1)
+		return http.StatusOK, []byte(`{}`)
+	})
+	if results[0].Status != http.StatusServiceUnavailable || atomic.LoadInt32(&calls) != 0 {
+		t.Errorf("operasi seharusnya dibatalkan tanpa dijalankan, didapat %+v", results[0])
+	}
+}
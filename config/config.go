@@ -0,0 +1,567 @@
+// Package config memuat konfigurasi aplikasi dari file YAML/TOML dan variabel lingkungan.
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFile adalah file konfigurasi yang dibaca jika CONFIG_FILE tidak diisi.
+const DefaultFile = "config.yaml"
+
+// Config adalah konfigurasi lengkap aplikasi.
+// Tag `env` menandai variabel lingkungan yang menimpa nilai dari file,
+// tag `secret` menandai nilai yang disensor di /admin/config.
+type Config struct {
+	Server       ServerConfig       `yaml:"server" toml:"server" json:"server"`
+	Source       SourceConfig       `yaml:"source" toml:"source" json:"source"`
+	Scraper      ScraperConfig      `yaml:"scraper" toml:"scraper" json:"scraper"`
+	Robots       RobotsConfig       `yaml:"robots" toml:"robots" json:"robots"`
+	Admin        AdminConfig        `yaml:"admin" toml:"admin" json:"admin"`
+	Auth         AuthConfig         `yaml:"auth" toml:"auth" json:"auth"`
+	RateLimit    RateLimitConfig    `yaml:"rate_limit" toml:"rate_limit" json:"rate_limit"`
+	Catalog      CatalogConfig      `yaml:"catalog" toml:"catalog" json:"catalog"`
+	Crawler      CrawlerConfig      `yaml:"crawler" toml:"crawler" json:"crawler"`
+	Webhooks     WebhooksConfig     `yaml:"webhooks" toml:"webhooks" json:"webhooks"`
+	Events       EventsConfig       `yaml:"events" toml:"events" json:"events"`
+	WebSocket    WebSocketConfig    `yaml:"websocket" toml:"websocket" json:"websocket"`
+	Watchlists   WatchlistsConfig   `yaml:"watchlists" toml:"watchlists" json:"watchlists"`
+	StreamHealth StreamHealthConfig `yaml:"stream_health" toml:"stream_health" json:"stream_health"`
+	Batch        BatchConfig        `yaml:"batch" toml:"batch" json:"batch"`
+}
+
+// ServerConfig mengatur alamat HTTP server.
+type ServerConfig struct {
+	BindAddress string `yaml:"bind_address" toml:"bind_address" json:"bind_address" env:"BIND_ADDRESS"`
+	Port        string `yaml:"port" toml:"port" json:"port" env:"PORT"`
+	// TrustedProxies adalah IP/CIDR proxy (nginx/Passenger) yang header X-Forwarded-For-nya dipercaya.
+	TrustedProxies []string `yaml:"trusted_proxies" toml:"trusted_proxies" json:"trusted_proxies" env:"TRUSTED_PROXIES"`
+}
+
+// SourceConfig mengatur situs sumber yang di-scrape.
+type SourceConfig struct {
+	Name         string `yaml:"name" toml:"name" json:"name" env:"SOURCE_NAME"`
+	BaseDomain   string `yaml:"base_domain" toml:"base_domain" json:"base_domain" env:"BASE_DOMAIN"`
+	AjaxPath     string `yaml:"ajax_path" toml:"ajax_path" json:"ajax_path" env:"SOURCE_AJAX_PATH"`
+	SchedulePath string `yaml:"schedule_path" toml:"schedule_path" json:"schedule_path" env:"SOURCE_SCHEDULE_PATH"`
+	UserAgent    string `yaml:"user_agent" toml:"user_agent" json:"user_agent" env:"SCRAPER_USER_AGENT"`
+	// Mirrors adalah domain cadangan yang dicoba jika base_domain gagal atau redirect.
+	Mirrors       []string `yaml:"mirrors" toml:"mirrors" json:"mirrors" env:"SOURCE_MIRRORS"`
+	ProbeInterval Duration `yaml:"probe_interval" toml:"probe_interval" json:"probe_interval" env:"MIRROR_PROBE_INTERVAL"`
+}
+
+// ScraperConfig mengatur perilaku collector colly.
+type ScraperConfig struct {
+	CacheDir          string   `yaml:"cache_dir" toml:"cache_dir" json:"cache_dir" env:"CACHE_DIR"`
+	RequestTimeout    Duration `yaml:"request_timeout" toml:"request_timeout" json:"request_timeout" env:"REQUEST_TIMEOUT"`
+	MinDelay          Duration `yaml:"min_delay" toml:"min_delay" json:"min_delay" env:"SCRAPER_MIN_DELAY"`
+	LatestParallelism int      `yaml:"latest_parallelism" toml:"latest_parallelism" json:"latest_parallelism" env:"LATEST_PARALLELISM"`
+	PageParallelism   int      `yaml:"page_parallelism" toml:"page_parallelism" json:"page_parallelism" env:"PAGE_PARALLELISM"`
+	SearchParallelism int      `yaml:"search_parallelism" toml:"search_parallelism" json:"search_parallelism" env:"SEARCH_PARALLELISM"`
+}
+
+// RobotsConfig mengatur kepatuhan terhadap robots.txt.
+type RobotsConfig struct {
+	RefreshInterval Duration `yaml:"refresh_interval" toml:"refresh_interval" json:"refresh_interval" env:"ROBOTS_REFRESH_INTERVAL"`
+	OverridePaths   []string `yaml:"override_paths" toml:"override_paths" json:"override_paths" env:"ROBOTS_OVERRIDE_PATHS"`
+}
+
+// AdminConfig mengatur akses ke endpoint /admin.
+type AdminConfig struct {
+	Token string `yaml:"token" toml:"token" json:"token" env:"ADMIN_TOKEN" secret:"true"`
+}
+
+// AuthConfig mengatur autentikasi API key untuk /api/v1.
+type AuthConfig struct {
+	// Required=false berarti request tanpa key tetap dilayani sebagai anonim.
+	Required          bool   `yaml:"required" toml:"required" json:"required" env:"AUTH_REQUIRED"`
+	KeysFile          string `yaml:"keys_file" toml:"keys_file" json:"keys_file" env:"API_KEYS_FILE"`
+	Header            string `yaml:"header" toml:"header" json:"header" env:"API_KEY_HEADER"`
+	QueryParam        string `yaml:"query_param" toml:"query_param" json:"query_param" env:"API_KEY_QUERY_PARAM"`
+	DefaultRateLimit  int    `yaml:"default_rate_limit_per_minute" toml:"default_rate_limit_per_minute" json:"default_rate_limit_per_minute" env:"API_KEY_DEFAULT_RATE_LIMIT"`
+	DefaultDailyQuota int    `yaml:"default_daily_quota" toml:"default_daily_quota" json:"default_daily_quota" env:"API_KEY_DEFAULT_DAILY_QUOTA"`
+}
+
+// RateLimitConfig mengatur batas request per IP klien untuk setiap kelas route.
+type RateLimitConfig struct {
+	Enabled         bool `yaml:"enabled" toml:"enabled" json:"enabled" env:"RATE_LIMIT_ENABLED"`
+	ListPerMinute   int  `yaml:"list_per_minute" toml:"list_per_minute" json:"list_per_minute" env:"RATE_LIMIT_REQUESTS_PER_MINUTE"`
+	SearchPerMinute int  `yaml:"search_per_minute" toml:"search_per_minute" json:"search_per_minute" env:"RATE_LIMIT_SEARCH_PER_MINUTE"`
+	DetailPerMinute int  `yaml:"detail_per_minute" toml:"detail_per_minute" json:"detail_per_minute" env:"RATE_LIMIT_DETAIL_PER_MINUTE"`
+}
+
+// CatalogConfig mengatur database katalog anime, episode, dan stream.
+// Detail yang lebih muda dari TTL dilayani dari katalog tanpa scrape ulang.
+type CatalogConfig struct {
+	Enabled    bool     `yaml:"enabled" toml:"enabled" json:"enabled" env:"CATALOG_ENABLED"`
+	Path       string   `yaml:"path" toml:"path" json:"path" env:"CATALOG_PATH"`
+	AnimeTTL   Duration `yaml:"anime_ttl" toml:"anime_ttl" json:"anime_ttl" env:"CATALOG_ANIME_TTL"`
+	EpisodeTTL Duration `yaml:"episode_ttl" toml:"episode_ttl" json:"episode_ttl" env:"CATALOG_EPISODE_TTL"`
+}
+
+// CrawlerConfig mengatur crawler background yang menjaga katalog tetap hangat.
+type CrawlerConfig struct {
+	Enabled          bool     `yaml:"enabled" toml:"enabled" json:"enabled" env:"CRAWLER_ENABLED"`
+	HomeInterval     Duration `yaml:"home_interval" toml:"home_interval" json:"home_interval" env:"CRAWLER_HOME_INTERVAL"`
+	LatestPages      int      `yaml:"latest_pages" toml:"latest_pages" json:"latest_pages" env:"CRAWLER_LATEST_PAGES"`
+	ScheduleInterval Duration `yaml:"schedule_interval" toml:"schedule_interval" json:"schedule_interval" env:"CRAWLER_SCHEDULE_INTERVAL"`
+	AiringMaxAge     Duration `yaml:"airing_max_age" toml:"airing_max_age" json:"airing_max_age" env:"CRAWLER_AIRING_MAX_AGE"`
+	Jitter           float64  `yaml:"jitter" toml:"jitter" json:"jitter" env:"CRAWLER_JITTER"`
+	JobDelay         Duration `yaml:"job_delay" toml:"job_delay" json:"job_delay" env:"CRAWLER_JOB_DELAY"`
+	BudgetPerHour    int      `yaml:"budget_per_hour" toml:"budget_per_hour" json:"budget_per_hour" env:"CRAWLER_BUDGET_PER_HOUR"`
+	MaxQueue         int      `yaml:"max_queue" toml:"max_queue" json:"max_queue" env:"CRAWLER_MAX_QUEUE"`
+}
+
+// WebhooksConfig mengatur pengiriman event ke webhook yang didaftarkan lewat /admin/webhooks.
+type WebhooksConfig struct {
+	File            string   `yaml:"file" toml:"file" json:"file" env:"WEBHOOKS_FILE"`
+	Timeout         Duration `yaml:"timeout" toml:"timeout" json:"timeout" env:"WEBHOOK_TIMEOUT"`
+	MaxAttempts     int      `yaml:"max_attempts" toml:"max_attempts" json:"max_attempts" env:"WEBHOOK_MAX_ATTEMPTS"`
+	RetryBackoff    Duration `yaml:"retry_backoff" toml:"retry_backoff" json:"retry_backoff" env:"WEBHOOK_RETRY_BACKOFF"`
+	Workers         int      `yaml:"workers" toml:"workers" json:"workers" env:"WEBHOOK_WORKERS"`
+	DeliveryLogSize int      `yaml:"delivery_log_size" toml:"delivery_log_size" json:"delivery_log_size" env:"WEBHOOK_DELIVERY_LOG_SIZE"`
+}
+
+// EventsConfig mengatur kanal push event live (SSE).
+type EventsConfig struct {
+	ReplaySize    int      `yaml:"replay_size" toml:"replay_size" json:"replay_size" env:"EVENTS_REPLAY_SIZE"`
+	Heartbeat     Duration `yaml:"heartbeat" toml:"heartbeat" json:"heartbeat" env:"EVENTS_HEARTBEAT"`
+	ClientBuffer  int      `yaml:"client_buffer" toml:"client_buffer" json:"client_buffer" env:"EVENTS_CLIENT_BUFFER"`
+	MaxSSEClients int      `yaml:"max_sse_clients" toml:"max_sse_clients" json:"max_sse_clients" env:"EVENTS_MAX_SSE_CLIENTS"`
+}
+
+// WebSocketConfig mengatur API langganan event per anime lewat WebSocket.
+type WebSocketConfig struct {
+	MaxClients       int      `yaml:"max_clients" toml:"max_clients" json:"max_clients" env:"WS_MAX_CLIENTS"`
+	MaxSubscriptions int      `yaml:"max_subscriptions" toml:"max_subscriptions" json:"max_subscriptions" env:"WS_MAX_SUBSCRIPTIONS"`
+	SendBuffer       int      `yaml:"send_buffer" toml:"send_buffer" json:"send_buffer" env:"WS_SEND_BUFFER"`
+	PingInterval     Duration `yaml:"ping_interval" toml:"ping_interval" json:"ping_interval" env:"WS_PING_INTERVAL"`
+	MaxMessageBytes  int      `yaml:"max_message_bytes" toml:"max_message_bytes" json:"max_message_bytes" env:"WS_MAX_MESSAGE_BYTES"`
+}
+
+// WatchlistsConfig mengatur penyimpanan watchlist pengguna.
+type WatchlistsConfig struct {
+	File     string `yaml:"file" toml:"file" json:"file" env:"WATCHLISTS_FILE"`
+	MaxAnime int    `yaml:"max_anime" toml:"max_anime" json:"max_anime" env:"WATCHLIST_MAX_ANIME"`
+}
+
+// StreamHealthConfig mengatur pengecekan link streaming dan download episode.
+type StreamHealthConfig struct {
+	Concurrency int      `yaml:"concurrency" toml:"concurrency" json:"concurrency" env:"STREAM_HEALTH_CONCURRENCY"`
+	Timeout     Duration `yaml:"timeout" toml:"timeout" json:"timeout" env:"STREAM_HEALTH_TIMEOUT"`
+	CacheTTL    Duration `yaml:"cache_ttl" toml:"cache_ttl" json:"cache_ttl" env:"STREAM_HEALTH_CACHE_TTL"`
+}
+
+// BatchConfig mengatur endpoint batch yang menjalankan banyak lookup dalam satu request.
+type BatchConfig struct {
+	MaxOperations int `yaml:"max_operations" toml:"max_operations" json:"max_operations" env:"BATCH_MAX_OPERATIONS"`
+	Concurrency   int `yaml:"concurrency" toml:"concurrency" json:"concurrency" env:"BATCH_CONCURRENCY"`
+}
+
+// Duration adalah time.Duration yang bisa dibaca dari string seperti "30s" atau "6h".
+type Duration time.Duration
+
+// UnmarshalText mem-parse durasi dari file konfigurasi.
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(strings.TrimSpace(string(text)))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalText menulis durasi dalam format yang sama dengan file konfigurasi.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// Std mengembalikan nilai sebagai time.Duration.
+func (d Duration) Std() time.Duration {
+	return time.Duration(d)
+}
+
+// Default mengembalikan konfigurasi bawaan yang sama dengan nilai hardcode sebelumnya.
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			BindAddress:    "127.0.0.1", // DOM Cloud compatibility
+			Port:           "8080",
+			TrustedProxies: []string{"127.0.0.1", "::1"},
+		},
+		Source: SourceConfig{
+			Name:          "gomunime.co",
+			BaseDomain:    "https://gomunime.co",
+			AjaxPath:      "/wp-admin/admin-ajax.php",
+			SchedulePath:  "/schedule/",
+			UserAgent:     "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/115.0.0.0 Safari/537.36",
+			ProbeInterval: Duration(5 * time.Minute),
+		},
+		Scraper: ScraperConfig{
+			CacheDir:          "./cache",
+			RequestTimeout:    Duration(30 * time.Second),
+			MinDelay:          Duration(100 * time.Millisecond),
+			LatestParallelism: 12,
+			PageParallelism:   15,
+			SearchParallelism: 4,
+		},
+		Robots: RobotsConfig{
+			RefreshInterval: Duration(6 * time.Hour),
+		},
+		Auth: AuthConfig{
+			KeysFile:         "./data/api_keys.json",
+			Header:           "X-API-Key",
+			QueryParam:       "api_key",
+			DefaultRateLimit: 120,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:         true,
+			ListPerMinute:   60,
+			SearchPerMinute: 30,
+			DetailPerMinute: 20,
+		},
+		Catalog: CatalogConfig{
+			Enabled:    true,
+			Path:       "./data/catalog.db",
+			AnimeTTL:   Duration(6 * time.Hour),
+			EpisodeTTL: Duration(12 * time.Hour),
+		},
+		Crawler: CrawlerConfig{
+			Enabled:          true,
+			HomeInterval:     Duration(5 * time.Minute),
+			LatestPages:      3,
+			ScheduleInterval: Duration(time.Hour),
+			AiringMaxAge:     Duration(6 * time.Hour),
+			Jitter:           0.2,
+			JobDelay:         Duration(3 * time.Second),
+			BudgetPerHour:    300,
+			MaxQueue:         500,
+		},
+		Webhooks: WebhooksConfig{
+			File:            "./data/webhooks.json",
+			Timeout:         Duration(10 * time.Second),
+			MaxAttempts:     5,
+			RetryBackoff:    Duration(5 * time.Second),
+			Workers:         2,
+			DeliveryLogSize: 500,
+		},
+		Events: EventsConfig{
+			ReplaySize:    500,
+			Heartbeat:     Duration(25 * time.Second),
+			ClientBuffer:  64,
+			MaxSSEClients: 200,
+		},
+		WebSocket: WebSocketConfig{
+			MaxClients:       200,
+			MaxSubscriptions: 50,
+			SendBuffer:       64,
+			PingInterval:     Duration(30 * time.Second),
+			MaxMessageBytes:  4096,
+		},
+		Watchlists: WatchlistsConfig{
+			File:     "./data/watchlists.json",
+			MaxAnime: 100,
+		},
+		StreamHealth: StreamHealthConfig{
+			Concurrency: 8,
+			Timeout:     Duration(8 * time.Second),
+			CacheTTL:    Duration(10 * time.Minute),
+		},
+		Batch: BatchConfig{
+			MaxOperations: 30,
+			Concurrency:   6,
+		},
+	}
+}
+
+var (
+	current   = Default()
+	currentMu sync.RWMutex
+)
+
+// Current mengembalikan konfigurasi yang sedang aktif.
+func Current() *Config {
+	currentMu.RLock()
+	defer currentMu.RUnlock()
+	return current
+}
+
+// Set mengganti konfigurasi aktif. Dipanggil sekali saat startup.
+func Set(cfg *Config) {
+	currentMu.Lock()
+	current = cfg
+	currentMu.Unlock()
+}
+
+// Load membaca file konfigurasi (jika ada), menerapkan override env, lalu memvalidasi hasilnya.
+// Path kosong berarti DefaultFile; file default yang tidak ada tidak dianggap error.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	explicit := path != ""
+	if !explicit {
+		path = DefaultFile
+	}
+
+	raw, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := decode(path, raw, cfg); err != nil {
+			return nil, fmt.Errorf("gagal membaca %s: %w", path, err)
+		}
+	case os.IsNotExist(err) && !explicit:
+		// Tidak ada file konfigurasi, pakai default + env
+	default:
+		return nil, fmt.Errorf("gagal membuka %s: %w", path, err)
+	}
+
+	if err := applyEnv(reflect.ValueOf(cfg).Elem()); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func decode(path string, raw []byte, cfg *Config) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		return toml.Unmarshal(raw, cfg)
+	case ".yaml", ".yml":
+		return yaml.Unmarshal(raw, cfg)
+	default:
+		return fmt.Errorf("format file tidak dikenal (gunakan .yaml, .yml, atau .toml)")
+	}
+}
+
+// applyEnv menimpa setiap field yang punya tag `env` jika variabelnya diisi.
+func applyEnv(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+
+		if field.Type.Kind() == reflect.Struct && field.Tag.Get("env") == "" {
+			if err := applyEnv(value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := field.Tag.Get("env")
+		raw, ok := os.LookupEnv(name)
+		if name == "" || !ok || raw == "" {
+			continue
+		}
+		if err := setFromString(value, raw); err != nil {
+			return fmt.Errorf("variabel %s tidak valid: %w", name, err)
+		}
+	}
+	return nil
+}
+
+var durationType = reflect.TypeOf(Duration(0))
+
+func setFromString(value reflect.Value, raw string) error {
+	if value.Type() == durationType {
+		var d Duration
+		if err := d.UnmarshalText([]byte(raw)); err != nil {
+			return err
+		}
+		value.Set(reflect.ValueOf(d))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Int:
+		n, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return err
+		}
+		value.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		value.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("tipe %s tidak didukung", value.Kind())
+	}
+	return nil
+}
+
+// Validate memastikan konfigurasi bisa dipakai sebelum server berjalan.
+func (c *Config) Validate() error {
+	var problems []string
+
+	if net.ParseIP(c.Server.BindAddress) == nil && c.Server.BindAddress != "localhost" {
+		problems = append(problems, "server.bind_address harus berupa alamat IP")
+	}
+	if port, err := strconv.Atoi(c.Server.Port); err != nil || port < 1 || port > 65535 {
+		problems = append(problems, "server.port harus angka 1-65535")
+	}
+
+	if u, err := url.Parse(c.Source.BaseDomain); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		problems = append(problems, "source.base_domain harus URL http(s) absolut")
+	}
+	for _, mirror := range c.Source.Mirrors {
+		if u, err := url.Parse(mirror); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("source.mirrors berisi URL tidak valid: %q", mirror))
+		}
+	}
+	if c.Source.ProbeInterval <= 0 {
+		problems = append(problems, "source.probe_interval harus lebih dari 0")
+	}
+	if c.Source.Name == "" {
+		problems = append(problems, "source.name wajib diisi")
+	}
+	if c.Source.UserAgent == "" {
+		problems = append(problems, "source.user_agent wajib diisi")
+	}
+
+	if c.Scraper.CacheDir == "" {
+		problems = append(problems, "scraper.cache_dir wajib diisi")
+	}
+	if c.Scraper.RequestTimeout <= 0 {
+		problems = append(problems, "scraper.request_timeout harus lebih dari 0")
+	}
+	if c.Scraper.MinDelay < 0 {
+		problems = append(problems, "scraper.min_delay tidak boleh negatif")
+	}
+	if c.Scraper.LatestParallelism < 1 || c.Scraper.PageParallelism < 1 || c.Scraper.SearchParallelism < 1 {
+		problems = append(problems, "scraper.*_parallelism minimal 1")
+	}
+
+	if c.Robots.RefreshInterval <= 0 {
+		problems = append(problems, "robots.refresh_interval harus lebih dari 0")
+	}
+
+	if c.Auth.Header == "" {
+		problems = append(problems, "auth.header wajib diisi")
+	}
+	if c.Auth.Required && c.Auth.KeysFile == "" {
+		problems = append(problems, "auth.keys_file wajib diisi jika auth.required aktif")
+	}
+	if c.Auth.DefaultRateLimit < 0 || c.Auth.DefaultDailyQuota < 0 {
+		problems = append(problems, "auth.default_* tidak boleh negatif")
+	}
+
+	for _, proxy := range c.Server.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			problems = append(problems, fmt.Sprintf("server.trusted_proxies berisi alamat tidak valid: %q", proxy))
+		}
+	}
+	if c.RateLimit.ListPerMinute < 0 || c.RateLimit.SearchPerMinute < 0 || c.RateLimit.DetailPerMinute < 0 {
+		problems = append(problems, "rate_limit.*_per_minute tidak boleh negatif")
+	}
+	if c.Catalog.Enabled && c.Catalog.Path == "" {
+		problems = append(problems, "catalog.path wajib diisi jika catalog.enabled aktif")
+	}
+	if c.Catalog.AnimeTTL < 0 || c.Catalog.EpisodeTTL < 0 {
+		problems = append(problems, "catalog.*_ttl tidak boleh negatif")
+	}
+	if c.Crawler.Enabled {
+		if c.Crawler.HomeInterval <= 0 || c.Crawler.ScheduleInterval <= 0 {
+			problems = append(problems, "crawler.*_interval harus lebih dari 0")
+		}
+		if c.Crawler.Jitter < 0 || c.Crawler.Jitter >= 1 {
+			problems = append(problems, "crawler.jitter harus di antara 0 dan 1")
+		}
+		if c.Crawler.LatestPages < 0 || c.Crawler.BudgetPerHour < 0 || c.Crawler.MaxQueue < 0 {
+			problems = append(problems, "crawler.latest_pages, budget_per_hour, dan max_queue tidak boleh negatif")
+		}
+	}
+	if c.Webhooks.File == "" {
+		problems = append(problems, "webhooks.file wajib diisi")
+	}
+	if c.Webhooks.MaxAttempts < 1 || c.Webhooks.Workers < 1 {
+		problems = append(problems, "webhooks.max_attempts dan webhooks.workers minimal 1")
+	}
+	if c.Events.ReplaySize < 1 || c.Events.ClientBuffer < 1 || c.Events.Heartbeat <= 0 {
+		problems = append(problems, "events.replay_size, client_buffer, dan heartbeat harus lebih dari 0")
+	}
+	if c.WebSocket.MaxSubscriptions < 1 || c.WebSocket.SendBuffer < 1 || c.WebSocket.PingInterval <= 0 || c.WebSocket.MaxMessageBytes < 1 {
+		problems = append(problems, "websocket.max_subscriptions, send_buffer, ping_interval, dan max_message_bytes harus lebih dari 0")
+	}
+
+	if c.Watchlists.File == "" {
+		problems = append(problems, "watchlists.file wajib diisi")
+	}
+	if c.Watchlists.MaxAnime < 0 {
+		problems = append(problems, "watchlists.max_anime tidak boleh negatif")
+	}
+	if c.StreamHealth.Concurrency < 1 || c.StreamHealth.Timeout <= 0 || c.StreamHealth.CacheTTL <= 0 {
+		problems = append(problems, "stream_health.concurrency, timeout, dan cache_ttl harus lebih dari 0")
+	}
+	if c.Batch.MaxOperations < 1 || c.Batch.Concurrency < 1 {
+		problems = append(problems, "batch.max_operations dan batch.concurrency harus lebih dari 0")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("konfigurasi tidak valid: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Redacted mengembalikan konfigurasi dalam bentuk map dengan nilai rahasia disensor.
+func (c *Config) Redacted() map[string]interface{} {
+	return redact(reflect.ValueOf(c).Elem())
+}
+
+func redact(v reflect.Value) map[string]interface{} {
+	out := make(map[string]interface{})
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" {
+			name = field.Name
+		}
+
+		switch {
+		case field.Tag.Get("secret") == "true":
+			if value.IsZero() {
+				out[name] = ""
+			} else {
+				out[name] = "***"
+			}
+		case value.Type() == durationType:
+			out[name] = value.Interface().(Duration).Std().String()
+		case value.Kind() == reflect.Struct:
+			out[name] = redact(value)
+		default:
+			out[name] = value.Interface()
+		}
+	}
+	return out
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadYAMLWithEnvOverride(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+server:
+  port: "9090"
+source:
+  base_domain: https://mirror.example
+scraper:
+  request_timeout: 5s
+robots:
+  override_paths: ["/wp-admin/admin-ajax.php"]
+`)
+	t.Setenv("PORT", "7070")
+	t.Setenv("ADMIN_TOKEN", "rahasia")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load gagal: %v", err)
+	}
+	if cfg.Server.Port != "7070" {
+		t.Errorf("env PORT seharusnya menimpa file, didapat %s", cfg.Server.Port)
+	}
+	if cfg.Source.BaseDomain != "https://mirror.example" {
+		t.Errorf("base_domain dari file tidak terbaca: %s", cfg.Source.BaseDomain)
+	}
+	if cfg.Scraper.RequestTimeout.Std() != 5*time.Second {
+		t.Errorf("request_timeout seharusnya 5s, didapat %v", cfg.Scraper.RequestTimeout.Std())
+	}
+	if cfg.Scraper.PageParallelism != 15 {
+		t.Errorf("nilai default seharusnya dipertahankan, didapat %d", cfg.Scraper.PageParallelism)
+	}
+	if len(cfg.Robots.OverridePaths) != 1 {
+		t.Errorf("override_paths tidak terbaca: %v", cfg.Robots.OverridePaths)
+	}
+
+	redacted := cfg.Redacted()
+	if got := redacted["admin"].(map[string]interface{})["token"]; got != "***" {
+		t.Errorf("token seharusnya disensor, didapat %v", got)
+	}
+}
+
+func TestLoadTOML(t *testing.T) {
+	path := writeFile(t, "config.toml", `
+[source]
+name = "mirror.example"
+
+[robots]
+refresh_interval = "1h"
+`)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load gagal: %v", err)
+	}
+	if cfg.Source.Name != "mirror.example" || cfg.Robots.RefreshInterval.Std() != time.Hour {
+		t.Errorf("nilai TOML tidak terbaca: %+v", cfg)
+	}
+}
+
+func TestValidateRejectsBadValues(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+source:
+  base_domain: gomunime.co
+scraper:
+  page_parallelism: 0
+`)
+	if _, err := Load(path); err == nil {
+		t.Fatal("konfigurasi tidak valid seharusnya ditolak")
+	}
+}
@@ -0,0 +1,351 @@
+// Package crawler menjalankan scrape berkala di background agar katalog dan cache tetap hangat.
+package crawler
+
+import (
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"multiplescrape/ratelimit"
+)
+
+// Job adalah satu pekerjaan scrape di antrean. Kind menentukan handler, Target adalah argumennya.
+type Job struct {
+	Kind       string    `json:"kind"`
+	Target     string    `json:"target"`
+	EnqueuedAt time.Time `json:"enqueued_at"`
+}
+
+func (j Job) key() string {
+	return j.Kind + "|" + j.Target
+}
+
+// Handler menjalankan satu job dan mengembalikan job lanjutan yang perlu diantrekan.
+type Handler func(target string) ([]Job, error)
+
+// Task adalah pekerjaan berkala yang mengantrekan job setiap Interval (ditambah jitter).
+type Task struct {
+	Name     string
+	Interval time.Duration
+	Jobs     func() []Job
+}
+
+// TaskStatus adalah status satu task berkala untuk endpoint admin.
+type TaskStatus struct {
+	Name     string    `json:"name"`
+	Interval string    `json:"interval"`
+	Runs     int       `json:"runs"`
+	LastRun  time.Time `json:"last_run,omitempty"`
+	NextRun  time.Time `json:"next_run"`
+}
+
+// Status adalah ringkasan kondisi crawler beserta isi antrean.
+type Status struct {
+	Started         bool         `json:"started"`
+	Paused          bool         `json:"paused"`
+	Running         *Job         `json:"running,omitempty"`
+	QueueLength     int          `json:"queue_length"`
+	Queue           []Job        `json:"queue"`
+	Tasks           []TaskStatus `json:"tasks"`
+	Processed       int64        `json:"processed"`
+	Failed          int64        `json:"failed"`
+	Dropped         int64        `json:"dropped"`
+	LastError       string       `json:"last_error,omitempty"`
+	BudgetPerHour   int          `json:"budget_per_hour"`
+	BudgetRemaining int          `json:"budget_remaining"`
+}
+
+// Options mengatur kesopanan crawler terhadap situs sumber.
+type Options struct {
+	// Jitter adalah fraksi acak (0-1) yang ditambahkan/dikurangkan dari interval task dan jeda job.
+	Jitter float64
+	// JobDelay adalah jeda antar job.
+	JobDelay time.Duration
+	// BudgetPerHour membatasi jumlah job per jam untuk semua task. 0 = tanpa batas.
+	BudgetPerHour int
+	// MaxQueue membatasi panjang antrean; job baru dibuang jika penuh.
+	MaxQueue int
+}
+
+type taskState struct {
+	task    Task
+	runs    int
+	lastRun time.Time
+	nextRun time.Time
+}
+
+// Crawler menyimpan antrean job dan menjalankannya satu per satu di background.
+type Crawler struct {
+	mu       sync.Mutex
+	opts     Options
+	handlers map[string]Handler
+	tasks    []*taskState
+	queue    []Job
+	queued   map[string]bool
+	running  *Job
+	started  bool
+	paused   bool
+	budget   *ratelimit.Bucket
+
+	// randMu terpisah dari mu karena jitter dipanggil baik saat mu dipegang maupun tidak.
+	randMu sync.Mutex
+	rand   *rand.Rand
+
+	processed int64
+	failed    int64
+	dropped   int64
+	lastError string
+
+	wake chan struct{}
+}
+
+// New membuat crawler tanpa task. Pasang handler dan task sebelum Start.
+func New(opts Options) *Crawler {
+	c := &Crawler{
+		opts:     opts,
+		handlers: make(map[string]Handler),
+		queued:   make(map[string]bool),
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		wake:     make(chan struct{}, 1),
+	}
+	if opts.BudgetPerHour > 0 {
+		// Burst dibatasi 10 menit budget agar job tidak menumpuk di awal jam.
+		burst := opts.BudgetPerHour / 6
+		if burst < 1 {
+			burst = 1
+		}
+		c.budget = ratelimit.NewBucket(float64(opts.BudgetPerHour)/3600, burst)
+	}
+	return c
+}
+
+// Handle memasang handler untuk satu jenis job.
+func (c *Crawler) Handle(kind string, h Handler) {
+	c.mu.Lock()
+	c.handlers[kind] = h
+	c.mu.Unlock()
+}
+
+// Schedule menambahkan task berkala. Task pertama kali dijalankan segera setelah Start.
+func (c *Crawler) Schedule(task Task) {
+	c.mu.Lock()
+	c.tasks = append(c.tasks, &taskState{task: task, nextRun: time.Now()})
+	c.mu.Unlock()
+}
+
+// Enqueue menambahkan job ke antrean. Job yang sudah ada di antrean atau sedang berjalan dilewati.
+// Mengembalikan jumlah job yang benar-benar ditambahkan.
+func (c *Crawler) Enqueue(jobs ...Job) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	added := 0
+	for _, job := range jobs {
+		if c.queued[job.key()] || (c.running != nil && c.running.key() == job.key()) {
+			continue
+		}
+		if c.opts.MaxQueue > 0 && len(c.queue) >= c.opts.MaxQueue {
+			c.dropped++
+			continue
+		}
+		if job.EnqueuedAt.IsZero() {
+			job.EnqueuedAt = time.Now()
+		}
+		c.queue = append(c.queue, job)
+		c.queued[job.key()] = true
+		added++
+	}
+	if added > 0 {
+		c.signal()
+	}
+	return added
+}
+
+// Pause menghentikan pemrosesan antrean. Job yang sedang berjalan tetap diselesaikan.
+func (c *Crawler) Pause() {
+	c.mu.Lock()
+	c.paused = true
+	c.mu.Unlock()
+}
+
+// Resume melanjutkan pemrosesan antrean.
+func (c *Crawler) Resume() {
+	c.mu.Lock()
+	c.paused = false
+	c.mu.Unlock()
+	c.signal()
+}
+
+// Status mengembalikan kondisi crawler saat ini.
+func (c *Crawler) Status() Status {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	status := Status{
+		Started:       c.started,
+		Paused:        c.paused,
+		QueueLength:   len(c.queue),
+		Queue:         append([]Job{}, c.queue...),
+		Processed:     c.processed,
+		Failed:        c.failed,
+		Dropped:       c.dropped,
+		LastError:     c.lastError,
+		BudgetPerHour: c.opts.BudgetPerHour,
+	}
+	if c.running != nil {
+		running := *c.running
+		status.Running = &running
+	}
+	if c.budget != nil {
+		status.BudgetRemaining = c.budget.Take(0).Remaining
+	}
+	for _, st := range c.tasks {
+		status.Tasks = append(status.Tasks, TaskStatus{
+			Name:     st.task.Name,
+			Interval: st.task.Interval.String(),
+			Runs:     st.runs,
+			LastRun:  st.lastRun,
+			NextRun:  st.nextRun,
+		})
+	}
+	return status
+}
+
+// Start menjalankan scheduler dan worker di background. Fungsi yang dikembalikan menghentikannya.
+func (c *Crawler) Start() func() {
+	c.mu.Lock()
+	c.started = true
+	c.mu.Unlock()
+
+	stop := make(chan struct{})
+	go c.scheduleLoop(stop)
+	go c.workLoop(stop)
+	return func() {
+		close(stop)
+		c.mu.Lock()
+		c.started = false
+		c.mu.Unlock()
+	}
+}
+
+func (c *Crawler) signal() {
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
+
+// jitter mengacak durasi sebesar ±opts.Jitter.
+func (c *Crawler) jitter(d time.Duration) time.Duration {
+	if c.opts.Jitter <= 0 || d <= 0 {
+		return d
+	}
+	c.randMu.Lock()
+	factor := 1 + (c.rand.Float64()*2-1)*c.opts.Jitter
+	c.randMu.Unlock()
+	return time.Duration(float64(d) * factor)
+}
+
+func (c *Crawler) scheduleLoop(stop <-chan struct{}) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		c.runDueTasks(time.Now())
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// runDueTasks mengantrekan job dari task yang sudah jatuh tempo.
+func (c *Crawler) runDueTasks(now time.Time) {
+	c.mu.Lock()
+	var due []Task
+	for _, st := range c.tasks {
+		if now.Before(st.nextRun) {
+			continue
+		}
+		st.runs++
+		st.lastRun = now
+		st.nextRun = now.Add(c.jitter(st.task.Interval))
+		due = append(due, st.task)
+	}
+	c.mu.Unlock()
+
+	for _, task := range due {
+		c.Enqueue(task.Jobs()...)
+	}
+}
+
+func (c *Crawler) workLoop(stop <-chan struct{}) {
+	for {
+		wait := c.RunNext()
+		if wait == 0 {
+			wait = c.jitter(c.opts.JobDelay)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-c.wake:
+			timer.Stop()
+		case <-stop:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// idleWait adalah lama worker menunggu saat antrean kosong atau crawler dijeda.
+const idleWait = 30 * time.Second
+
+// RunNext menjalankan satu job dari antrean. Mengembalikan 0 jika job dijalankan,
+// atau lama yang perlu ditunggu sebelum mencoba lagi (antrean kosong, dijeda, atau budget habis).
+func (c *Crawler) RunNext() time.Duration {
+	c.mu.Lock()
+	if c.paused || len(c.queue) == 0 {
+		c.mu.Unlock()
+		return idleWait
+	}
+	if c.budget != nil {
+		if res := c.budget.Take(1); !res.Allowed {
+			c.mu.Unlock()
+			return res.RetryAfter
+		}
+	}
+	job := c.queue[0]
+	c.queue = c.queue[1:]
+	delete(c.queued, job.key())
+	c.running = &job
+	handler := c.handlers[job.Kind]
+	c.mu.Unlock()
+
+	var followUps []Job
+	var err error
+	if handler == nil {
+		err = errUnknownKind(job.Kind)
+	} else {
+		followUps, err = handler(job.Target)
+	}
+
+	c.mu.Lock()
+	c.running = nil
+	c.processed++
+	if err != nil {
+		c.failed++
+		c.lastError = job.Kind + " " + job.Target + ": " + err.Error()
+		log.Printf("Crawler gagal menjalankan %s %s: %v", job.Kind, job.Target, err)
+	}
+	c.mu.Unlock()
+
+	c.Enqueue(followUps...)
+	return 0
+}
+
+type errUnknownKind string
+
+func (e errUnknownKind) Error() string {
+	return "tidak ada handler untuk job " + string(e)
+}
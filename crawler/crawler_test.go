@@ -0,0 +1,105 @@
+package crawler
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEnqueueDeduplicatesAndRespectsMaxQueue(t *testing.T) {
+	c := New(Options{MaxQueue: 2})
+
+	added := c.Enqueue(
+		Job{Kind: KindEpisode, Target: "a"},
+		Job{Kind: KindEpisode, Target: "a"},
+		Job{Kind: KindEpisode, Target: "b"},
+		Job{Kind: KindEpisode, Target: "c"},
+	)
+	if added != 2 {
+		t.Errorf("seharusnya 2 job ditambahkan, didapat %d", added)
+	}
+	status := c.Status()
+	if status.QueueLength != 2 || status.Dropped != 1 {
+		t.Errorf("antrean %d, dibuang %d", status.QueueLength, status.Dropped)
+	}
+}
+
+func TestRunNextQueuesFollowUpsAndRecordsErrors(t *testing.T) {
+	c := New(Options{})
+	c.Handle(KindHome, func(string) ([]Job, error) {
+		return []Job{{Kind: KindEpisode, Target: "ep-1"}}, nil
+	})
+	c.Handle(KindEpisode, func(string) ([]Job, error) {
+		return nil, errors.New("gagal")
+	})
+
+	c.Enqueue(Job{Kind: KindHome})
+	if wait := c.RunNext(); wait != 0 {
+		t.Fatalf("job seharusnya dijalankan, didapat tunggu %v", wait)
+	}
+	if status := c.Status(); status.QueueLength != 1 || status.Queue[0].Target != "ep-1" {
+		t.Fatalf("job lanjutan seharusnya diantrekan: %+v", status.Queue)
+	}
+
+	c.RunNext()
+	status := c.Status()
+	if status.Processed != 2 || status.Failed != 1 || status.LastError == "" {
+		t.Errorf("statistik tidak sesuai: %+v", status)
+	}
+}
+
+func TestPauseStopsProcessing(t *testing.T) {
+	c := New(Options{})
+	ran := 0
+	c.Handle(KindSchedule, func(string) ([]Job, error) {
+		ran++
+		return nil, nil
+	})
+	c.Enqueue(Job{Kind: KindSchedule})
+
+	c.Pause()
+	if wait := c.RunNext(); wait == 0 || ran != 0 {
+		t.Fatal("crawler yang dijeda tidak boleh menjalankan job")
+	}
+	c.Resume()
+	if c.RunNext(); ran != 1 {
+		t.Error("job seharusnya berjalan setelah resume")
+	}
+}
+
+func TestBudgetLimitsJobs(t *testing.T) {
+	c := New(Options{BudgetPerHour: 6})
+	c.Handle(KindEpisode, func(string) ([]Job, error) { return nil, nil })
+	c.Enqueue(Job{Kind: KindEpisode, Target: "a"}, Job{Kind: KindEpisode, Target: "b"})
+
+	if wait := c.RunNext(); wait != 0 {
+		t.Fatal("job pertama seharusnya masuk budget")
+	}
+	if wait := c.RunNext(); wait <= 0 {
+		t.Error("job kedua seharusnya menunggu budget terisi ulang")
+	}
+	if status := c.Status(); status.QueueLength != 1 {
+		t.Errorf("job yang menunggu budget harus tetap di antrean, panjang %d", status.QueueLength)
+	}
+}
+
+func TestRunDueTasksAppliesInterval(t *testing.T) {
+	c := New(Options{})
+	c.Schedule(Task{
+		Name:     "home",
+		Interval: time.Hour,
+		Jobs:     func() []Job { return []Job{{Kind: KindHome}} },
+	})
+
+	now := time.Now()
+	c.runDueTasks(now)
+	c.runDueTasks(now.Add(time.Minute))
+
+	status := c.Status()
+	if status.Tasks[0].Runs != 1 || status.QueueLength != 1 {
+		t.Errorf("task seharusnya berjalan sekali, runs=%d antrean=%d", status.Tasks[0].Runs, status.QueueLength)
+	}
+	if !status.Tasks[0].NextRun.Equal(now.Add(time.Hour)) {
+		t.Errorf("next run seharusnya satu jam lagi tanpa jitter, didapat %v", status.Tasks[0].NextRun)
+	}
+}
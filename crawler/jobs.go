@@ -0,0 +1,122 @@
+package crawler
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"multiplescrape/config"
+	"multiplescrape/repository"
+)
+
+// Jenis job untuk situs sumber.
+const (
+	KindHome        = "home"
+	KindLatestPage  = "latest_page"
+	KindSchedule    = "schedule"
+	KindAnimeDetail = "anime_detail"
+	KindEpisode     = "episode"
+)
+
+// NewSourceCrawler membuat crawler untuk situs sumber dari konfigurasi:
+// halaman utama dan N halaman terbaru setiap home_interval, jadwal setiap schedule_interval,
+// detail anime yang tayang minggu ini, dan halaman episode yang baru terlihat.
+func NewSourceCrawler(cfg config.CrawlerConfig) *Crawler {
+	c := New(Options{
+		Jitter:        cfg.Jitter,
+		JobDelay:      cfg.JobDelay.Std(),
+		BudgetPerHour: cfg.BudgetPerHour,
+		MaxQueue:      cfg.MaxQueue,
+	})
+
+	c.Handle(KindHome, func(string) ([]Job, error) {
+		items := repository.ScrapeLatestAnime(repository.FreshFetch())
+		if len(items) == 0 {
+			return nil, fmt.Errorf("halaman utama kosong")
+		}
+		return newEpisodeJobs(items), nil
+	})
+	c.Handle(KindLatestPage, func(target string) ([]Job, error) {
+		page, err := strconv.Atoi(target)
+		if err != nil || page < 1 {
+			return nil, fmt.Errorf("nomor halaman tidak valid: %q", target)
+		}
+		items := repository.ScrapeLatestByPage(page, repository.FreshFetch())
+		if len(items) == 0 {
+			return nil, fmt.Errorf("halaman terbaru %d kosong", page)
+		}
+		return newEpisodeJobs(items), nil
+	})
+	c.Handle(KindSchedule, func(string) ([]Job, error) {
+		days := repository.ScrapeSchedule(repository.FreshFetch())
+		if len(days) == 0 {
+			return nil, fmt.Errorf("jadwal kosong")
+		}
+		return airingDetailJobs(days, cfg.AiringMaxAge.Std()), nil
+	})
+	c.Handle(KindAnimeDetail, func(slug string) ([]Job, error) {
+		if data := repository.ScrapeAnimeDetail(slug, repository.FreshFetch()); data.Judul == "" {
+			return nil, fmt.Errorf("detail anime %s kosong", slug)
+		}
+		return nil, nil
+	})
+	c.Handle(KindEpisode, func(episodeURL string) ([]Job, error) {
+		if data := repository.ScrapeEpisodeDetail(episodeURL, repository.FreshFetch()); data.Title == "" {
+			return nil, fmt.Errorf("halaman episode %s kosong", episodeURL)
+		}
+		return nil, nil
+	})
+
+	c.Schedule(Task{
+		Name:     "home",
+		Interval: cfg.HomeInterval.Std(),
+		Jobs: func() []Job {
+			jobs := []Job{{Kind: KindHome}}
+			for page := 1; page <= cfg.LatestPages; page++ {
+				jobs = append(jobs, Job{Kind: KindLatestPage, Target: strconv.Itoa(page)})
+			}
+			return jobs
+		},
+	})
+	c.Schedule(Task{
+		Name:     "schedule",
+		Interval: cfg.ScheduleInterval.Std(),
+		Jobs:     func() []Job { return []Job{{Kind: KindSchedule}} },
+	})
+	return c
+}
+
+// newEpisodeJobs mengantrekan halaman episode yang belum pernah tersimpan di katalog.
+// Tanpa katalog, episode baru tidak bisa dikenali sehingga tidak ada job lanjutan.
+func newEpisodeJobs(items []repository.ScrapedLatestAnime) []Job {
+	if !repository.CatalogEnabled() {
+		return nil
+	}
+	var jobs []Job
+	for _, item := range items {
+		if item.Tautan != "" && !repository.CatalogHasEpisodePage(item.Tautan) {
+			jobs = append(jobs, Job{Kind: KindEpisode, Target: item.Tautan})
+		}
+	}
+	return jobs
+}
+
+// airingDetailJobs mengantrekan detail anime di jadwal minggu ini yang di katalog lebih tua dari maxAge.
+func airingDetailJobs(days []repository.ScrapedDaySchedule, maxAge time.Duration) []Job {
+	if !repository.CatalogEnabled() {
+		return nil
+	}
+	var jobs []Job
+	for _, day := range days {
+		for _, anime := range day.AnimeList {
+			if anime.Tautan == "" {
+				continue
+			}
+			slug := repository.GetSlugFromURL(anime.Tautan)
+			if _, fresh := repository.CachedAnimeDetail(slug, maxAge); !fresh {
+				jobs = append(jobs, Job{Kind: KindAnimeDetail, Target: slug})
+			}
+		}
+	}
+	return jobs
+}
@@ -22,43 +22,34 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/anime-detail/": {
+        "/admin/config": {
             "get": {
-                "description": "Mengambil detail lengkap sebuah anime berdasarkan slug seri atau slug episode.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Menampilkan konfigurasi yang sedang aktif (file + env), nilai rahasia disensor.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Anime Detail"
+                    "Admin"
                 ],
-                "summary": "Get Anime Detail",
+                "summary": "Effective Configuration",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Slug dari anime yang ingin dicari",
-                        "name": "anime_slug",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Force refresh cache (opsional, belum diimplementasikan)",
-                        "name": "force_refresh",
-                        "in": "query"
+                        "description": "Token admin (atau API key dengan scope admin)",
+                        "name": "X-Admin-Token",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Detail anime berhasil diambil",
+                        "description": "Konfigurasi efektif",
                         "schema": {
-                            "$ref": "#/definitions/repository.AnimeDetailResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Parameter anime_slug tidak ditemukan",
+                    "401": {
+                        "description": "Token admin tidak valid",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -66,8 +57,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Anime tidak ditemukan",
+                    "503": {
+                        "description": "Endpoint admin nonaktif",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -78,34 +69,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/anime-terbaru/": {
+        "/admin/crawler": {
             "get": {
-                "description": "Mengambil daftar rilis anime terbaru dengan paginasi.",
+                "description": "Menampilkan status crawler, task berkala, sisa budget, dan isi antrean job.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Anime List"
+                    "Admin"
                 ],
-                "summary": "Get Latest Anime Releases",
+                "summary": "Crawler Status",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Nomor halaman",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Token admin (atau API key dengan scope admin)",
+                        "name": "X-Admin-Token",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Daftar rilis terbaru berhasil diambil",
+                        "description": "Status crawler",
                         "schema": {
-                            "$ref": "#/definitions/repository.AnimeTerbaruResponse"
+                            "$ref": "#/definitions/crawler.Status"
                         }
                     },
-                    "400": {
-                        "description": "Parameter halaman tidak valid",
+                    "401": {
+                        "description": "Token admin tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Endpoint admin nonaktif",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -116,43 +115,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/episode-detail/": {
-            "get": {
-                "description": "Mengambil detail lengkap sebuah episode berdasarkan URL.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/crawler/pause": {
+            "post": {
+                "description": "Menjeda crawler. Job yang sedang berjalan diselesaikan, antrean tetap disimpan.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Episode Detail"
+                    "Admin"
                 ],
-                "summary": "Get Episode Detail",
+                "summary": "Pause Crawler",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "URL lengkap dari halaman episode",
-                        "name": "episode_url",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Force refresh cache (opsional, belum diimplementasikan)",
-                        "name": "force_refresh",
-                        "in": "query"
+                        "description": "Token admin (atau API key dengan scope admin)",
+                        "name": "X-Admin-Token",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Detail episode berhasil diambil",
+                        "description": "Status crawler setelah dijeda",
                         "schema": {
-                            "$ref": "#/definitions/repository.EpisodeDetailResponse"
+                            "$ref": "#/definitions/crawler.Status"
                         }
                     },
-                    "400": {
-                        "description": "Parameter episode_url tidak valid atau kosong",
+                    "401": {
+                        "description": "Token admin tidak valid",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -160,8 +149,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Episode tidak ditemukan",
+                    "503": {
+                        "description": "Endpoint admin nonaktif",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -172,28 +161,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/home/": {
-            "get": {
-                "description": "Mengambil daftar anime terbaru, film, top 10, dan jadwal rilis mingguan.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/crawler/resume": {
+            "post": {
+                "description": "Melanjutkan crawler yang sedang dijeda.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Anime"
+                    "Admin"
+                ],
+                "summary": "Resume Crawler",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token admin (atau API key dengan scope admin)",
+                        "name": "X-Admin-Token",
+                        "in": "header"
+                    }
                 ],
-                "summary": "Get Latest Anime \u0026 Schedule",
                 "responses": {
                     "200": {
-                        "description": "Data berhasil diambil",
+                        "description": "Status crawler setelah dilanjutkan",
                         "schema": {
-                            "$ref": "#/definitions/repository.FinalResponse"
+                            "$ref": "#/definitions/crawler.Status"
                         }
                     },
-                    "500": {
-                        "description": "Error internal server",
+                    "401": {
+                        "description": "Token admin tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Endpoint admin nonaktif",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -204,37 +207,81 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/jadwal-rilis/": {
+        "/admin/keys": {
             "get": {
-                "description": "Mengambil jadwal rilis anime untuk semua hari.",
-                "consumes": [
+                "description": "Menampilkan daftar API key beserta scope, kuota, dan statistik pemakaian.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "API Key Usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token admin (atau API key dengan scope admin)",
+                        "name": "X-Admin-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Statistik API key",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Token admin tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Endpoint admin nonaktif",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/keys/reload": {
+            "post": {
+                "description": "Membaca ulang file API key. Statistik key yang masih ada dipertahankan.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Jadwal Rilis"
+                    "Admin"
                 ],
-                "summary": "Get Release Schedule",
+                "summary": "Reload API Keys",
                 "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "Force refresh cache (opsional, belum diimplementasikan)",
-                        "name": "force_refresh",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Token admin (atau API key dengan scope admin)",
+                        "name": "X-Admin-Token",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Jadwal rilis berhasil diambil",
+                        "description": "Key berhasil dimuat ulang",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Error internal server",
+                        "description": "File API key tidak valid",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -245,43 +292,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/jadwal-rilis/{day}": {
+        "/admin/mirrors": {
             "get": {
-                "description": "Mengambil jadwal rilis anime untuk hari yang spesifik.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Menampilkan domain sumber yang aktif beserta status probe semua kandidat mirror.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Jadwal Rilis"
+                    "Admin"
                 ],
-                "summary": "Get Release Schedule by Day",
+                "summary": "Mirror Status",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Hari dalam bahasa Indonesia (e.g., senin, selasa)",
-                        "name": "day",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Force refresh cache (opsional, belum diimplementasikan)",
-                        "name": "force_refresh",
-                        "in": "query"
+                        "description": "Token admin (atau API key dengan scope admin)",
+                        "name": "X-Admin-Token",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Jadwal rilis berhasil diambil",
+                        "description": "Status mirror",
                         "schema": {
-                            "$ref": "#/definitions/repository.JadwalHarianResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Hari tidak ditemukan",
+                    "401": {
+                        "description": "Token admin tidak valid",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -289,8 +327,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Error internal server",
+                    "503": {
+                        "description": "Endpoint admin nonaktif",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -301,37 +339,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/movie/": {
-            "get": {
-                "description": "Mengambil daftar anime dari halaman utama (latest) sebagai pengganti daftar film.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/mirrors/probe": {
+            "post": {
+                "description": "Menjalankan probe semua kandidat mirror dan memilih ulang domain aktif.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Movie"
+                    "Admin"
                 ],
-                "summary": "Get \"Movie\" List (from Latest)",
+                "summary": "Probe Mirrors",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Nomor halaman",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Token admin (atau API key dengan scope admin)",
+                        "name": "X-Admin-Token",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Daftar berhasil diambil",
+                        "description": "Status mirror setelah probe",
                         "schema": {
-                            "$ref": "#/definitions/repository.MovieListResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Parameter halaman tidak valid",
+                    "401": {
+                        "description": "Token admin tidak valid",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -339,8 +374,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Error internal server",
+                    "503": {
+                        "description": "Endpoint admin nonaktif",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -351,34 +386,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/search/": {
+        "/admin/webhooks": {
             "get": {
-                "description": "Mencari anime berdasarkan query.",
+                "description": "Menampilkan semua webhook yang terdaftar. Secret tidak ditampilkan.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Anime List"
+                    "Webhooks"
                 ],
-                "summary": "Search Anime",
+                "summary": "List Webhooks",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Kata kunci pencarian",
-                        "name": "query",
-                        "in": "query",
-                        "required": true
+                        "description": "Token admin (atau API key dengan scope admin)",
+                        "name": "X-Admin-Token",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Hasil pencarian",
+                        "description": "Daftar webhook",
                         "schema": {
-                            "$ref": "#/definitions/repository.SearchResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Parameter query tidak ditemukan",
+                    "401": {
+                        "description": "Token admin tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Endpoint admin nonaktif",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -387,21 +431,45 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/health": {
-            "get": {
-                "description": "Memeriksa apakah layanan berjalan dengan baik.",
+            },
+            "post": {
+                "description": "Mendaftarkan URL penerima event. Secret dibuat acak jika kosong dan hanya ditampilkan sekali di response ini.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Utilities"
+                    "Webhooks"
+                ],
+                "summary": "Create Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token admin (atau API key dengan scope admin)",
+                        "name": "X-Admin-Token",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Data webhook; events kosong berarti semua event",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhook.Input"
+                        }
+                    }
                 ],
-                "summary": "Health Check",
                 "responses": {
-                    "200": {
-                        "description": "Layanan berjalan",
+                    "201": {
+                        "description": "Webhook berhasil dibuat",
+                        "schema": {
+                            "$ref": "#/definitions/webhook.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Data webhook tidak valid",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -412,39 +480,2156 @@ const docTemplate = `{
                 }
             }
         },
-        "/monitoring": {
+        "/admin/webhooks/{id}": {
             "get": {
-                "description": "Mengambil informasi monitoring sistem dan performa API.",
+                "description": "Menampilkan satu webhook berdasarkan ID (tanpa secret).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Utilities"
+                    "Webhooks"
+                ],
+                "summary": "Get Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token admin (atau API key dengan scope admin)",
+                        "name": "X-Admin-Token",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "System Monitoring",
                 "responses": {
                     "200": {
-                        "description": "Informasi monitoring",
+                        "description": "Data webhook",
+                        "schema": {
+                            "$ref": "#/definitions/webhook.Webhook"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook tidak ditemukan",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "repository.AnimeDetailData": {
-            "type": "object",
-            "properties": {
-                "anime_slug": {
-                    "type": "string",
-                    "example": "haikyuu-movie-gomisuteba-no-kessen"
+            },
+            "put": {
+                "description": "Mengubah URL, secret, daftar event, status aktif, atau deskripsi webhook. Field kosong tidak diubah.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Update Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token admin (atau API key dengan scope admin)",
+                        "name": "X-Admin-Token",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Field yang diubah",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhook.Input"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook berhasil diubah",
+                        "schema": {
+                            "$ref": "#/definitions/webhook.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Data webhook tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Menghapus webhook. Event berikutnya tidak lagi dikirim ke URL tersebut.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token admin (atau API key dengan scope admin)",
+                        "name": "X-Admin-Token",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook berhasil dihapus",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Menampilkan percobaan pengiriman terbaru (termasuk retry) untuk satu webhook, terbaru lebih dulu.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Webhook Delivery Log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token admin (atau API key dengan scope admin)",
+                        "name": "X-Admin-Token",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Log pengiriman",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{id}/test": {
+            "post": {
+                "description": "Mengirim event \"ping\" bertanda tangan ke webhook secara langsung (tanpa retry) dan mengembalikan hasilnya.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Test Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token admin (atau API key dengan scope admin)",
+                        "name": "X-Admin-Token",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Hasil pengiriman",
+                        "schema": {
+                            "$ref": "#/definitions/webhook.Delivery"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/anime": {
+            "get": {
+                "description": "Menelusuri arsip anime situs sumber dengan filter yang sama seperti halaman /anime/ situs tersebut,\natau daftar A-Z lewat parameter letter (tidak bisa digabung dengan filter lain). Filter diteruskan apa adanya\nke situs sumber; setiap item dilengkapi sinopsis, skor, status, dan genre dari tooltip ajax.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Anime List"
+                ],
+                "summary": "Browse Anime",
+                "parameters": [
+                    {
+                        "enum": [
+                            "update",
+                            "latest",
+                            "title",
+                            "titlereverse",
+                            "popular",
+                            "rating"
+                        ],
+                        "type": "string",
+                        "description": "Urutan",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ongoing",
+                            "completed",
+                            "hiatus",
+                            "upcoming"
+                        ],
+                        "type": "string",
+                        "description": "Status tayang",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "tv",
+                            "movie",
+                            "ova",
+                            "ona",
+                            "special",
+                            "bd",
+                            "music"
+                        ],
+                        "type": "string",
+                        "description": "Tipe anime",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slug genre dipisah koma (contoh: action,slice-of-life)",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slug season dipisah koma (contoh: fall-2024)",
+                        "name": "season",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Daftar A-Z: huruf A-Z, 0-9, atau . untuk simbol",
+                        "name": "letter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Nomor halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Ambil ulang halaman dari situs sumber tanpa cache",
+                        "name": "force_refresh",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Daftar anime",
+                        "schema": {
+                            "$ref": "#/definitions/repository.BrowseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Parameter filter tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/anime-detail/": {
+            "get": {
+                "description": "Mengambil detail lengkap sebuah anime berdasarkan slug seri atau slug episode.\nJika kotak Recommended Series di halaman kosong, rekomendasi dihitung dari katalog lokal (genre, studio, tipe,\nkedekatan musim tayang, dan skor) dan setiap item berisi similarity serta explanation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Anime Detail"
+                ],
+                "summary": "Get Anime Detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Slug dari anime yang ingin dicari",
+                        "name": "anime_slug",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Abaikan katalog dan scrape ulang dari sumber",
+                        "name": "force_refresh",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Detail anime berhasil diambil",
+                        "schema": {
+                            "$ref": "#/definitions/repository.AnimeDetailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Parameter anime_slug tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Anime tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/anime-terbaru/": {
+            "get": {
+                "description": "Mengambil daftar rilis anime terbaru dengan paginasi.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Anime List"
+                ],
+                "summary": "Get Latest Anime Releases",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Nomor halaman",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Daftar rilis terbaru berhasil diambil",
+                        "schema": {
+                            "$ref": "#/definitions/repository.AnimeTerbaruResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Parameter halaman tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/anime/{slug}/playlist.m3u8": {
+            "get": {
+                "description": "Membuat playlist extended M3U berisi satu URL media langsung (mp4/HLS) per episode, urut dari episode pertama,\nuntuk diputar di VLC atau mpv. Detail episode diambil dari katalog (atau scrape jika kedaluwarsa) dan setiap\nserver di-resolve lewat resolver embed. Episode tanpa sumber yang bisa diputar dilewati dan ditulis sebagai komentar.\nSeri panjang dibagi per 25 episode; pakai ?page= untuk halaman berikutnya. Header X-Playlist-Entries dan\nX-Playlist-Skipped berisi jumlah episode, X-Playlist-Page dan X-Playlist-Total-Pages berisi posisi halaman.",
+                "produces": [
+                    "application/x-mpegurl"
+                ],
+                "tags": [
+                    "Anime Detail"
+                ],
+                "summary": "Export Season Playlist (M3U)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Slug anime (contoh: naruto)",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kualitas pilihan, contoh: 720p",
+                        "name": "quality",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Potongan nama server pilihan, contoh: nakama",
+                        "name": "server",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Halaman playlist, 25 episode per halaman (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Abaikan katalog dan scrape ulang daftar episode; halaman episode tetap dari katalog jika belum kedaluwarsa",
+                        "name": "force_refresh",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Playlist M3U",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Parameter tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Anime atau halaman tidak ditemukan, atau tidak ada episode yang bisa diputar",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/anime/{slug}/related": {
+            "get": {
+                "description": "Menyusun graf seri terkait (season lain, movie, OVA/special) dari kotak seri terkait dan rekomendasi di halaman\ndetail serta pencarian judul di katalog. Rekomendasi hanya dipakai jika judulnya satu franchise (nomor season,\n\"Movie\", dan \"2nd Season\" diabaikan saat membandingkan). Relasi terhadap anime ini: sequel, prequel, movie, atau\nside_story. watch_order diurutkan menurut tanggal tayang jika semua anime punya tanggal, selain itu menurut nomor\nseason. Paling banyak 5 kandidat yang belum ada di katalog di-scrape per request.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Anime Detail"
+                ],
+                "summary": "Related Series \u0026 Watch Order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Slug anime (contoh: haikyuu)",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Abaikan katalog dan scrape ulang detail anime ini",
+                        "name": "force_refresh",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Graf seri terkait",
+                        "schema": {
+                            "$ref": "#/definitions/related.Graph"
+                        }
+                    },
+                    "400": {
+                        "description": "Slug tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Anime tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/batch": {
+            "post": {
+                "description": "Menjalankan beberapa operasi sekaligus: anime-detail (slug), episode-detail (slug atau url), dan search (query, page).\nSetiap operasi menghasilkan response yang sama dengan endpoint tunggalnya, termasuk katalog dan confidence score,\nbeserta status HTTP masing-masing. Operasi identik hanya dijalankan sekali (deduplicated), dan operasi yang gagal\ntidak menggagalkan operasi lain. Semua request batch berbagi batch.concurrency slot. Setiap operasi memakai satu unit\nrate limit dan kuota harian API key, atau tanpa API key satu token rate limit kelas endpoint aslinya; begitu batas\nhabis, sisa operasi mendapat status 429.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Batch"
+                ],
+                "summary": "Batch Lookup",
+                "parameters": [
+                    {
+                        "description": "Daftar operasi (maksimal batch.max_operations)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/batch.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Hasil per operasi",
+                        "schema": {
+                            "$ref": "#/definitions/batch.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Body tidak valid atau operasi terlalu banyak",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/episode-detail/": {
+            "get": {
+                "description": "Mengambil detail lengkap sebuah episode berdasarkan URL.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Episode Detail"
+                ],
+                "summary": "Get Episode Detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "URL lengkap dari halaman episode",
+                        "name": "episode_url",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Abaikan katalog dan scrape ulang dari sumber",
+                        "name": "force_refresh",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Ubah URL embed setiap server menjadi URL media langsung (mp4/HLS)",
+                        "name": "resolve",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Detail episode berhasil diambil",
+                        "schema": {
+                            "$ref": "#/definitions/repository.EpisodeDetailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Parameter episode_url tidak valid, kosong, atau bukan domain sumber",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Episode tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/episode-detail/health": {
+            "get": {
+                "description": "Mengecek setiap server streaming dan link download episode dengan HEAD (atau GET satu byte jika host menolak HEAD),\nlalu mengembalikan status, latensi, dan content type per link. Hasil di-cache (stream_health.cache_ttl) dan\ndipakai untuk field alive/checked_at di detail episode. Isi salah satu dari episode_url atau episode_slug.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Episode Detail"
+                ],
+                "summary": "Check Episode Link Health",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "URL lengkap halaman episode di domain sumber",
+                        "name": "episode_url",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slug episode (contoh: naruto-episode-1)",
+                        "name": "episode_slug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Abaikan katalog dan scrape ulang halaman episode",
+                        "name": "force_refresh",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Hasil cek link",
+                        "schema": {
+                            "$ref": "#/definitions/linkhealth.EpisodeHealthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Parameter episode tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Episode tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/episode/{episode_slug}": {
+            "get": {
+                "description": "Mengambil detail lengkap sebuah episode berdasarkan slug (field episode_slug di daftar episode).\nURL halaman episode dibangun dari domain sumber yang aktif, jadi klien tidak perlu mengirim URL lengkap.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Episode Detail"
+                ],
+                "summary": "Get Episode Detail by Slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Slug episode (contoh: naruto-episode-1)",
+                        "name": "episode_slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Abaikan katalog dan scrape ulang dari sumber",
+                        "name": "force_refresh",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Ubah URL embed setiap server menjadi URL media langsung (mp4/HLS)",
+                        "name": "resolve",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Detail episode berhasil diambil",
+                        "schema": {
+                            "$ref": "#/definitions/repository.EpisodeDetailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Slug episode tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Episode tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/events/stream": {
+            "get": {
+                "description": "Stream Server-Sent Events berisi event new_episode, schedule_changed, new_anime, anime_completed, dan stream_added\nyang ditemukan crawler atau scrape biasa. Nama event SSE = jenis event, data = JSON event.\nKlien yang menyambung ulang mengirim header Last-Event-ID (atau query last_event_id) untuk menerima event yang terlewat\ndari buffer replay; jika sebagian sudah terbuang, event \"replay_gap\" dikirim lebih dulu.\nKlien yang terlalu lambat diputus dan bisa menyambung ulang dengan Last-Event-ID.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Live Event Stream (SSE)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter slug anime, dipisah koma",
+                        "name": "anime_slug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter genre, dipisah koma (tidak peka huruf besar/kecil)",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter jenis event, dipisah koma",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alternatif header Last-Event-ID",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID event terakhir yang diterima klien",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream event",
+                        "schema": {
+                            "$ref": "#/definitions/events.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Last-Event-ID tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Terlalu banyak klien SSE",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/home/": {
+            "get": {
+                "description": "Mengambil daftar anime terbaru, film, top 10, dan jadwal rilis mingguan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Anime"
+                ],
+                "summary": "Get Latest Anime \u0026 Schedule",
+                "responses": {
+                    "200": {
+                        "description": "Data berhasil diambil",
+                        "schema": {
+                            "$ref": "#/definitions/repository.FinalResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error internal server",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/jadwal-rilis/": {
+            "get": {
+                "description": "Mengambil jadwal rilis anime untuk semua hari.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jadwal Rilis"
+                ],
+                "summary": "Get Release Schedule",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Ambil ulang dari sumber tanpa cache",
+                        "name": "force_refresh",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Jadwal rilis berhasil diambil",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Error internal server",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/jadwal-rilis/{day}": {
+            "get": {
+                "description": "Mengambil jadwal rilis anime untuk hari yang spesifik.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jadwal Rilis"
+                ],
+                "summary": "Get Release Schedule by Day",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hari dalam bahasa Indonesia (e.g., senin, selasa)",
+                        "name": "day",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Ambil ulang dari sumber tanpa cache",
+                        "name": "force_refresh",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Jadwal rilis berhasil diambil",
+                        "schema": {
+                            "$ref": "#/definitions/repository.JadwalHarianResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Hari tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error internal server",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/movie/": {
+            "get": {
+                "description": "Mengambil daftar anime dari halaman utama (latest) sebagai pengganti daftar film.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movie"
+                ],
+                "summary": "Get \"Movie\" List (from Latest)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Nomor halaman",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Daftar berhasil diambil",
+                        "schema": {
+                            "$ref": "#/definitions/repository.MovieListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Parameter halaman tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error internal server",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search/": {
+            "get": {
+                "description": "Mencari anime berdasarkan query. Pencarian memakai indeks lokal dari katalog lebih dulu (toleran salah ketik,\nmencakup judul, judul alternatif, sinopsis, dan genre) dan baru memanggil situs sumber jika indeks tidak menemukan apa pun.\nFilter dan urutan berlaku sama untuk hasil indeks lokal maupun situs sumber. Hasil situs sumber dilengkapi data katalog\n(season, studio, waktu episode terbaru) jika detail anime pernah di-scrape; tanpa data itu, anime tidak lolos filter season/studio.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Anime List"
+                ],
+                "summary": "Search Anime",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kata kunci pencarian",
+                        "name": "query",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter genre, dipisah koma; anime harus memiliki semua genre (contoh: Action,Comedy)",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter tipe: TV, Movie, OVA, ONA, Special",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status: Ongoing atau Completed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter season, cukup sebagian teks (contoh: Fall 2024 atau 2024)",
+                        "name": "season",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter studio, cukup sebagian teks (contoh: MAPPA)",
+                        "name": "studio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Skor minimum 0-10; anime tanpa skor tidak lolos",
+                        "name": "min_score",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "relevance",
+                            "score",
+                            "title",
+                            "latest"
+                        ],
+                        "type": "string",
+                        "default": "relevance",
+                        "description": "Urutan hasil",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Nomor halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Jumlah hasil per halaman (maksimal 50)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Lewati indeks lokal dan cari langsung di situs sumber",
+                        "name": "force_refresh",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Hasil pencarian",
+                        "schema": {
+                            "$ref": "#/definitions/repository.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Parameter query kosong atau parameter filter tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/suggest": {
+            "get": {
+                "description": "Mengembalikan hingga 10 saran judul yang diawali teks q, dari judul dan judul alternatif semua anime di katalog.\nEndpoint ini hanya membaca indeks lokal dan tidak pernah memanggil situs sumber, jadi aman dipanggil di setiap ketikan.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Anime List"
+                ],
+                "summary": "Suggest Anime Titles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Awal judul yang sedang diketik",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Jumlah saran (maksimal 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Daftar saran judul",
+                        "schema": {
+                            "$ref": "#/definitions/repository.SuggestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Parameter q kosong atau limit tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/watchlists": {
+            "post": {
+                "description": "Membuat watchlist kosong. ID pada response adalah token akses watchlist; simpan dan kirim di setiap request berikutnya.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlist"
+                ],
+                "summary": "Create Watchlist",
+                "parameters": [
+                    {
+                        "description": "Nama watchlist (opsional)",
+                        "name": "watchlist",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/watchlist.CreateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Watchlist berhasil dibuat",
+                        "schema": {
+                            "$ref": "#/definitions/watchlist.Watchlist"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/watchlists/{id}": {
+            "get": {
+                "description": "Menampilkan anime di watchlist beserta episode terakhir yang ditonton.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlist"
+                ],
+                "summary": "Get Watchlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID watchlist",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data watchlist",
+                        "schema": {
+                            "$ref": "#/definitions/watchlist.Watchlist"
+                        }
+                    },
+                    "404": {
+                        "description": "Watchlist tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Menghapus watchlist beserta seluruh riwayat tontonnya.",
+                "tags": [
+                    "Watchlist"
+                ],
+                "summary": "Delete Watchlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID watchlist",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Watchlist dihapus"
+                    },
+                    "404": {
+                        "description": "Watchlist tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/watchlists/{id}/anime/{anime_slug}": {
+            "put": {
+                "description": "Menambahkan anime ke watchlist. last_watched_episode opsional untuk anime yang sudah ditonton sebagian;\ntanpa itu semua episode dianggap belum ditonton. Menambahkan anime yang sudah ada tidak menghapus riwayatnya.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlist"
+                ],
+                "summary": "Add Anime to Watchlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID watchlist",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slug anime",
+                        "name": "anime_slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Episode terakhir yang sudah ditonton",
+                        "name": "anime",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/watchlist.AnimeInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Watchlist setelah diubah",
+                        "schema": {
+                            "$ref": "#/definitions/watchlist.Watchlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Slug atau body tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Watchlist tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Jumlah anime sudah mencapai batas",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Menghapus anime beserta riwayat tontonnya dari watchlist.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlist"
+                ],
+                "summary": "Remove Anime from Watchlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID watchlist",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slug anime",
+                        "name": "anime_slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Watchlist setelah diubah",
+                        "schema": {
+                            "$ref": "#/definitions/watchlist.Watchlist"
+                        }
+                    },
+                    "404": {
+                        "description": "Watchlist atau anime tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/watchlists/{id}/anime/{anime_slug}/watched": {
+            "post": {
+                "description": "Menandai episode sudah ditonton. Episode terakhir yang ditonton hanya bergerak maju,\njadi menandai episode lama tidak membuat episode yang lebih baru dianggap sudah ditonton.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlist"
+                ],
+                "summary": "Mark Episode Watched",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID watchlist",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slug anime",
+                        "name": "anime_slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nomor episode yang ditonton",
+                        "name": "episode",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/watchlist.WatchedInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Watchlist setelah diubah",
+                        "schema": {
+                            "$ref": "#/definitions/watchlist.Watchlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Body tidak valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Watchlist atau anime tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/watchlists/{id}/unwatched": {
+            "get": {
+                "description": "Menghitung episode yang dirilis setelah episode terakhir yang ditonton untuk setiap anime di watchlist,\ndari daftar episode di katalog (atau scrape jika katalog sudah lewat catalog.anime_ttl).\nField source per anime: catalog, scrape, catalog_stale (scrape gagal, memakai data lama), atau unavailable.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlist"
+                ],
+                "summary": "Unwatched Episodes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID watchlist",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Episode yang belum ditonton",
+                        "schema": {
+                            "$ref": "#/definitions/watchlist.UnwatchedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Watchlist tidak ditemukan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/ws": {
+            "get": {
+                "description": "Upgrade ke WebSocket. Klien mengirim {\"type\":\"subscribe\",\"id\":\"1\",\"anime_slugs\":[\"one-piece\"]},\n\"unsubscribe\", \"list\", atau \"ping\"; server membalas \"ack\" (berisi daftar langganan saat ini), \"error\", atau \"pong\".\nEvent new_episode, stream_added, schedule_changed, dan lainnya untuk anime yang dilangganinya dikirim sebagai\n{\"type\":\"event\",\"event\":{...}}. Jumlah langganan per koneksi dibatasi (lihat max_subscriptions di pesan \"welcome\"),\ndan klien yang terlalu lambat membaca diputus dengan close code 1013 agar menyambung ulang.",
+                "tags": [
+                    "Events"
+                ],
+                "summary": "WebSocket Langganan Anime",
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bukan request WebSocket",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Terlalu banyak klien WebSocket",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Memeriksa apakah layanan berjalan dengan baik.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Utilities"
+                ],
+                "summary": "Health Check",
+                "responses": {
+                    "200": {
+                        "description": "Layanan berjalan",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/monitoring": {
+            "get": {
+                "description": "Mengambil informasi monitoring sistem dan performa API.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Utilities"
+                ],
+                "summary": "System Monitoring",
+                "responses": {
+                    "200": {
+                        "description": "Informasi monitoring",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "batch.Operation": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID opsional dari klien, dikembalikan apa adanya agar hasil mudah dicocokkan.",
+                    "type": "string",
+                    "example": "card-1"
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "anime-detail",
+                        "episode-detail",
+                        "search"
+                    ],
+                    "example": "anime-detail"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "query": {
+                    "type": "string",
+                    "example": "naruto"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "naruto"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://gomunime.co/naruto-episode-1/"
+                }
+            }
+        },
+        "batch.Request": {
+            "type": "object",
+            "properties": {
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/batch.Operation"
+                    }
+                }
+            }
+        },
+        "batch.Response": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/batch.Result"
+                    }
+                },
+                "source": {
+                    "type": "string"
+                },
+                "summary": {
+                    "$ref": "#/definitions/batch.Summary"
+                }
+            }
+        },
+        "batch.Result": {
+            "type": "object",
+            "properties": {
+                "confidence_score": {
+                    "type": "number",
+                    "example": 1
+                },
+                "deduplicated": {
+                    "description": "Deduplicated bernilai true jika hasil diambil dari operasi identik sebelumnya di batch yang sama.",
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "card-1"
+                },
+                "op": {
+                    "type": "string",
+                    "example": "anime-detail"
+                },
+                "response": {
+                    "type": "object"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "batch.Summary": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "succeeded": {
+                    "type": "integer",
+                    "example": 27
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "unique": {
+                    "type": "integer",
+                    "example": 28
+                }
+            }
+        },
+        "crawler.Job": {
+            "type": "object",
+            "properties": {
+                "enqueued_at": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "crawler.Status": {
+            "type": "object",
+            "properties": {
+                "budget_per_hour": {
+                    "type": "integer"
+                },
+                "budget_remaining": {
+                    "type": "integer"
+                },
+                "dropped": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "paused": {
+                    "type": "boolean"
+                },
+                "processed": {
+                    "type": "integer"
+                },
+                "queue": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/crawler.Job"
+                    }
+                },
+                "queue_length": {
+                    "type": "integer"
+                },
+                "running": {
+                    "$ref": "#/definitions/crawler.Job"
+                },
+                "started": {
+                    "type": "boolean"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/crawler.TaskStatus"
+                    }
+                }
+            }
+        },
+        "crawler.TaskStatus": {
+            "type": "object",
+            "properties": {
+                "interval": {
+                    "type": "string"
+                },
+                "last_run": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "next_run": {
+                    "type": "string"
+                },
+                "runs": {
+                    "type": "integer"
+                }
+            }
+        },
+        "events.Event": {
+            "type": "object",
+            "properties": {
+                "anime_slug": {
+                    "type": "string"
+                },
+                "anime_title": {
+                    "type": "string"
+                },
+                "day": {
+                    "type": "string"
+                },
+                "episode": {
+                    "type": "string"
+                },
+                "episode_url": {
+                    "type": "string"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "previous": {
+                    "type": "string"
+                },
+                "release_time": {
+                    "type": "string"
+                },
+                "server_name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "stream_url": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "linkhealth.DownloadHealth": {
+            "type": "object",
+            "properties": {
+                "alive": {
+                    "type": "boolean"
+                },
+                "checked_at": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "video/mp4"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string",
+                    "example": "MKV"
+                },
+                "latency_ms": {
+                    "type": "integer",
+                    "example": 182
+                },
+                "method": {
+                    "type": "string",
+                    "example": "HEAD"
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "Gofile"
+                },
+                "quality": {
+                    "type": "string",
+                    "example": "720p"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "linkhealth.EpisodeHealth": {
+            "type": "object",
+            "properties": {
+                "download_links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/linkhealth.DownloadHealth"
+                    }
+                },
+                "episode_url": {
+                    "type": "string"
+                },
+                "streaming_servers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/linkhealth.ServerHealth"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/linkhealth.Summary"
+                }
+            }
+        },
+        "linkhealth.EpisodeHealthResponse": {
+            "type": "object",
+            "properties": {
+                "confidence_score": {
+                    "type": "number",
+                    "example": 1
+                },
+                "data": {
+                    "$ref": "#/definitions/linkhealth.EpisodeHealth"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "linkhealth.ServerHealth": {
+            "type": "object",
+            "properties": {
+                "alive": {
+                    "type": "boolean"
+                },
+                "checked_at": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "video/mp4"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "type": "integer",
+                    "example": 182
+                },
+                "method": {
+                    "type": "string",
+                    "example": "HEAD"
+                },
+                "server_name": {
+                    "type": "string",
+                    "example": "Nakama 1080p"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "linkhealth.Summary": {
+            "type": "object",
+            "properties": {
+                "alive_downloads": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "alive_streams": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "downloads": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "streams": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "related.Edge": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "haikyuu"
+                },
+                "relation": {
+                    "type": "string",
+                    "example": "sequel"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "haikyuu-second-season"
+                }
+            }
+        },
+        "related.Graph": {
+            "type": "object",
+            "properties": {
+                "anime_slug": {
+                    "type": "string",
+                    "example": "haikyuu"
+                },
+                "edges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/related.Edge"
+                    }
+                },
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/related.Node"
+                    }
+                },
+                "ordered_by": {
+                    "description": "OrderedBy bernilai release_date jika semua anime punya tanggal tayang, selain itu season_number.",
+                    "type": "string",
+                    "enum": [
+                        "release_date",
+                        "season_number"
+                    ],
+                    "example": "release_date"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Haikyuu!!"
+                },
+                "watch_order": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "haikyuu",
+                        "haikyuu-second-season"
+                    ]
+                }
+            }
+        },
+        "related.Node": {
+            "type": "object",
+            "properties": {
+                "anime_slug": {
+                    "type": "string",
+                    "example": "haikyuu-second-season"
+                },
+                "confidence": {
+                    "type": "number",
+                    "example": 0.9
+                },
+                "cover_url": {
+                    "type": "string"
+                },
+                "relation": {
+                    "description": "Relation kosong untuk anime utama.",
+                    "type": "string",
+                    "enum": [
+                        "sequel",
+                        "prequel",
+                        "movie",
+                        "side_story"
+                    ],
+                    "example": "sequel"
+                },
+                "released": {
+                    "type": "string",
+                    "example": "Oct 4, 2015"
+                },
+                "root": {
+                    "type": "boolean"
+                },
+                "season": {
+                    "description": "Season adalah nomor season dari judul; \"Part 2\" menjadi x.1.",
+                    "type": "number",
+                    "example": 2
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "related_box",
+                        "title_match"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Completed"
+                },
+                "studio": {
+                    "type": "string",
+                    "example": "Production I.G"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Haikyuu!! Second Season"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "TV"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://gomunime.co/anime/haikyuu-second-season/"
+                },
+                "watch_order": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "repository.AnimeDetailData": {
+            "type": "object",
+            "properties": {
+                "alternative_titles": {
+                    "description": "AlternativeTitles berisi judul Jepang, Inggris, sinonim, dan judul alternatif lain tanpa duplikat.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "ハイキュー!! ゴミ捨て場の決戦",
+                        "Haikyu!! The Dumpster Battle"
+                    ]
+                },
+                "anime_slug": {
+                    "type": "string",
+                    "example": "haikyuu-movie-gomisuteba-no-kessen"
+                },
+                "casts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Ayumu Murase",
+                        "Kaito Ishikawa"
+                    ]
                 },
                 "details": {
                     "$ref": "#/definitions/repository.Details"
                 },
+                "director": {
+                    "type": "string",
+                    "example": "Susumu Mitsunaka"
+                },
                 "episode_list": {
                     "type": "array",
                     "items": {
@@ -478,6 +2663,11 @@ const docTemplate = `{
                         "$ref": "#/definitions/repository.RecommendationItem"
                     }
                 },
+                "released_on": {
+                    "description": "ReleasedOn dan UpdatedOn berformat RFC 3339, atau \"2006-01-02\" jika situs hanya menampilkan tanggal.",
+                    "type": "string",
+                    "example": "2024-10-05T10:00:00+07:00"
+                },
                 "sinopsis": {
                     "type": "string",
                     "example": "Kozume Kenma tidak pernah menganggap..."
@@ -494,6 +2684,10 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Movie"
                 },
+                "updated_on": {
+                    "type": "string",
+                    "example": "2024-11-01T08:30:00+07:00"
+                },
                 "url_anime": {
                     "type": "string",
                     "example": "https://gomunime.co/anime/haikyuu-movie-gomisuteba-no-kessen/"
@@ -582,7 +2776,45 @@ const docTemplate = `{
                 }
             }
         },
-        "repository.AnimeTerbaruResponse": {
+        "repository.AnimeTerbaruResponse": {
+            "type": "object",
+            "properties": {
+                "confidence_score": {
+                    "type": "number",
+                    "example": 1
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.AnimeTerbaruItem"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "repository.BrowsePagination": {
+            "type": "object",
+            "properties": {
+                "has_next_page": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "has_prev_page": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "repository.BrowseResponse": {
             "type": "object",
             "properties": {
                 "confidence_score": {
@@ -592,12 +2824,15 @@ const docTemplate = `{
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/repository.AnimeTerbaruItem"
+                        "$ref": "#/definitions/repository.SearchResultItem"
                     }
                 },
                 "message": {
                     "type": "string"
                 },
+                "pagination": {
+                    "$ref": "#/definitions/repository.BrowsePagination"
+                },
                 "source": {
                     "type": "string"
                 }
@@ -618,7 +2853,7 @@ const docTemplate = `{
                 "Producers": {
                     "type": "string"
                 },
-                "Released:": {
+                "Released": {
                     "type": "string"
                 },
                 "Season": {
@@ -675,6 +2910,11 @@ const docTemplate = `{
                         }
                     }
                 },
+                "download_source": {
+                    "description": "Asal download_links: page, stream_fallback (diturunkan dari server streaming), atau none",
+                    "type": "string",
+                    "example": "page"
+                },
                 "navigation": {
                     "$ref": "#/definitions/repository.EpisodeNavigation"
                 },
@@ -722,6 +2962,27 @@ const docTemplate = `{
                 }
             }
         },
+        "repository.EpisodeLink": {
+            "type": "object",
+            "properties": {
+                "episode": {
+                    "type": "string",
+                    "example": "2"
+                },
+                "episode_slug": {
+                    "type": "string",
+                    "example": "naruto-episode-2"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Naruto Episode 2"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://gomunime.co/naruto-episode-2/"
+                }
+            }
+        },
         "repository.EpisodeListItem": {
             "type": "object",
             "properties": {
@@ -753,9 +3014,19 @@ const docTemplate = `{
                 "all_episodes_url": {
                     "type": "string"
                 },
+                "anime_slug": {
+                    "type": "string",
+                    "example": "naruto"
+                },
+                "next": {
+                    "$ref": "#/definitions/repository.EpisodeLink"
+                },
                 "next_episode_url": {
                     "type": "string"
                 },
+                "previous": {
+                    "$ref": "#/definitions/repository.EpisodeLink"
+                },
                 "previous_episode_url": {
                     "type": "string"
                 }
@@ -909,6 +3180,34 @@ const docTemplate = `{
                 }
             }
         },
+        "repository.MediaSource": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "ExpiresAt kosong berarti URL tidak punya masa berlaku yang diketahui.",
+                    "type": "string"
+                },
+                "headers": {
+                    "description": "Headers berisi header yang wajib dikirim player, misalnya Referer untuk host yang memeriksanya.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "mime_type": {
+                    "type": "string",
+                    "example": "video/mp4"
+                },
+                "quality": {
+                    "type": "string",
+                    "example": "1080p"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://pixeldrain.com/api/file/Ra5A3rtj"
+                }
+            }
+        },
         "repository.Movie": {
             "type": "object",
             "properties": {
@@ -1035,6 +3334,10 @@ const docTemplate = `{
         "repository.OtherEpisode": {
             "type": "object",
             "properties": {
+                "episode": {
+                    "type": "string",
+                    "example": "1"
+                },
                 "release_date": {
                     "type": "string",
                     "example": "31 October 2024"
@@ -1053,6 +3356,35 @@ const docTemplate = `{
                 }
             }
         },
+        "repository.Pagination": {
+            "type": "object",
+            "properties": {
+                "has_next_page": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "has_prev_page": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "total_items": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "total_pages": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
         "repository.RatingInfo": {
             "type": "object",
             "properties": {
@@ -1081,10 +3413,19 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Eps 12"
                 },
+                "explanation": {
+                    "type": "string",
+                    "example": "genre sama: Action, Fantasy (67%); studio sama: Madhouse; skor 8.10"
+                },
                 "rating": {
                     "type": "string",
                     "example": "8"
                 },
+                "similarity": {
+                    "description": "Similarity dan Explanation hanya terisi untuk rekomendasi dari katalog lokal.",
+                    "type": "number",
+                    "example": 0.72
+                },
                 "title": {
                     "type": "string",
                     "example": "Overlord III"
@@ -1111,6 +3452,9 @@ const docTemplate = `{
                 "message": {
                     "type": "string"
                 },
+                "pagination": {
+                    "$ref": "#/definitions/repository.Pagination"
+                },
                 "source": {
                     "type": "string"
                 }
@@ -1170,16 +3514,76 @@ const docTemplate = `{
         "repository.StreamingServer": {
             "type": "object",
             "properties": {
+                "alive": {
+                    "description": "Alive dan CheckedAt berasal dari hasil cek link terakhir; kosong jika server belum pernah dicek.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "checked_at": {
+                    "type": "string"
+                },
+                "resolve_error": {
+                    "type": "string"
+                },
+                "resolver": {
+                    "description": "Field berikut hanya terisi jika diminta dengan resolve=true dan tidak pernah disimpan di katalog.",
+                    "type": "string",
+                    "example": "pixeldrain"
+                },
                 "server_name": {
                     "type": "string",
                     "example": "Nakama 1080p"
                 },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.MediaSource"
+                    }
+                },
                 "streaming_url": {
                     "type": "string",
                     "example": "https://pixeldrain.com/api/file/Ra5A3rtj"
                 }
             }
         },
+        "repository.SuggestItem": {
+            "type": "object",
+            "properties": {
+                "cover": {
+                    "type": "string",
+                    "example": "https://v1.samehadaku.how/wp-content/uploads/2024/08/142503.jpg"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "kimetsu-no-yaiba"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Kimetsu no Yaiba"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "TV"
+                }
+            }
+        },
+        "repository.SuggestResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.SuggestItem"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
         "repository.Top10Anime": {
             "type": "object",
             "properties": {
@@ -1205,6 +3609,227 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "watchlist.AnimeInput": {
+            "type": "object",
+            "properties": {
+                "last_watched_episode": {
+                    "type": "string",
+                    "example": "1100"
+                }
+            }
+        },
+        "watchlist.CreateInput": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Musim ini"
+                }
+            }
+        },
+        "watchlist.Entry": {
+            "type": "object",
+            "properties": {
+                "added_at": {
+                    "type": "string"
+                },
+                "anime_slug": {
+                    "type": "string",
+                    "example": "one-piece"
+                },
+                "last_watched_episode": {
+                    "type": "string",
+                    "example": "1100"
+                },
+                "watched_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "watchlist.UnwatchedAnime": {
+            "type": "object",
+            "properties": {
+                "anime_slug": {
+                    "type": "string",
+                    "example": "one-piece"
+                },
+                "episodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.EpisodeListItem"
+                    }
+                },
+                "last_watched_episode": {
+                    "type": "string",
+                    "example": "1100"
+                },
+                "source": {
+                    "description": "Source menunjukkan asal daftar episode: catalog, scrape, catalog_stale, atau unavailable.",
+                    "type": "string",
+                    "example": "catalog"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "One Piece"
+                }
+            }
+        },
+        "watchlist.UnwatchedResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/watchlist.UnwatchedAnime"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "total_unwatched": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "watchlist_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "watchlist.WatchedInput": {
+            "type": "object",
+            "properties": {
+                "episode": {
+                    "type": "string",
+                    "example": "1101"
+                }
+            }
+        },
+        "watchlist.Watchlist": {
+            "type": "object",
+            "properties": {
+                "anime": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/watchlist.Entry"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "9f2c4e7a1b3d5f6e8a0c2e4f6a8b0d1e"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Musim ini"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "webhook.Delivery": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "webhook.Input": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Bot Discord"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "new_episode",
+                        "stream_added"
+                    ]
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "rahasia-bersama"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/anime"
+                }
+            }
+        },
+        "webhook.Webhook": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
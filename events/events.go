@@ -0,0 +1,105 @@
+// Package events menyalurkan event perubahan data (episode baru, anime baru, dll.)
+// dari scraper ke kanal push seperti webhook.
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Jenis event.
+const (
+	NewEpisode      = "new_episode"
+	NewAnime        = "new_anime"
+	AnimeCompleted  = "anime_completed"
+	StreamAdded     = "stream_added"
+	ScheduleChanged = "schedule_changed"
+)
+
+// Types berisi semua jenis event yang dikenal.
+var Types = []string{NewEpisode, NewAnime, AnimeCompleted, StreamAdded, ScheduleChanged}
+
+// Event adalah satu perubahan yang terdeteksi saat membandingkan hasil scrape dengan katalog.
+// ID diisi oleh Bus dan selalu naik, termasuk setelah restart.
+type Event struct {
+	ID          uint64    `json:"id"`
+	Type        string    `json:"type"`
+	AnimeSlug   string    `json:"anime_slug"`
+	AnimeTitle  string    `json:"anime_title,omitempty"`
+	Genres      []string  `json:"genres,omitempty"`
+	Status      string    `json:"status,omitempty"`
+	Episode     string    `json:"episode,omitempty"`
+	EpisodeURL  string    `json:"episode_url,omitempty"`
+	ServerName  string    `json:"server_name,omitempty"`
+	StreamURL   string    `json:"stream_url,omitempty"`
+	Day         string    `json:"day,omitempty"`
+	ReleaseTime string    `json:"release_time,omitempty"`
+	Previous    string    `json:"previous,omitempty"`
+	Time        time.Time `json:"time"`
+}
+
+// Bus meneruskan event ke semua subscriber. Subscriber dipanggil secara sinkron
+// sehingga tidak boleh blocking; kirim ke channel atau goroutine sendiri.
+type Bus struct {
+	pubMu sync.Mutex // menjaga urutan event sama dengan urutan ID
+	mu    sync.RWMutex
+	seq   uint64
+	next  int
+	subs  map[int]func(Event)
+}
+
+// NewBus membuat bus baru. Nomor ID dimulai dari waktu sekarang (milidetik)
+// agar tetap lebih besar dari ID sebelum restart.
+func NewBus() *Bus {
+	return &Bus{
+		seq:  uint64(time.Now().UnixMilli()),
+		subs: make(map[int]func(Event)),
+	}
+}
+
+// Default adalah bus yang dipakai repository dan kanal push.
+var Default = NewBus()
+
+// Publish memberi ID dan waktu pada event lalu meneruskannya ke semua subscriber.
+func (b *Bus) Publish(e Event) Event {
+	b.pubMu.Lock()
+	defer b.pubMu.Unlock()
+
+	b.seq++
+	e.ID = b.seq
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	b.mu.RLock()
+	subs := make([]func(Event), 0, len(b.subs))
+	for _, fn := range b.subs {
+		subs = append(subs, fn)
+	}
+	b.mu.RUnlock()
+
+	for _, fn := range subs {
+		fn(e)
+	}
+	return e
+}
+
+// Subscribe mendaftarkan fungsi penerima event. Fungsi yang dikembalikan membatalkan langganan.
+func (b *Bus) Subscribe(fn func(Event)) func() {
+	b.mu.Lock()
+	id := b.next
+	b.next++
+	b.subs[id] = fn
+	b.mu.Unlock()
+
+	return func() {
+		b.mu.Lock()
+		delete(b.subs, id)
+		b.mu.Unlock()
+	}
+}
+
+// Publish mengirim event lewat Default bus.
+func Publish(e Event) Event {
+	return Default.Publish(e)
+}
@@ -0,0 +1,59 @@
+package events
+
+import "testing"
+
+func TestBusAssignsIncreasingIDs(t *testing.T) {
+	bus := NewBus()
+	var got []uint64
+	cancel := bus.Subscribe(func(e Event) { got = append(got, e.ID) })
+
+	first := bus.Publish(Event{Type: NewEpisode})
+	second := bus.Publish(Event{Type: NewEpisode})
+	cancel()
+	bus.Publish(Event{Type: NewEpisode})
+
+	if second.ID != first.ID+1 {
+		t.Errorf("ID seharusnya berurutan, didapat %d lalu %d", first.ID, second.ID)
+	}
+	if len(got) != 2 {
+		t.Errorf("subscriber yang dibatalkan tidak boleh menerima event, didapat %d", len(got))
+	}
+}
+
+func TestReplaySince(t *testing.T) {
+	r := NewReplay(3)
+	for id := uint64(1); id <= 5; id++ {
+		r.Add(Event{ID: id})
+	}
+
+	list, complete := r.Since(3)
+	if !complete || len(list) != 2 || list[0].ID != 4 || list[1].ID != 5 {
+		t.Errorf("replay setelah 3 tidak sesuai: %+v complete=%v", list, complete)
+	}
+
+	list, complete = r.Since(1)
+	if complete || len(list) != 3 || list[0].ID != 3 {
+		t.Errorf("event 2 sudah terbuang, replay seharusnya tidak lengkap: %+v complete=%v", list, complete)
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	e := Event{Type: NewEpisode, AnimeSlug: "naruto", Genres: []string{"Action", "Comedy"}}
+
+	cases := []struct {
+		filter Filter
+		want   bool
+	}{
+		{Filter{}, true},
+		{Filter{Slugs: []string{"bleach", "Naruto"}}, true},
+		{Filter{Slugs: []string{"bleach"}}, false},
+		{Filter{Genres: []string{"comedy"}}, true},
+		{Filter{Genres: []string{"romance"}}, false},
+		{Filter{Types: []string{StreamAdded}}, false},
+	}
+	for _, tc := range cases {
+		if got := tc.filter.Match(e); got != tc.want {
+			t.Errorf("%+v: didapat %v, seharusnya %v", tc.filter, got, tc.want)
+		}
+	}
+}
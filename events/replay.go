@@ -0,0 +1,100 @@
+package events
+
+import (
+	"strings"
+	"sync"
+)
+
+// Replay menyimpan event terakhir di ring buffer agar klien yang menyambung ulang
+// bisa melanjutkan dari Last-Event-ID.
+type Replay struct {
+	mu   sync.RWMutex
+	buf  []Event
+	next int
+	size int
+}
+
+// NewReplay membuat buffer berukuran size event.
+func NewReplay(size int) *Replay {
+	if size < 1 {
+		size = 1
+	}
+	return &Replay{size: size}
+}
+
+// Add menyimpan event, menimpa event paling lama jika buffer penuh.
+func (r *Replay) Add(e Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.buf) < r.size {
+		r.buf = append(r.buf, e)
+		return
+	}
+	r.buf[r.next] = e
+	r.next = (r.next + 1) % r.size
+}
+
+// Since mengembalikan event dengan ID lebih besar dari lastID, dari yang paling lama.
+// complete bernilai false jika sebagian event setelah lastID sudah terbuang dari buffer.
+func (r *Replay) Since(lastID uint64) (list []Event, complete bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.buf) == 0 {
+		return nil, true
+	}
+	oldest := r.buf[r.next%len(r.buf)]
+	complete = lastID+1 >= oldest.ID
+	for i := 0; i < len(r.buf); i++ {
+		e := r.buf[(r.next+i)%len(r.buf)]
+		if e.ID > lastID {
+			list = append(list, e)
+		}
+	}
+	return list, complete
+}
+
+// Filter memilih event berdasarkan jenis, slug anime, atau genre. Field kosong berarti semua.
+type Filter struct {
+	Types  []string
+	Slugs  []string
+	Genres []string
+}
+
+// ParseList memecah nilai query yang dipisah koma menjadi slice tanpa elemen kosong.
+func ParseList(raw string) []string {
+	var out []string
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// Match mengecek apakah event lolos filter.
+func (f Filter) Match(e Event) bool {
+	if len(f.Types) > 0 && !containsFold(f.Types, e.Type) {
+		return false
+	}
+	if len(f.Slugs) > 0 && !containsFold(f.Slugs, e.AnimeSlug) {
+		return false
+	}
+	if len(f.Genres) > 0 {
+		for _, genre := range e.Genres {
+			if containsFold(f.Genres, genre) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
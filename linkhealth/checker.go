@@ -0,0 +1,258 @@
+// Package linkhealth mengecek apakah link streaming dan download episode masih hidup,
+// dengan jumlah request bersamaan yang dibatasi dan hasil yang di-cache.
+package linkhealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Result adalah hasil pengecekan satu link.
+type Result struct {
+	URL         string    `json:"url"`
+	Alive       bool      `json:"alive"`
+	Method      string    `json:"method,omitempty" example:"HEAD"`
+	StatusCode  int       `json:"status_code,omitempty" example:"200"`
+	LatencyMs   int64     `json:"latency_ms" example:"182"`
+	ContentType string    `json:"content_type,omitempty" example:"video/mp4"`
+	Error       string    `json:"error,omitempty"`
+	CheckedAt   time.Time `json:"checked_at"`
+}
+
+// Options mengatur pengecekan link.
+type Options struct {
+	Concurrency int
+	Timeout     time.Duration
+	CacheTTL    time.Duration
+	UserAgent   string
+}
+
+// Checker mengecek link dengan HEAD, lalu GET satu byte (Range) jika host menolak HEAD.
+// Koneksi ke alamat privat atau loopback selalu ditolak, karena link berasal dari halaman sumber.
+type Checker struct {
+	opts   Options
+	client *http.Client
+	sem    chan struct{}
+
+	mu       sync.Mutex
+	cache    map[string]Result
+	inflight singleflight.Group
+}
+
+// errPrivateAddress dikembalikan jika link mengarah ke alamat jaringan internal.
+var errPrivateAddress = errors.New("alamat privat tidak dicek")
+
+// NewChecker membuat checker dengan batas request bersamaan dan masa berlaku cache.
+func NewChecker(opts Options) *Checker {
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 8 * time.Second
+	}
+	return &Checker{
+		opts:   opts,
+		client: newClient(opts.Timeout, false),
+		sem:    make(chan struct{}, opts.Concurrency),
+		cache:  make(map[string]Result),
+	}
+}
+
+// newClient membuat client yang menolak koneksi ke alamat privat kecuali allowPrivate (untuk test).
+// Pengecekan dilakukan pada IP yang benar-benar di-dial, sehingga redirect dan DNS rebinding ikut tertangkap.
+func newClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			if allowPrivate {
+				return nil
+			}
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+				return errPrivateAddress
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = nil
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 5 {
+				return errors.New("terlalu banyak redirect")
+			}
+			return nil
+		},
+	}
+}
+
+// Cached mengembalikan hasil cek yang masih berlaku tanpa melakukan request.
+func (c *Checker) Cached(rawURL string) (Result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result, ok := c.cache[rawURL]
+	if !ok || time.Since(result.CheckedAt) > c.opts.CacheTTL {
+		return Result{}, false
+	}
+	return result, true
+}
+
+// Check mengecek satu link, memakai cache jika masih berlaku. Pemanggil bersamaan untuk URL yang sama
+// menunggu satu probe yang sama; probe itu tidak ikut batal jika salah satu pemanggil membatalkan ctx.
+func (c *Checker) Check(ctx context.Context, rawURL string) Result {
+	if result, ok := c.Cached(rawURL); ok {
+		return result
+	}
+
+	flight := c.inflight.DoChan(rawURL, func() (interface{}, error) {
+		return c.checkNow(context.WithoutCancel(ctx), rawURL), nil
+	})
+	select {
+	case done := <-flight:
+		return done.Val.(Result)
+	case <-ctx.Done():
+		return Result{URL: rawURL, Error: ctx.Err().Error(), CheckedAt: time.Now()}
+	}
+}
+
+// checkNow menunggu slot, mengecek link, lalu menyimpan hasilnya di cache.
+func (c *Checker) checkNow(ctx context.Context, rawURL string) Result {
+	c.sem <- struct{}{}
+	result := c.probe(ctx, rawURL)
+	<-c.sem
+
+	c.mu.Lock()
+	for key, old := range c.cache {
+		if time.Since(old.CheckedAt) > c.opts.CacheTTL {
+			delete(c.cache, key)
+		}
+	}
+	c.cache[rawURL] = result
+	c.mu.Unlock()
+	return result
+}
+
+// CheckAll mengecek banyak link sekaligus (duplikat hanya dicek sekali) dan mengembalikan hasil per URL.
+func (c *Checker) CheckAll(ctx context.Context, urls []string) map[string]Result {
+	results := make(map[string]Result, len(urls))
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, rawURL := range urls {
+		mu.Lock()
+		_, seen := results[rawURL]
+		results[rawURL] = Result{}
+		mu.Unlock()
+		if seen {
+			continue
+		}
+		wg.Add(1)
+		go func(rawURL string) {
+			defer wg.Done()
+			result := c.Check(ctx, rawURL)
+			mu.Lock()
+			results[rawURL] = result
+			mu.Unlock()
+		}(rawURL)
+	}
+	wg.Wait()
+	return results
+}
+
+// Stats mengembalikan jumlah hasil di cache untuk monitoring.
+func (c *Checker) Stats() map[string]interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	alive := 0
+	for _, result := range c.cache {
+		if result.Alive {
+			alive++
+		}
+	}
+	return map[string]interface{}{
+		"cached":      len(c.cache),
+		"alive":       alive,
+		"dead":        len(c.cache) - alive,
+		"concurrency": c.opts.Concurrency,
+	}
+}
+
+// probe mengirim HEAD; jika host tidak mendukung HEAD, dicoba GET dengan Range satu byte.
+func (c *Checker) probe(ctx context.Context, rawURL string) Result {
+	result := Result{URL: rawURL, CheckedAt: time.Now()}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		result.Error = "URL tidak valid"
+		return result
+	}
+
+	start := time.Now()
+	resp, err := c.do(ctx, http.MethodHead, rawURL)
+	result.Method = http.MethodHead
+	if err == nil && headUnsupported(resp.StatusCode) {
+		resp.Body.Close()
+		resp, err = c.do(ctx, http.MethodGet, rawURL)
+		result.Method = http.MethodGet
+	}
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		if errors.Is(err, errPrivateAddress) {
+			result.Error = errPrivateAddress.Error()
+		} else {
+			result.Error = err.Error()
+		}
+		return result
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	result.StatusCode = resp.StatusCode
+	result.ContentType = strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0])
+	result.Alive = resp.StatusCode >= 200 && resp.StatusCode < 300
+	if !result.Alive {
+		result.Error = fmt.Sprintf("status HTTP %d", resp.StatusCode)
+	}
+	return result
+}
+
+func (c *Checker) do(ctx context.Context, method, rawURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.opts.UserAgent != "" {
+		req.Header.Set("User-Agent", c.opts.UserAgent)
+	}
+	if method == http.MethodGet {
+		req.Header.Set("Range", "bytes=0-0")
+	}
+	return c.client.Do(req)
+}
+
+// headUnsupported mengenali status yang biasa dipakai host untuk menolak HEAD walaupun file ada.
+func headUnsupported(status int) bool {
+	switch status {
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented, http.StatusForbidden, http.StatusBadRequest:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+package linkhealth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"multiplescrape/repository"
+)
+
+// newTestChecker membuat checker yang boleh menghubungi server uji di 127.0.0.1.
+func newTestChecker(concurrency int) *Checker {
+	c := NewChecker(Options{Concurrency: concurrency, Timeout: 2 * time.Second, CacheTTL: time.Minute})
+	c.client = newClient(2*time.Second, true)
+	return c
+}
+
+func stubServer(t *testing.T, hits *int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		switch r.URL.Path {
+		case "/ok.mp4":
+			w.Header().Set("Content-Type", "video/mp4")
+		case "/nohead":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			if r.Header.Get("Range") != "bytes=0-0" {
+				t.Errorf("GET cadangan seharusnya memakai Range satu byte, didapat %q", r.Header.Get("Range"))
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write([]byte("<"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCheckAliveDeadAndHeadFallback(t *testing.T) {
+	var hits int32
+	server := stubServer(t, &hits)
+	c := newTestChecker(4)
+
+	ok := c.Check(context.Background(), server.URL+"/ok.mp4")
+	if !ok.Alive || ok.Method != http.MethodHead || ok.StatusCode != 200 || ok.ContentType != "video/mp4" {
+		t.Errorf("link hidup tidak terdeteksi: %+v", ok)
+	}
+
+	fallback := c.Check(context.Background(), server.URL+"/nohead")
+	if !fallback.Alive || fallback.Method != http.MethodGet || fallback.StatusCode != http.StatusPartialContent || fallback.ContentType != "text/html" {
+		t.Errorf("host yang menolak HEAD seharusnya dicek dengan GET: %+v", fallback)
+	}
+
+	dead := c.Check(context.Background(), server.URL+"/gone")
+	if dead.Alive || dead.StatusCode != http.StatusNotFound || dead.Error == "" {
+		t.Errorf("link mati seharusnya dilaporkan: %+v", dead)
+	}
+}
+
+func TestCheckRejectsPrivateAddresses(t *testing.T) {
+	var hits int32
+	server := stubServer(t, &hits)
+	c := NewChecker(Options{Concurrency: 1, Timeout: time.Second, CacheTTL: time.Minute})
+
+	result := c.Check(context.Background(), server.URL+"/ok.mp4")
+	if result.Alive || result.Error != errPrivateAddress.Error() {
+		t.Errorf("alamat loopback seharusnya ditolak, didapat %+v", result)
+	}
+	if atomic.LoadInt32(&hits) != 0 {
+		t.Error("server di alamat privat tidak boleh dihubungi")
+	}
+}
+
+func TestCheckAllCachesAndBoundsConcurrency(t *testing.T) {
+	var inFlight, maxInFlight, hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	}))
+	defer server.Close()
+	c := newTestChecker(2)
+
+	urls := []string{server.URL + "/1", server.URL + "/2", server.URL + "/3", server.URL + "/4", server.URL + "/5", server.URL + "/1"}
+	results := c.CheckAll(context.Background(), urls)
+	if len(results) != 5 {
+		t.Fatalf("URL duplikat seharusnya dicek sekali, didapat %d hasil", len(results))
+	}
+	if max := atomic.LoadInt32(&maxInFlight); max > 2 {
+		t.Errorf("request bersamaan seharusnya paling banyak 2, didapat %d", max)
+	}
+
+	c.CheckAll(context.Background(), urls)
+	if got := atomic.LoadInt32(&hits); got != 5 {
+		t.Errorf("hasil cek seharusnya di-cache, didapat %d request", got)
+	}
+}
+
+func TestCheckDeduplicatesConcurrentProbes(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer server.Close()
+	c := newTestChecker(4)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if result := c.Check(context.Background(), server.URL+"/ep1.mp4"); !result.Alive {
+				t.Errorf("link seharusnya hidup, didapat %+v", result)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Errorf("pengecekan bersamaan untuk URL yang sama seharusnya satu request, didapat %d", got)
+	}
+}
+
+func TestCheckEpisodeAndAnnotate(t *testing.T) {
+	var hits int32
+	server := stubServer(t, &hits)
+	c := newTestChecker(4)
+
+	data := repository.EpisodeDetailData{
+		Title: "Naruto Episode 1",
+		StreamingServers: []repository.StreamingServer{
+			{ServerName: "Nakama 1080p", StreamingURL: server.URL + "/ok.mp4"},
+			{ServerName: "Mati 720p", StreamingURL: server.URL + "/gone"},
+		},
+		DownloadLinks: map[string]map[string][]repository.DownloadProvider{
+			"MP4": {"720p": {{Provider: "Pixeldrain", URL: server.URL + "/nohead"}}},
+		},
+	}
+
+	// Sebelum dicek, server tidak punya anotasi
+	if annotated := c.Annotate(data.StreamingServers); annotated[0].Alive != nil {
+		t.Error("server yang belum dicek tidak boleh punya anotasi alive")
+	}
+
+	health := c.CheckEpisode(context.Background(), "https://gomunime.co/naruto-episode-1/", data)
+	want := Summary{Streams: 2, AliveStreams: 1, Downloads: 1, AliveDownloads: 1}
+	if health.Summary != want {
+		t.Errorf("ringkasan didapat %+v, seharusnya %+v", health.Summary, want)
+	}
+	if health.DownloadLinks[0].Format != "MP4" || health.DownloadLinks[0].Quality != "720p" || !health.DownloadLinks[0].Alive {
+		t.Errorf("hasil link download tidak sesuai: %+v", health.DownloadLinks[0])
+	}
+
+	annotated := c.Annotate(data.StreamingServers)
+	if annotated[0].Alive == nil || !*annotated[0].Alive || annotated[1].Alive == nil || *annotated[1].Alive || annotated[0].CheckedAt == nil {
+		t.Errorf("anotasi dari cache tidak sesuai: %+v", annotated)
+	}
+	if data.StreamingServers[0].Alive != nil {
+		t.Error("daftar server asli tidak boleh diubah")
+	}
+
+	// Confidence score turun jika semua server mati
+	data.StreamingServers = c.Annotate(data.StreamingServers)
+	if score := repository.ValidateEpisodeDetailData(data); score != 1.0 {
+		t.Errorf("satu server hidup seharusnya tetap 1.0, didapat %v", score)
+	}
+	data.StreamingServers = data.StreamingServers[1:]
+	if score := repository.ValidateEpisodeDetailData(data); score != 0.0 {
+		t.Errorf("semua server mati seharusnya 0.0, didapat %v", score)
+	}
+}
@@ -0,0 +1,114 @@
+package linkhealth
+
+import (
+	"context"
+	"sort"
+
+	"multiplescrape/repository"
+)
+
+// ServerHealth adalah hasil cek satu server streaming.
+type ServerHealth struct {
+	ServerName string `json:"server_name" example:"Nakama 1080p"`
+	Result
+}
+
+// DownloadHealth adalah hasil cek satu link download.
+type DownloadHealth struct {
+	Format   string `json:"format" example:"MKV"`
+	Quality  string `json:"quality" example:"720p"`
+	Provider string `json:"provider" example:"Gofile"`
+	Result
+}
+
+// Summary merangkum jumlah link yang hidup.
+type Summary struct {
+	Streams        int `json:"streams" example:"4"`
+	AliveStreams   int `json:"alive_streams" example:"3"`
+	Downloads      int `json:"downloads" example:"12"`
+	AliveDownloads int `json:"alive_downloads" example:"10"`
+}
+
+// EpisodeHealth adalah hasil cek semua link satu episode.
+type EpisodeHealth struct {
+	EpisodeURL       string           `json:"episode_url"`
+	Summary          Summary          `json:"summary"`
+	StreamingServers []ServerHealth   `json:"streaming_servers"`
+	DownloadLinks    []DownloadHealth `json:"download_links"`
+}
+
+// EpisodeHealthResponse adalah response GET /api/v1/episode-detail/health.
+type EpisodeHealthResponse struct {
+	ConfidenceScore float64       `json:"confidence_score" example:"1"`
+	Data            EpisodeHealth `json:"data"`
+	Message         string        `json:"message"`
+	Source          string        `json:"source"`
+}
+
+// CheckEpisode mengecek semua server streaming dan link download episode. Link download diurutkan
+// berdasarkan format, kualitas, lalu urutan provider di halaman.
+func (c *Checker) CheckEpisode(ctx context.Context, episodeURL string, data repository.EpisodeDetailData) EpisodeHealth {
+	health := EpisodeHealth{
+		EpisodeURL:       episodeURL,
+		StreamingServers: make([]ServerHealth, 0, len(data.StreamingServers)),
+		DownloadLinks:    []DownloadHealth{},
+	}
+
+	var urls []string
+	for _, server := range data.StreamingServers {
+		urls = append(urls, server.StreamingURL)
+	}
+	for _, format := range sortedKeys(data.DownloadLinks) {
+		qualities := data.DownloadLinks[format]
+		for _, quality := range sortedKeys(qualities) {
+			for _, provider := range qualities[quality] {
+				urls = append(urls, provider.URL)
+				health.DownloadLinks = append(health.DownloadLinks, DownloadHealth{
+					Format: format, Quality: quality, Provider: provider.Provider, Result: Result{URL: provider.URL},
+				})
+			}
+		}
+	}
+
+	results := c.CheckAll(ctx, urls)
+	for _, server := range data.StreamingServers {
+		result := results[server.StreamingURL]
+		health.StreamingServers = append(health.StreamingServers, ServerHealth{ServerName: server.ServerName, Result: result})
+		health.Summary.Streams++
+		if result.Alive {
+			health.Summary.AliveStreams++
+		}
+	}
+	for i := range health.DownloadLinks {
+		health.DownloadLinks[i].Result = results[health.DownloadLinks[i].URL]
+		health.Summary.Downloads++
+		if health.DownloadLinks[i].Alive {
+			health.Summary.AliveDownloads++
+		}
+	}
+	return health
+}
+
+// Annotate mengembalikan salinan daftar server dengan alive dan checked_at dari cache.
+// Tidak ada request yang dikirim; server yang belum pernah dicek dibiarkan tanpa anotasi.
+func (c *Checker) Annotate(servers []repository.StreamingServer) []repository.StreamingServer {
+	annotated := make([]repository.StreamingServer, len(servers))
+	copy(annotated, servers)
+	for i := range annotated {
+		if result, ok := c.Cached(annotated[i].StreamingURL); ok {
+			alive, checkedAt := result.Alive, result.CheckedAt
+			annotated[i].Alive = &alive
+			annotated[i].CheckedAt = &checkedAt
+		}
+	}
+	return annotated
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -2,32 +2,62 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
-	"math/rand"
+	"math"
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/gin-contrib/sse"
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 
+	"multiplescrape/auth"
+	"multiplescrape/batch"
+	"multiplescrape/config"
+	"multiplescrape/crawler"
 	"multiplescrape/docs"
+	"multiplescrape/events"
+	"multiplescrape/linkhealth"
+	"multiplescrape/playlist"
+	"multiplescrape/ratelimit"
+	"multiplescrape/recommend"
+	"multiplescrape/related"
 	"multiplescrape/repository"
-)
-
-const (
-	BaseDomain = "https://gomunime.co"
+	"multiplescrape/search"
+	"multiplescrape/watchlist"
+	"multiplescrape/webhook"
+	"multiplescrape/wshub"
 )
 
 var (
 	serverStartTime = time.Now()
 	requestCount    = 0
 	requestMutex    sync.Mutex
+
+	apiKeys     *auth.KeyStore
+	authOptions auth.Options
+	ipLimiter   *ratelimit.Limiter
+	bgCrawler   *crawler.Crawler
+	webhooks    *webhook.Store
+	dispatcher  *webhook.Dispatcher
+	eventReplay *events.Replay
+	sseClients  int64
+	wsHub       *wshub.Hub
+	watchlists  *watchlist.Store
+	linkChecker *linkhealth.Checker
+	batchRunner *batch.Runner
 )
 
 // Anotasi untuk informasi utama Swagger
@@ -43,13 +73,113 @@ var (
 
 func main() {
 	gin.SetMode(gin.ReleaseMode)
+
+	// Muat konfigurasi dari file (CONFIG_FILE atau config.yaml) dan variabel lingkungan
+	cfg, err := config.Load(os.Getenv("CONFIG_FILE"))
+	if err != nil {
+		log.Fatal("Gagal memuat konfigurasi: ", err)
+	}
+	config.Set(cfg)
+	repository.SetRobotsOverrides(cfg.Robots.OverridePaths)
+	repository.SetRobotsRefreshInterval(cfg.Robots.RefreshInterval.Std())
+
+	// Probe mirror domain secara berkala untuk failover otomatis
+	repository.StartMirrorProber(cfg.Source.ProbeInterval.Std())
+
+	// Katalog persisten untuk anime, episode, dan stream yang pernah di-scrape
+	if cfg.Catalog.Enabled {
+		if err := repository.OpenCatalog(cfg.Catalog.Path); err != nil {
+			log.Fatal("Gagal membuka katalog: ", err)
+		}
+		defer repository.CloseCatalog()
+	}
+
+	// Webhook menerima event perubahan (episode baru, stream baru, dll.) dari katalog
+	webhooks, err = webhook.NewStore(cfg.Webhooks.File)
+	if err != nil {
+		log.Fatal("Gagal memuat webhook: ", err)
+	}
+	dispatcher = webhook.NewDispatcher(webhooks, webhook.Options{
+		Timeout:      cfg.Webhooks.Timeout.Std(),
+		MaxAttempts:  cfg.Webhooks.MaxAttempts,
+		RetryBackoff: cfg.Webhooks.RetryBackoff.Std(),
+		Workers:      cfg.Webhooks.Workers,
+		LogSize:      cfg.Webhooks.DeliveryLogSize,
+	})
+	defer dispatcher.Start(events.Default)()
+
+	// Buffer event terakhir untuk klien SSE yang menyambung ulang dengan Last-Event-ID
+	eventReplay = events.NewReplay(cfg.Events.ReplaySize)
+	events.Default.Subscribe(eventReplay.Add)
+
+	// Hub WebSocket untuk klien yang berlangganan event per anime
+	wsHub = wshub.NewHub(wshub.Options{
+		MaxSubscriptions: cfg.WebSocket.MaxSubscriptions,
+		SendBuffer:       cfg.WebSocket.SendBuffer,
+		PingInterval:     cfg.WebSocket.PingInterval.Std(),
+		MaxMessageBytes:  int64(cfg.WebSocket.MaxMessageBytes),
+	})
+	defer wsHub.Start(events.Default)()
+
+	// Watchlist pengguna untuk feed episode yang belum ditonton
+	watchlists, err = watchlist.NewStore(cfg.Watchlists.File, cfg.Watchlists.MaxAnime)
+	if err != nil {
+		log.Fatal("Gagal memuat watchlist: ", err)
+	}
+
+	// Slot bersama untuk semua operasi POST /api/v1/batch
+	batchRunner = batch.NewRunner(cfg.Batch.Concurrency)
+
+	// Pengecekan link streaming dan download episode
+	linkChecker = linkhealth.NewChecker(linkhealth.Options{
+		Concurrency: cfg.StreamHealth.Concurrency,
+		Timeout:     cfg.StreamHealth.Timeout.Std(),
+		CacheTTL:    cfg.StreamHealth.CacheTTL.Std(),
+		UserAgent:   cfg.Source.UserAgent,
+	})
+
+	// Crawler background menyegarkan halaman utama, jadwal, dan detail secara berkala
+	bgCrawler = crawler.NewSourceCrawler(cfg.Crawler)
+	if cfg.Crawler.Enabled {
+		defer bgCrawler.Start()()
+	}
+
+	// API key untuk autentikasi, scope, dan kuota per klien
+	apiKeys, err = auth.NewKeyStore(cfg.Auth.KeysFile, cfg.Auth.DefaultRateLimit, cfg.Auth.DefaultDailyQuota)
+	if err != nil {
+		log.Fatal("Gagal memuat API key: ", err)
+	}
+	defer apiKeys.StartPersist(keyUsageFlushInterval)()
+	authOptions = auth.Options{
+		Header:     cfg.Auth.Header,
+		QueryParam: cfg.Auth.QueryParam,
+		Required:   cfg.Auth.Required,
+	}
+
 	router := gin.Default()
 
+	// Hanya percaya X-Forwarded-For dari proxy lokal (nginx/Passenger)
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatal("Gagal mengatur trusted proxies: ", err)
+	}
+
+	// Rate limit per IP klien, dengan batas berbeda per kelas route
+	limits := map[string]int{}
+	if cfg.RateLimit.Enabled {
+		limits[ratelimit.ClassList] = cfg.RateLimit.ListPerMinute
+		limits[ratelimit.ClassSearch] = cfg.RateLimit.SearchPerMinute
+		limits[ratelimit.ClassDetail] = cfg.RateLimit.DetailPerMinute
+	}
+	ipLimiter = ratelimit.NewLimiter(limits)
+	limitList := ipLimiter.Middleware(ratelimit.ClassList, hasAPIKey)
+	limitSearch := ipLimiter.Middleware(ratelimit.ClassSearch, hasAPIKey)
+	limitDetail := ipLimiter.Middleware(ratelimit.ClassDetail, hasAPIKey)
+
 	// CORS middleware
 	router.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Admin-Token, "+cfg.Auth.Header)
 		
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -89,30 +219,63 @@ func main() {
 	router.GET("/monitoring", monitoringHandler)
 	
 	// Grup endpoint baru untuk v1
-	apiV1 := router.Group("/api/v1")
+	apiV1 := router.Group("/api/v1", auth.Middleware(apiKeys, authOptions, auth.ScopeRead))
 	{
 		// Endpoint baru untuk jadwal rilis
-		apiV1.GET("/home", getAnimeDataHandler)
-		apiV1.GET("/jadwal-rilis/", getJadwalRilisHandler)
-		apiV1.GET("/jadwal-rilis/:day", getJadwalRilisByDayHandler)
-		apiV1.GET("/movie/", getMovieListHandler)
-		apiV1.GET("/anime-detail/", getAnimeDetailHandler)
-		apiV1.GET("/episode-detail/", getEpisodeDetailHandler)
-		apiV1.GET("/anime-terbaru/", getAnimeTerbaruHandler)
-		apiV1.GET("/search/", getSearchHandler)
+		apiV1.GET("/home", limitList, getAnimeDataHandler)
+		apiV1.GET("/jadwal-rilis/", limitList, getJadwalRilisHandler)
+		apiV1.GET("/jadwal-rilis/:day", limitList, getJadwalRilisByDayHandler)
+		apiV1.GET("/movie/", limitList, getMovieListHandler)
+		apiV1.GET("/anime-detail/", limitDetail, getAnimeDetailHandler)
+		apiV1.GET("/episode-detail/", limitDetail, getEpisodeDetailHandler)
+		apiV1.GET("/episode-detail/health", limitDetail, getEpisodeHealthHandler)
+		apiV1.GET("/episode/:episode_slug", limitDetail, getEpisodeBySlugHandler)
+		apiV1.GET("/anime-terbaru/", limitList, getAnimeTerbaruHandler)
+		apiV1.GET("/search/", limitSearch, getSearchHandler)
+		apiV1.GET("/suggest", limitList, getSuggestHandler)
+		apiV1.GET("/anime", limitSearch, getBrowseHandler)
+		apiV1.GET("/anime/:slug/playlist.m3u8", limitDetail, getPlaylistHandler)
+		apiV1.GET("/anime/:slug/related", limitDetail, getRelatedHandler)
+		apiV1.POST("/batch", limitList, batchHandler)
+		apiV1.GET("/events/stream", limitList, eventsStreamHandler)
+		apiV1.GET("/ws", limitList, websocketHandler)
+		apiV1.POST("/watchlists", limitList, createWatchlistHandler)
+		apiV1.GET("/watchlists/:id", limitList, getWatchlistHandler)
+		apiV1.DELETE("/watchlists/:id", limitList, deleteWatchlistHandler)
+		apiV1.PUT("/watchlists/:id/anime/:anime_slug", limitList, addWatchlistAnimeHandler)
+		apiV1.DELETE("/watchlists/:id/anime/:anime_slug", limitList, removeWatchlistAnimeHandler)
+		apiV1.POST("/watchlists/:id/anime/:anime_slug/watched", limitList, markWatchedHandler)
+		apiV1.GET("/watchlists/:id/unwatched", limitDetail, getUnwatchedHandler)
 		apiV1.GET("/monitoring", monitoringHandler) // Monitoring endpoint
 	}
 
-	// Get port from environment variable or use default
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	// Endpoint administrasi
+	if cfg.Admin.Token == "" {
+		log.Println("⚠️  ADMIN_TOKEN kosong, endpoint /admin hanya menerima API key dengan scope admin")
+	}
+	admin := router.Group("/admin", adminAuthMiddleware())
+	{
+		admin.GET("/config", adminConfigHandler)
+		admin.GET("/keys", adminKeysHandler)
+		admin.POST("/keys/reload", adminKeysReloadHandler)
+		admin.GET("/mirrors", adminMirrorsHandler)
+		admin.POST("/mirrors/probe", adminMirrorProbeHandler)
+		admin.GET("/crawler", adminCrawlerHandler)
+		admin.POST("/crawler/pause", adminCrawlerPauseHandler)
+		admin.POST("/crawler/resume", adminCrawlerResumeHandler)
+		admin.GET("/webhooks", adminListWebhooksHandler)
+		admin.POST("/webhooks", adminCreateWebhookHandler)
+		admin.GET("/webhooks/:id", adminGetWebhookHandler)
+		admin.PUT("/webhooks/:id", adminUpdateWebhookHandler)
+		admin.DELETE("/webhooks/:id", adminDeleteWebhookHandler)
+		admin.GET("/webhooks/:id/deliveries", adminWebhookDeliveriesHandler)
+		admin.POST("/webhooks/:id/test", adminTestWebhookHandler)
 	}
 
-	// Server address - use 127.0.0.1 for DOM Cloud compatibility
-	serverAddr := "127.0.0.1:" + port
+	// Server address - default 127.0.0.1 for DOM Cloud compatibility
+	serverAddr := cfg.Server.BindAddress + ":" + cfg.Server.Port
 	
-	log.Println("🚀 Server berjalan di http://127.0.0.1:" + port)
+	log.Println("🚀 Server berjalan di http://" + serverAddr)
 	log.Println("📊 Dashboard Basic: /static/dashboard.html")
 	log.Println("📈 Dashboard Advanced: /static/advanced-dashboard.html")
 	log.Println("🔧 System Monitoring: /monitoring")
@@ -124,6 +287,382 @@ func main() {
 	}
 }
 
+// apiError adalah response error yang belum dikirim. Dipakai fungsi yang dipanggil handler maupun batch,
+// sehingga keduanya menghasilkan status dan body yang sama.
+type apiError struct {
+	status int
+	body   gin.H
+}
+
+func newAPIError(status int, message string) *apiError {
+	return &apiError{status: status, body: gin.H{"error": message}}
+}
+
+// respond mengirim error sebagai response JSON.
+func (e *apiError) respond(c *gin.Context) {
+	c.JSON(e.status, e.body)
+}
+
+// robotsError mengembalikan error 403 jika salah satu URL sumber dilarang robots.txt.
+func robotsError(targetURLs ...string) *apiError {
+	for _, targetURL := range targetURLs {
+		err := repository.CheckRobots(targetURL)
+		var disallowed *repository.RobotsDisallowedError
+		if errors.As(err, &disallowed) {
+			return &apiError{status: http.StatusForbidden, body: gin.H{
+				"error": "Akses ke sumber dilarang oleh robots.txt.",
+				"host":  disallowed.Host,
+				"path":  disallowed.Path,
+			}}
+		}
+	}
+	return nil
+}
+
+// checkRobotsAllowed memastikan URL sumber boleh di-scrape menurut robots.txt.
+// Jika dilarang, response 403 langsung dikirim dan fungsi mengembalikan false.
+func checkRobotsAllowed(c *gin.Context, targetURLs ...string) bool {
+	if apiErr := robotsError(targetURLs...); apiErr != nil {
+		apiErr.respond(c)
+		return false
+	}
+	return true
+}
+
+// isForceRefresh membaca query force_refresh.
+func isForceRefresh(c *gin.Context) bool {
+	forceRefresh, _ := strconv.ParseBool(c.DefaultQuery("force_refresh", "false"))
+	return forceRefresh
+}
+
+// scrapeOptions mengembalikan opsi scraper untuk request ini;
+// force_refresh membuat halaman sumber diambil ulang tanpa cache disk.
+func scrapeOptions(c *gin.Context) []repository.ScrapeOption {
+	return freshOptions(isForceRefresh(c))
+}
+
+// freshOptions mengembalikan FreshFetch jika forceRefresh aktif.
+func freshOptions(forceRefresh bool) []repository.ScrapeOption {
+	if forceRefresh {
+		return []repository.ScrapeOption{repository.FreshFetch()}
+	}
+	return nil
+}
+
+// keyUsageFlushInterval adalah jeda penyimpanan pemakaian API key ke disk.
+const keyUsageFlushInterval = time.Minute
+
+// hasAPIKey bernilai true jika request sudah diautentikasi dengan API key,
+// yang punya rate limit sendiri sehingga tidak dibatasi per IP.
+func hasAPIKey(c *gin.Context) bool {
+	_, ok := c.Get(auth.ContextKeyName)
+	return ok
+}
+
+// adminAuthMiddleware menerima header X-Admin-Token atau API key dengan scope admin.
+// Jika admin.token kosong, hanya API key dengan scope admin yang diterima; tanpa keduanya endpoint admin ditolak.
+func adminAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cfg := config.Current()
+		token := cfg.Admin.Token
+		if token != "" && c.GetHeader("X-Admin-Token") == token {
+			c.Next()
+			return
+		}
+
+		if raw := auth.ExtractKey(c, authOptions); raw != "" {
+			decision := apiKeys.Authorize(raw, auth.ScopeAdmin)
+			if !decision.Allowed {
+				auth.Abort(c, decision)
+				return
+			}
+			c.Set(auth.ContextKeyName, decision.Key.Name)
+			c.Next()
+			return
+		}
+
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "Endpoint admin nonaktif: isi admin.token atau gunakan API key dengan scope admin."})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token admin tidak valid."})
+	}
+}
+
+// adminKeysHandler menampilkan statistik pemakaian setiap API key (tanpa nilai key).
+// @Summary      API Key Usage
+// @Description  Menampilkan daftar API key beserta scope, kuota, dan statistik pemakaian.
+// @Tags         Admin
+// @Produce      json
+// @Param        X-Admin-Token  header  string  false  "Token admin (atau API key dengan scope admin)"
+// @Success      200  {object}  map[string]interface{} "Statistik API key"
+// @Failure      401  {object}  map[string]string "Token admin tidak valid"
+// @Failure      503  {object}  map[string]string "Endpoint admin nonaktif"
+// @Router       /admin/keys [get]
+func adminKeysHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"keys": apiKeys.Usage()})
+}
+
+// adminKeysReloadHandler membaca ulang file API key tanpa restart server.
+// @Summary      Reload API Keys
+// @Description  Membaca ulang file API key. Statistik key yang masih ada dipertahankan.
+// @Tags         Admin
+// @Produce      json
+// @Param        X-Admin-Token  header  string  false  "Token admin (atau API key dengan scope admin)"
+// @Success      200  {object}  map[string]interface{} "Key berhasil dimuat ulang"
+// @Failure      500  {object}  map[string]string "File API key tidak valid"
+// @Router       /admin/keys/reload [post]
+func adminKeysReloadHandler(c *gin.Context) {
+	if err := apiKeys.Reload(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "API key berhasil dimuat ulang", "keys": apiKeys.Usage()})
+}
+
+// adminConfigHandler menampilkan konfigurasi efektif dengan nilai rahasia disensor.
+// @Summary      Effective Configuration
+// @Description  Menampilkan konfigurasi yang sedang aktif (file + env), nilai rahasia disensor.
+// @Tags         Admin
+// @Produce      json
+// @Param        X-Admin-Token  header  string  false  "Token admin (atau API key dengan scope admin)"
+// @Success      200  {object}  map[string]interface{} "Konfigurasi efektif"
+// @Failure      401  {object}  map[string]string "Token admin tidak valid"
+// @Failure      503  {object}  map[string]string "Endpoint admin nonaktif"
+// @Router       /admin/config [get]
+func adminConfigHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, config.Current().Redacted())
+}
+
+// adminMirrorsHandler menampilkan mirror aktif dan hasil probe setiap kandidat domain.
+// @Summary      Mirror Status
+// @Description  Menampilkan domain sumber yang aktif beserta status probe semua kandidat mirror.
+// @Tags         Admin
+// @Produce      json
+// @Param        X-Admin-Token  header  string  false  "Token admin (atau API key dengan scope admin)"
+// @Success      200  {object}  map[string]interface{} "Status mirror"
+// @Failure      401  {object}  map[string]string "Token admin tidak valid"
+// @Failure      503  {object}  map[string]string "Endpoint admin nonaktif"
+// @Router       /admin/mirrors [get]
+func adminMirrorsHandler(c *gin.Context) {
+	active, statuses := repository.MirrorStatuses()
+	c.JSON(http.StatusOK, gin.H{
+		"active":  active,
+		"source":  repository.SourceName(),
+		"mirrors": statuses,
+	})
+}
+
+// adminMirrorProbeHandler memaksa probe ulang semua mirror sekarang juga.
+// @Summary      Probe Mirrors
+// @Description  Menjalankan probe semua kandidat mirror dan memilih ulang domain aktif.
+// @Tags         Admin
+// @Produce      json
+// @Param        X-Admin-Token  header  string  false  "Token admin (atau API key dengan scope admin)"
+// @Success      200  {object}  map[string]interface{} "Status mirror setelah probe"
+// @Failure      401  {object}  map[string]string "Token admin tidak valid"
+// @Failure      503  {object}  map[string]string "Endpoint admin nonaktif"
+// @Router       /admin/mirrors/probe [post]
+func adminMirrorProbeHandler(c *gin.Context) {
+	repository.ProbeMirrors()
+	adminMirrorsHandler(c)
+}
+
+// adminCrawlerHandler menampilkan status crawler background beserta antrean job.
+// @Summary      Crawler Status
+// @Description  Menampilkan status crawler, task berkala, sisa budget, dan isi antrean job.
+// @Tags         Admin
+// @Produce      json
+// @Param        X-Admin-Token  header  string  false  "Token admin (atau API key dengan scope admin)"
+// @Success      200  {object}  crawler.Status "Status crawler"
+// @Failure      401  {object}  map[string]string "Token admin tidak valid"
+// @Failure      503  {object}  map[string]string "Endpoint admin nonaktif"
+// @Router       /admin/crawler [get]
+func adminCrawlerHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, bgCrawler.Status())
+}
+
+// adminCrawlerPauseHandler menjeda pemrosesan antrean crawler.
+// @Summary      Pause Crawler
+// @Description  Menjeda crawler. Job yang sedang berjalan diselesaikan, antrean tetap disimpan.
+// @Tags         Admin
+// @Produce      json
+// @Param        X-Admin-Token  header  string  false  "Token admin (atau API key dengan scope admin)"
+// @Success      200  {object}  crawler.Status "Status crawler setelah dijeda"
+// @Failure      401  {object}  map[string]string "Token admin tidak valid"
+// @Failure      503  {object}  map[string]string "Endpoint admin nonaktif"
+// @Router       /admin/crawler/pause [post]
+func adminCrawlerPauseHandler(c *gin.Context) {
+	bgCrawler.Pause()
+	adminCrawlerHandler(c)
+}
+
+// adminCrawlerResumeHandler melanjutkan pemrosesan antrean crawler.
+// @Summary      Resume Crawler
+// @Description  Melanjutkan crawler yang sedang dijeda.
+// @Tags         Admin
+// @Produce      json
+// @Param        X-Admin-Token  header  string  false  "Token admin (atau API key dengan scope admin)"
+// @Success      200  {object}  crawler.Status "Status crawler setelah dilanjutkan"
+// @Failure      401  {object}  map[string]string "Token admin tidak valid"
+// @Failure      503  {object}  map[string]string "Endpoint admin nonaktif"
+// @Router       /admin/crawler/resume [post]
+func adminCrawlerResumeHandler(c *gin.Context) {
+	bgCrawler.Resume()
+	adminCrawlerHandler(c)
+}
+
+// adminListWebhooksHandler menampilkan semua webhook terdaftar (tanpa secret).
+// @Summary      List Webhooks
+// @Description  Menampilkan semua webhook yang terdaftar. Secret tidak ditampilkan.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        X-Admin-Token  header  string  false  "Token admin (atau API key dengan scope admin)"
+// @Success      200  {object}  map[string]interface{} "Daftar webhook"
+// @Failure      401  {object}  map[string]string "Token admin tidak valid"
+// @Failure      503  {object}  map[string]string "Endpoint admin nonaktif"
+// @Router       /admin/webhooks [get]
+func adminListWebhooksHandler(c *gin.Context) {
+	list := webhooks.List()
+	for i := range list {
+		list[i] = list[i].Redacted()
+	}
+	c.JSON(http.StatusOK, gin.H{"webhooks": list, "event_types": events.Types})
+}
+
+// adminCreateWebhookHandler mendaftarkan webhook baru.
+// @Summary      Create Webhook
+// @Description  Mendaftarkan URL penerima event. Secret dibuat acak jika kosong dan hanya ditampilkan sekali di response ini.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Token  header  string  false  "Token admin (atau API key dengan scope admin)"
+// @Param        webhook  body  webhook.Input  true  "Data webhook; events kosong berarti semua event"
+// @Success      201  {object}  webhook.Webhook "Webhook berhasil dibuat"
+// @Failure      400  {object}  map[string]string "Data webhook tidak valid"
+// @Router       /admin/webhooks [post]
+func adminCreateWebhookHandler(c *gin.Context) {
+	var in webhook.Input
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Body JSON tidak valid."})
+		return
+	}
+	created, err := webhooks.Create(in)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// adminGetWebhookHandler menampilkan satu webhook.
+// @Summary      Get Webhook
+// @Description  Menampilkan satu webhook berdasarkan ID (tanpa secret).
+// @Tags         Webhooks
+// @Produce      json
+// @Param        X-Admin-Token  header  string  false  "Token admin (atau API key dengan scope admin)"
+// @Param        id  path  string  true  "ID webhook"
+// @Success      200  {object}  webhook.Webhook "Data webhook"
+// @Failure      404  {object}  map[string]string "Webhook tidak ditemukan"
+// @Router       /admin/webhooks/{id} [get]
+func adminGetWebhookHandler(c *gin.Context) {
+	hook, err := webhooks.Get(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, hook.Redacted())
+}
+
+// adminUpdateWebhookHandler mengubah webhook.
+// @Summary      Update Webhook
+// @Description  Mengubah URL, secret, daftar event, status aktif, atau deskripsi webhook. Field kosong tidak diubah.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Token  header  string  false  "Token admin (atau API key dengan scope admin)"
+// @Param        id  path  string  true  "ID webhook"
+// @Param        webhook  body  webhook.Input  true  "Field yang diubah"
+// @Success      200  {object}  webhook.Webhook "Webhook berhasil diubah"
+// @Failure      400  {object}  map[string]string "Data webhook tidak valid"
+// @Failure      404  {object}  map[string]string "Webhook tidak ditemukan"
+// @Router       /admin/webhooks/{id} [put]
+func adminUpdateWebhookHandler(c *gin.Context) {
+	var in webhook.Input
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Body JSON tidak valid."})
+		return
+	}
+	updated, err := webhooks.Update(c.Param("id"), in)
+	switch {
+	case errors.Is(err, webhook.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case err != nil:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusOK, updated.Redacted())
+	}
+}
+
+// adminDeleteWebhookHandler menghapus webhook.
+// @Summary      Delete Webhook
+// @Description  Menghapus webhook. Event berikutnya tidak lagi dikirim ke URL tersebut.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        X-Admin-Token  header  string  false  "Token admin (atau API key dengan scope admin)"
+// @Param        id  path  string  true  "ID webhook"
+// @Success      200  {object}  map[string]string "Webhook berhasil dihapus"
+// @Failure      404  {object}  map[string]string "Webhook tidak ditemukan"
+// @Router       /admin/webhooks/{id} [delete]
+func adminDeleteWebhookHandler(c *gin.Context) {
+	err := webhooks.Delete(c.Param("id"))
+	switch {
+	case errors.Is(err, webhook.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusOK, gin.H{"message": "Webhook berhasil dihapus"})
+	}
+}
+
+// adminWebhookDeliveriesHandler menampilkan log pengiriman sebuah webhook.
+// @Summary      Webhook Delivery Log
+// @Description  Menampilkan percobaan pengiriman terbaru (termasuk retry) untuk satu webhook, terbaru lebih dulu.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        X-Admin-Token  header  string  false  "Token admin (atau API key dengan scope admin)"
+// @Param        id  path  string  true  "ID webhook"
+// @Success      200  {object}  map[string]interface{} "Log pengiriman"
+// @Failure      404  {object}  map[string]string "Webhook tidak ditemukan"
+// @Router       /admin/webhooks/{id}/deliveries [get]
+func adminWebhookDeliveriesHandler(c *gin.Context) {
+	if _, err := webhooks.Get(c.Param("id")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"deliveries": dispatcher.Deliveries(c.Param("id"))})
+}
+
+// adminTestWebhookHandler mengirim event ping ke webhook.
+// @Summary      Test Webhook
+// @Description  Mengirim event "ping" bertanda tangan ke webhook secara langsung (tanpa retry) dan mengembalikan hasilnya.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        X-Admin-Token  header  string  false  "Token admin (atau API key dengan scope admin)"
+// @Param        id  path  string  true  "ID webhook"
+// @Success      200  {object}  webhook.Delivery "Hasil pengiriman"
+// @Failure      404  {object}  map[string]string "Webhook tidak ditemukan"
+// @Router       /admin/webhooks/{id}/test [post]
+func adminTestWebhookHandler(c *gin.Context) {
+	hook, err := webhooks.Get(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, dispatcher.Ping(hook))
+}
+
 // healthCheckHandler menangani permintaan health check.
 // @Summary      Health Check
 // @Description  Memeriksa apakah layanan berjalan dengan baik.
@@ -177,6 +716,22 @@ func monitoringHandler(c *gin.Context) {
 				"/api/v1/anime-terbaru/",
 			},
 		},
+		// Statistik per key hanya di /admin/keys; di sini cukup jumlahnya
+		"api_keys":   gin.H{"total": apiKeys.Len()},
+		"rate_limit": ipLimiter.Stats(),
+		"source": gin.H{
+			"name":        repository.SourceName(),
+			"active_base": repository.ActiveBaseURL(),
+		},
+		"catalog":    repository.CatalogStats(),
+		"events": gin.H{
+			"sse_clients": atomic.LoadInt64(&sseClients),
+			"websocket":   wsHub.Stats(),
+		},
+		"watchlists":    watchlists.Count(),
+		"search_index":  search.Default.Stats(),
+		"stream_health": linkChecker.Stats(),
+		"batch":         batchRunner.Stats(),
 		"system": gin.H{
 			"go_version":    runtime.Version(),
 			"os":           runtime.GOOS,
@@ -189,14 +744,444 @@ func monitoringHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, monitoring)
 }
 
+// eventsStreamHandler mengirim event perubahan secara live lewat Server-Sent Events.
+// @Summary      Live Event Stream (SSE)
+// @Description  Stream Server-Sent Events berisi event new_episode, schedule_changed, new_anime, anime_completed, dan stream_added
+// @Description  yang ditemukan crawler atau scrape biasa. Nama event SSE = jenis event, data = JSON event.
+// @Description  Klien yang menyambung ulang mengirim header Last-Event-ID (atau query last_event_id) untuk menerima event yang terlewat
+// @Description  dari buffer replay; jika sebagian sudah terbuang, event "replay_gap" dikirim lebih dulu.
+// @Description  Klien yang terlalu lambat diputus dan bisa menyambung ulang dengan Last-Event-ID.
+// @Tags         Events
+// @Produce      text/event-stream
+// @Param        anime_slug     query   string  false  "Filter slug anime, dipisah koma"
+// @Param        genre          query   string  false  "Filter genre, dipisah koma (tidak peka huruf besar/kecil)"
+// @Param        types          query   string  false  "Filter jenis event, dipisah koma"
+// @Param        last_event_id  query   string  false  "Alternatif header Last-Event-ID"
+// @Param        Last-Event-ID  header  string  false  "ID event terakhir yang diterima klien"
+// @Success      200  {object}  events.Event "Stream event"
+// @Failure      400  {object}  map[string]string "Last-Event-ID tidak valid"
+// @Failure      503  {object}  map[string]string "Terlalu banyak klien SSE"
+// @Router       /api/v1/events/stream [get]
+func eventsStreamHandler(c *gin.Context) {
+	cfg := config.Current().Events
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	var lastID uint64
+	if lastEventID != "" {
+		parsed, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Last-Event-ID harus berupa angka."})
+			return
+		}
+		lastID = parsed
+	}
+
+	clients := atomic.AddInt64(&sseClients, 1)
+	defer atomic.AddInt64(&sseClients, -1)
+	if cfg.MaxSSEClients > 0 && clients > int64(cfg.MaxSSEClients) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Terlalu banyak klien SSE, coba lagi nanti."})
+		return
+	}
+
+	filter := events.Filter{
+		Types:  events.ParseList(c.Query("types")),
+		Slugs:  events.ParseList(c.Query("anime_slug")),
+		Genres: events.ParseList(c.Query("genre")),
+	}
+
+	// Berlangganan sebelum replay agar tidak ada event yang terlewat di antaranya.
+	// Jika buffer klien penuh, koneksi diputus dan klien melanjutkan lewat Last-Event-ID.
+	incoming := make(chan events.Event, cfg.ClientBuffer)
+	overflow := make(chan struct{})
+	var overflowOnce sync.Once
+	cancel := events.Default.Subscribe(func(e events.Event) {
+		select {
+		case incoming <- e:
+		default:
+			overflowOnce.Do(func() { close(overflow) })
+		}
+	})
+	defer cancel()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	sent := lastID
+	send := func(e events.Event) {
+		if e.ID <= sent || !filter.Match(e) {
+			return
+		}
+		c.Render(-1, sse.Event{Id: strconv.FormatUint(e.ID, 10), Event: e.Type, Data: e})
+		sent = e.ID
+	}
+
+	c.Render(-1, sse.Event{Event: "ready", Retry: 5000, Data: gin.H{"last_event_id": lastID}})
+	if lastEventID != "" {
+		replay, complete := eventReplay.Since(lastID)
+		if !complete {
+			c.Render(-1, sse.Event{Event: "replay_gap", Data: gin.H{"message": "Sebagian event sudah tidak ada di buffer replay."}})
+		}
+		for _, e := range replay {
+			send(e)
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(cfg.Heartbeat.Std())
+	defer heartbeat.Stop()
+	for {
+		select {
+		case e := <-incoming:
+			send(e)
+			c.Writer.Flush()
+		case <-heartbeat.C:
+			c.Writer.WriteString(": ping\n\n")
+			c.Writer.Flush()
+		case <-overflow:
+			return
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+// websocketHandler membuka koneksi WebSocket untuk berlangganan event per anime.
+// @Summary      WebSocket Langganan Anime
+// @Description  Upgrade ke WebSocket. Klien mengirim {"type":"subscribe","id":"1","anime_slugs":["one-piece"]},
+// @Description  "unsubscribe", "list", atau "ping"; server membalas "ack" (berisi daftar langganan saat ini), "error", atau "pong".
+// @Description  Event new_episode, stream_added, schedule_changed, dan lainnya untuk anime yang dilangganinya dikirim sebagai
+// @Description  {"type":"event","event":{...}}. Jumlah langganan per koneksi dibatasi (lihat max_subscriptions di pesan "welcome"),
+// @Description  dan klien yang terlalu lambat membaca diputus dengan close code 1013 agar menyambung ulang.
+// @Tags         Events
+// @Success      101  {string}  string "Switching Protocols"
+// @Failure      400  {object}  map[string]string "Bukan request WebSocket"
+// @Failure      503  {object}  map[string]string "Terlalu banyak klien WebSocket"
+// @Router       /api/v1/ws [get]
+func websocketHandler(c *gin.Context) {
+	if !websocket.IsWebSocketUpgrade(c.Request) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Endpoint ini hanya menerima koneksi WebSocket."})
+		return
+	}
+	cfg := config.Current().WebSocket
+	if cfg.MaxClients > 0 && wsHub.Stats().Clients >= cfg.MaxClients {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Terlalu banyak klien WebSocket, coba lagi nanti."})
+		return
+	}
+	wsHub.ServeHTTP(c.Writer, c.Request)
+}
+
+// watchlistError menulis response error untuk operasi watchlist.
+func watchlistError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, watchlist.ErrNotFound), errors.Is(err, watchlist.ErrAnimeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, watchlist.ErrLimit):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+// createWatchlistHandler membuat watchlist baru.
+// @Summary      Create Watchlist
+// @Description  Membuat watchlist kosong. ID pada response adalah token akses watchlist; simpan dan kirim di setiap request berikutnya.
+// @Tags         Watchlist
+// @Accept       json
+// @Produce      json
+// @Param        watchlist  body  watchlist.CreateInput  false  "Nama watchlist (opsional)"
+// @Success      201  {object}  watchlist.Watchlist "Watchlist berhasil dibuat"
+// @Router       /api/v1/watchlists [post]
+func createWatchlistHandler(c *gin.Context) {
+	var in watchlist.CreateInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&in); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Body JSON tidak valid."})
+			return
+		}
+	}
+	created, err := watchlists.Create(in.Name)
+	if err != nil {
+		watchlistError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// getWatchlistHandler menampilkan isi watchlist.
+// @Summary      Get Watchlist
+// @Description  Menampilkan anime di watchlist beserta episode terakhir yang ditonton.
+// @Tags         Watchlist
+// @Produce      json
+// @Param        id  path  string  true  "ID watchlist"
+// @Success      200  {object}  watchlist.Watchlist "Data watchlist"
+// @Failure      404  {object}  map[string]string "Watchlist tidak ditemukan"
+// @Router       /api/v1/watchlists/{id} [get]
+func getWatchlistHandler(c *gin.Context) {
+	list, err := watchlists.Get(c.Param("id"))
+	if err != nil {
+		watchlistError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// deleteWatchlistHandler menghapus watchlist.
+// @Summary      Delete Watchlist
+// @Description  Menghapus watchlist beserta seluruh riwayat tontonnya.
+// @Tags         Watchlist
+// @Param        id  path  string  true  "ID watchlist"
+// @Success      204  "Watchlist dihapus"
+// @Failure      404  {object}  map[string]string "Watchlist tidak ditemukan"
+// @Router       /api/v1/watchlists/{id} [delete]
+func deleteWatchlistHandler(c *gin.Context) {
+	if err := watchlists.Delete(c.Param("id")); err != nil {
+		watchlistError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// addWatchlistAnimeHandler menambahkan anime ke watchlist.
+// @Summary      Add Anime to Watchlist
+// @Description  Menambahkan anime ke watchlist. last_watched_episode opsional untuk anime yang sudah ditonton sebagian;
+// @Description  tanpa itu semua episode dianggap belum ditonton. Menambahkan anime yang sudah ada tidak menghapus riwayatnya.
+// @Tags         Watchlist
+// @Accept       json
+// @Produce      json
+// @Param        id          path  string                 true   "ID watchlist"
+// @Param        anime_slug  path  string                 true   "Slug anime"
+// @Param        anime       body  watchlist.AnimeInput   false  "Episode terakhir yang sudah ditonton"
+// @Success      200  {object}  watchlist.Watchlist "Watchlist setelah diubah"
+// @Failure      400  {object}  map[string]string "Slug atau body tidak valid"
+// @Failure      404  {object}  map[string]string "Watchlist tidak ditemukan"
+// @Failure      409  {object}  map[string]string "Jumlah anime sudah mencapai batas"
+// @Router       /api/v1/watchlists/{id}/anime/{anime_slug} [put]
+func addWatchlistAnimeHandler(c *gin.Context) {
+	slug, err := watchlist.NormalizeSlug(c.Param("anime_slug"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var in watchlist.AnimeInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&in); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Body JSON tidak valid."})
+			return
+		}
+	}
+	updated, err := watchlists.AddAnime(c.Param("id"), slug, in.LastWatched)
+	if err != nil {
+		watchlistError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// removeWatchlistAnimeHandler menghapus anime dari watchlist.
+// @Summary      Remove Anime from Watchlist
+// @Description  Menghapus anime beserta riwayat tontonnya dari watchlist.
+// @Tags         Watchlist
+// @Produce      json
+// @Param        id          path  string  true  "ID watchlist"
+// @Param        anime_slug  path  string  true  "Slug anime"
+// @Success      200  {object}  watchlist.Watchlist "Watchlist setelah diubah"
+// @Failure      404  {object}  map[string]string "Watchlist atau anime tidak ditemukan"
+// @Router       /api/v1/watchlists/{id}/anime/{anime_slug} [delete]
+func removeWatchlistAnimeHandler(c *gin.Context) {
+	slug, err := watchlist.NormalizeSlug(c.Param("anime_slug"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	updated, err := watchlists.RemoveAnime(c.Param("id"), slug)
+	if err != nil {
+		watchlistError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// markWatchedHandler menandai episode sudah ditonton.
+// @Summary      Mark Episode Watched
+// @Description  Menandai episode sudah ditonton. Episode terakhir yang ditonton hanya bergerak maju,
+// @Description  jadi menandai episode lama tidak membuat episode yang lebih baru dianggap sudah ditonton.
+// @Tags         Watchlist
+// @Accept       json
+// @Produce      json
+// @Param        id          path  string                  true  "ID watchlist"
+// @Param        anime_slug  path  string                  true  "Slug anime"
+// @Param        episode     body  watchlist.WatchedInput  true  "Nomor episode yang ditonton"
+// @Success      200  {object}  watchlist.Watchlist "Watchlist setelah diubah"
+// @Failure      400  {object}  map[string]string "Body tidak valid"
+// @Failure      404  {object}  map[string]string "Watchlist atau anime tidak ditemukan"
+// @Router       /api/v1/watchlists/{id}/anime/{anime_slug}/watched [post]
+func markWatchedHandler(c *gin.Context) {
+	slug, err := watchlist.NormalizeSlug(c.Param("anime_slug"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var in watchlist.WatchedInput
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Body JSON tidak valid."})
+		return
+	}
+	updated, err := watchlists.MarkWatched(c.Param("id"), slug, in.Episode)
+	if err != nil {
+		watchlistError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// feedAnimeDetail mengambil detail anime untuk feed watchlist dan playlist: katalog jika masih segar,
+// scrape jika tidak, dan katalog lama jika scrape gagal.
+func feedAnimeDetail(slug string, forceRefresh bool, opts []repository.ScrapeOption) (repository.ScrapedAnimeDetails, string) {
+	if !forceRefresh {
+		if data, ok := repository.CachedAnimeDetail(slug, config.Current().Catalog.AnimeTTL.Std()); ok {
+			return data, "catalog"
+		}
+	}
+	var disallowed *repository.RobotsDisallowedError
+	if err := repository.CheckRobots(repository.AnimeDetailURL(slug)); !errors.As(err, &disallowed) {
+		if data := repository.ScrapeAnimeDetail(slug, opts...); data.Judul != "" {
+			return data, "scrape"
+		}
+	}
+	if data, ok := repository.CachedAnimeDetail(slug, time.Duration(math.MaxInt64)); ok {
+		return data, "catalog_stale"
+	}
+	return repository.ScrapedAnimeDetails{}, "unavailable"
+}
+
+// getUnwatchedHandler menampilkan episode yang belum ditonton untuk semua anime di watchlist.
+// @Summary      Unwatched Episodes
+// @Description  Menghitung episode yang dirilis setelah episode terakhir yang ditonton untuk setiap anime di watchlist,
+// @Description  dari daftar episode di katalog (atau scrape jika katalog sudah lewat catalog.anime_ttl).
+// @Description  Field source per anime: catalog, scrape, catalog_stale (scrape gagal, memakai data lama), atau unavailable.
+// @Tags         Watchlist
+// @Produce      json
+// @Param        id  path  string  true  "ID watchlist"
+// @Success      200  {object}  watchlist.UnwatchedResponse "Episode yang belum ditonton"
+// @Failure      404  {object}  map[string]string "Watchlist tidak ditemukan"
+// @Router       /api/v1/watchlists/{id}/unwatched [get]
+func getUnwatchedHandler(c *gin.Context) {
+	list, err := watchlists.Get(c.Param("id"))
+	if err != nil {
+		watchlistError(c, err)
+		return
+	}
+	// Detail diambil paralel dengan batas kecil agar sumber tidak dibanjiri request
+	results := make([]watchlist.UnwatchedAnime, len(list.Anime))
+	sem := make(chan struct{}, 4)
+	var wg sync.WaitGroup
+	for i, entry := range list.Anime {
+		wg.Add(1)
+		go func(i int, entry watchlist.Entry) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			// force_refresh sengaja diabaikan: satu request tidak boleh memaksa scrape ulang seluruh watchlist
+			data, source := feedAnimeDetail(entry.AnimeSlug, false, nil)
+			item := watchlist.UnwatchedAnime{
+				AnimeSlug:   entry.AnimeSlug,
+				Title:       repository.FillStrIfEmpty(data.Judul, repository.SlugToTitle(entry.AnimeSlug)),
+				LastWatched: entry.LastWatched,
+				Episodes:    []repository.EpisodeListItem{},
+				Source:      source,
+			}
+			for _, ep := range entry.Unwatched(data.EpisodeList) {
+				item.Episodes = append(item.Episodes, repository.NewEpisodeListItem(ep))
+			}
+			results[i] = item
+		}(i, entry)
+	}
+	wg.Wait()
+
+	total := 0
+	for _, item := range results {
+		total += len(item.Episodes)
+	}
+	c.JSON(http.StatusOK, watchlist.UnwatchedResponse{
+		WatchlistID:    list.ID,
+		TotalUnwatched: total,
+		Data:           results,
+		Message:        strconv.Itoa(total) + " episode belum ditonton dari " + strconv.Itoa(len(results)) + " anime.",
+	})
+}
+
+// Batas ukuran halaman untuk daftar yang dipaginasi.
+const (
+	defaultPageSize = 20
+	maxPageSize     = 50
+)
+
+// parseListParams membaca parameter filter, sort, dan paginasi yang dipakai bersama oleh endpoint daftar anime.
+// Jika ada parameter yang tidak valid, response 400 sudah ditulis dan ok bernilai false.
+func parseListParams(c *gin.Context, defaultSort string) (filter search.Filter, sortBy string, page, pageSize int, ok bool) {
+	filter = search.Filter{
+		Genres: events.ParseList(c.Query("genre")),
+		Type:   strings.TrimSpace(c.Query("type")),
+		Status: strings.TrimSpace(c.Query("status")),
+		Season: strings.TrimSpace(c.Query("season")),
+		Studio: strings.TrimSpace(c.Query("studio")),
+	}
+	if raw := c.Query("min_score"); raw != "" {
+		score, err := strconv.ParseFloat(raw, 64)
+		if err != nil || score < 0 || score > 10 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'min_score' harus angka 0 sampai 10."})
+			return filter, "", 0, 0, false
+		}
+		filter.MinScore = score
+	}
+
+	sortBy = c.DefaultQuery("sort", defaultSort)
+	if !search.ValidSort(sortBy) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'sort' harus salah satu dari: " + strings.Join(search.SortOptions, ", ") + "."})
+		return filter, "", 0, 0, false
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'page' harus angka mulai dari 1."})
+		return filter, "", 0, 0, false
+	}
+	pageSize, err = strconv.Atoi(c.DefaultQuery("page_size", strconv.Itoa(defaultPageSize)))
+	if err != nil || pageSize < 1 || pageSize > maxPageSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'page_size' harus angka 1 sampai " + strconv.Itoa(maxPageSize) + "."})
+		return filter, "", 0, 0, false
+	}
+	return filter, sortBy, page, pageSize, true
+}
+
 // getSearchHandler menangani permintaan pencarian anime.
 // @Summary      Search Anime
-// @Description  Mencari anime berdasarkan query.
+// @Description  Mencari anime berdasarkan query. Pencarian memakai indeks lokal dari katalog lebih dulu (toleran salah ketik,
+// @Description  mencakup judul, judul alternatif, sinopsis, dan genre) dan baru memanggil situs sumber jika indeks tidak menemukan apa pun.
+// @Description  Filter dan urutan berlaku sama untuk hasil indeks lokal maupun situs sumber. Hasil situs sumber dilengkapi data katalog
+// @Description  (season, studio, waktu episode terbaru) jika detail anime pernah di-scrape; tanpa data itu, anime tidak lolos filter season/studio.
 // @Tags         Anime List
 // @Produce      json
-// @Param        query  query  string  true  "Kata kunci pencarian"
+// @Param        query          query  string   true   "Kata kunci pencarian"
+// @Param        genre          query  string   false  "Filter genre, dipisah koma; anime harus memiliki semua genre (contoh: Action,Comedy)"
+// @Param        type           query  string   false  "Filter tipe: TV, Movie, OVA, ONA, Special"
+// @Param        status         query  string   false  "Filter status: Ongoing atau Completed"
+// @Param        season         query  string   false  "Filter season, cukup sebagian teks (contoh: Fall 2024 atau 2024)"
+// @Param        studio         query  string   false  "Filter studio, cukup sebagian teks (contoh: MAPPA)"
+// @Param        min_score      query  number   false  "Skor minimum 0-10; anime tanpa skor tidak lolos"
+// @Param        sort           query  string   false  "Urutan hasil"  Enums(relevance, score, title, latest)  default(relevance)
+// @Param        page           query  int      false  "Nomor halaman"  default(1)
+// @Param        page_size      query  int      false  "Jumlah hasil per halaman (maksimal 50)"  default(20)
+// @Param        force_refresh  query  boolean  false  "Lewati indeks lokal dan cari langsung di situs sumber"
 // @Success      200  {object}  repository.SearchResponse "Hasil pencarian"
-// @Failure      400  {object}  map[string]string "Parameter query tidak ditemukan"
+// @Failure      400  {object}  map[string]string "Parameter query kosong atau parameter filter tidak valid"
 // @Router       /api/v1/search/ [get]
 func getSearchHandler(c *gin.Context) {
 	query := c.Query("query")
@@ -204,36 +1189,208 @@ func getSearchHandler(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'query' wajib diisi."})
 		return
 	}
+	filter, sortBy, page, pageSize, ok := parseListParams(c, search.SortRelevance)
+	if !ok {
+		return
+	}
+	response, apiErr := searchAnime(query, filter, sortBy, page, pageSize, isForceRefresh(c))
+	if apiErr != nil {
+		apiErr.respond(c)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
 
-	scrapedResults := repository.ScrapeSearch(query)
+// searchAnime mencari di indeks lokal lebih dulu, lalu di situs sumber jika indeks tidak menemukan apa pun.
+func searchAnime(query string, filter search.Filter, sortBy string, page, pageSize int, forceRefresh bool) (repository.SearchResponse, *apiError) {
+	// --- Indeks Lokal ---
+	var results []repository.CatalogAnime
+	message := "Data diambil dari indeks katalog lokal"
+	if !forceRefresh {
+		for _, hit := range search.Default.Current().Search(query, 0) {
+			results = append(results, hit.Anime)
+		}
+	}
 
-	var searchResults []repository.SearchResultItem
-	for _, item := range scrapedResults {
-		searchResults = append(searchResults, repository.SearchResultItem{
-			Judul:     item.Judul,
-			URLAnime:  item.Tautan,
-			AnimeSlug: repository.GetSlugFromURL(item.Tautan),
-			URLCover:  item.Thumbnail,
-			Status:    repository.FillStrIfEmpty(item.Status, "N/A"),
-			Tipe:      repository.FillStrIfEmpty(item.Tipe, "N/A"),
-			Skor:      repository.FillStrIfEmpty(item.Skor, "N/A"),
-			Sinopsis:  repository.FillStrIfEmpty(item.Sinopsis, "Sinopsis tidak tersedia."),
-			Genre:     item.Genres,
-			Penonton:  "N/A", // Data tidak tersedia dari scraper
-		})
+	// --- Situs Sumber (jika indeks tidak menemukan apa pun) ---
+	if len(results) == 0 {
+		if apiErr := robotsError(repository.SearchURL(query)); apiErr != nil {
+			return repository.SearchResponse{}, apiErr
+		}
+		message = "Data berhasil diambil"
+		for _, item := range repository.ScrapeSearch(query) {
+			anime := repository.SearchResultAnime(item)
+			// Hasil scrape sudah digabung ke katalog, yang mungkin punya season/studio dari detail sebelumnya
+			if record, found := repository.CatalogAnimeBySlug(anime.Slug); found {
+				anime = record
+			}
+			results = append(results, anime)
+		}
 	}
 
-	// Validasi data dan set confidence score
-	confidenceScore := repository.ValidateSearchData(searchResults)
+	results = filter.Apply(results)
+	search.Sort(results, sortBy)
+	start, end, pagination := search.Paginate(len(results), page, pageSize)
 
-	response := repository.SearchResponse{
-		ConfidenceScore: confidenceScore,
+	searchResults := make([]repository.SearchResultItem, 0, end-start)
+	for _, anime := range results[start:end] {
+		searchResults = append(searchResults, repository.NewSearchResultItem(anime))
+	}
+
+	return repository.SearchResponse{
+		ConfidenceScore: repository.ValidateSearchData(searchResults),
 		Data:            searchResults,
-		Message:         "Data berhasil diambil",
-		Source:          "gomunime.co",
+		Pagination:      pagination,
+		Message:         message,
+		Source:          repository.SourceName(),
+	}, nil
+}
+
+// browseSlugPattern membatasi slug genre dan season yang diteruskan ke situs sumber.
+var browseSlugPattern = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// parseBrowseOption membaca satu filter arsip yang nilainya harus salah satu dari allowed.
+func parseBrowseOption(c *gin.Context, name string, allowed []string) (string, bool) {
+	value := strings.ToLower(strings.TrimSpace(c.Query(name)))
+	if value == "" {
+		return "", true
+	}
+	for _, option := range allowed {
+		if value == option {
+			return value, true
+		}
 	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter '" + name + "' harus salah satu dari: " + strings.Join(allowed, ", ") + "."})
+	return "", false
+}
 
-	c.JSON(http.StatusOK, response)
+// parseBrowseSlugs membaca daftar slug dipisah koma untuk filter genre atau season.
+func parseBrowseSlugs(c *gin.Context, name string) ([]string, bool) {
+	slugs := events.ParseList(strings.ToLower(c.Query(name)))
+	for _, slug := range slugs {
+		if !browseSlugPattern.MatchString(slug) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter '" + name + "' harus berisi slug huruf kecil, angka, dan tanda hubung (contoh: slice-of-life)."})
+			return nil, false
+		}
+	}
+	return slugs, true
+}
+
+// getBrowseHandler menangani permintaan daftar anime dari arsip situs sumber.
+// @Summary      Browse Anime
+// @Description  Menelusuri arsip anime situs sumber dengan filter yang sama seperti halaman /anime/ situs tersebut,
+// @Description  atau daftar A-Z lewat parameter letter (tidak bisa digabung dengan filter lain). Filter diteruskan apa adanya
+// @Description  ke situs sumber; setiap item dilengkapi sinopsis, skor, status, dan genre dari tooltip ajax.
+// @Tags         Anime List
+// @Produce      json
+// @Param        order          query  string   false  "Urutan"  Enums(update, latest, title, titlereverse, popular, rating)
+// @Param        status         query  string   false  "Status tayang"  Enums(ongoing, completed, hiatus, upcoming)
+// @Param        type           query  string   false  "Tipe anime"  Enums(tv, movie, ova, ona, special, bd, music)
+// @Param        genre          query  string   false  "Slug genre dipisah koma (contoh: action,slice-of-life)"
+// @Param        season         query  string   false  "Slug season dipisah koma (contoh: fall-2024)"
+// @Param        letter         query  string   false  "Daftar A-Z: huruf A-Z, 0-9, atau . untuk simbol"
+// @Param        page           query  int      false  "Nomor halaman"  default(1)
+// @Param        force_refresh  query  boolean  false  "Ambil ulang halaman dari situs sumber tanpa cache"
+// @Success      200  {object}  repository.BrowseResponse "Daftar anime"
+// @Failure      400  {object}  map[string]string "Parameter filter tidak valid"
+// @Router       /api/v1/anime [get]
+func getBrowseHandler(c *gin.Context) {
+	var opts repository.BrowseOptions
+	var ok bool
+	if opts.Order, ok = parseBrowseOption(c, "order", repository.BrowseOrders); !ok {
+		return
+	}
+	if opts.Status, ok = parseBrowseOption(c, "status", repository.BrowseStatuses); !ok {
+		return
+	}
+	if opts.Type, ok = parseBrowseOption(c, "type", repository.BrowseTypes); !ok {
+		return
+	}
+	if opts.Genres, ok = parseBrowseSlugs(c, "genre"); !ok {
+		return
+	}
+	if opts.Seasons, ok = parseBrowseSlugs(c, "season"); !ok {
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'page' harus angka mulai dari 1."})
+		return
+	}
+	opts.Page = page
+
+	if letter := strings.ToUpper(strings.TrimSpace(c.Query("letter"))); letter != "" {
+		if !repository.ValidBrowseLetter(letter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'letter' harus huruf A-Z, '0-9', atau '.'."})
+			return
+		}
+		if opts.Order != "" || opts.Status != "" || opts.Type != "" || len(opts.Genres) > 0 || len(opts.Seasons) > 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'letter' tidak bisa digabung dengan filter lain."})
+			return
+		}
+		opts.Letter = letter
+	}
+
+	if !checkRobotsAllowed(c, repository.BrowseURL(opts)) {
+		return
+	}
+
+	result := repository.ScrapeBrowse(opts, scrapeOptions(c)...)
+	items := make([]repository.SearchResultItem, 0, len(result.Items))
+	for _, item := range result.Items {
+		items = append(items, repository.NewSearchResultItem(repository.SearchResultAnime(item)))
+	}
+
+	message := "Data berhasil diambil"
+	if len(items) == 0 {
+		message = "Tidak ada anime yang cocok dengan filter di halaman ini."
+	}
+	c.JSON(http.StatusOK, repository.BrowseResponse{
+		ConfidenceScore: repository.ValidateSearchData(items),
+		Data:            items,
+		Pagination: repository.BrowsePagination{
+			Page:        page,
+			HasNextPage: result.HasNextPage,
+			HasPrevPage: page > 1,
+		},
+		Message: message,
+		Source:  repository.SourceName(),
+	})
+}
+
+// getSuggestHandler menangani permintaan autocomplete judul anime.
+// @Summary      Suggest Anime Titles
+// @Description  Mengembalikan hingga 10 saran judul yang diawali teks q, dari judul dan judul alternatif semua anime di katalog.
+// @Description  Endpoint ini hanya membaca indeks lokal dan tidak pernah memanggil situs sumber, jadi aman dipanggil di setiap ketikan.
+// @Tags         Anime List
+// @Produce      json
+// @Param        q      query  string  true   "Awal judul yang sedang diketik"
+// @Param        limit  query  int     false  "Jumlah saran (maksimal 10)"  default(10)
+// @Success      200  {object}  repository.SuggestResponse "Daftar saran judul"
+// @Failure      400  {object}  map[string]string "Parameter q kosong atau limit tidak valid"
+// @Router       /api/v1/suggest [get]
+func getSuggestHandler(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" || len(query) > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'q' wajib diisi (maksimal 100 karakter)."})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(search.MaxSuggestions)))
+	if err != nil || limit < 1 || limit > search.MaxSuggestions {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'limit' harus angka 1 sampai " + strconv.Itoa(search.MaxSuggestions) + "."})
+		return
+	}
+
+	suggestions := make([]repository.SuggestItem, 0, limit)
+	for _, anime := range search.Default.Current().Suggest(query, limit) {
+		suggestions = append(suggestions, repository.NewSuggestItem(anime))
+	}
+	c.JSON(http.StatusOK, repository.SuggestResponse{
+		Data:    suggestions,
+		Message: "Data diambil dari indeks katalog lokal",
+		Source:  repository.SourceName(),
+	})
 }
 
 // getAnimeTerbaruHandler menangani permintaan untuk daftar anime terbaru.
@@ -251,6 +1408,9 @@ func getAnimeTerbaruHandler(c *gin.Context) {
 	if err != nil || page < 1 {
 		page = 1
 	}
+	if !checkRobotsAllowed(c, repository.LatestPageURL(page)) {
+		return
+	}
 
 	// Gunakan scraper yang sudah ada untuk mengambil data dari halaman utama
 	latestItems := repository.ScrapeLatestByPage(page)
@@ -275,7 +1435,7 @@ func getAnimeTerbaruHandler(c *gin.Context) {
 		ConfidenceScore: 1.0,
 		Data:            animeTerbaruList,
 		Message:         "Data berhasil diambil",
-		Source:          "gomunime.co",
+		Source:          repository.SourceName(),
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -289,27 +1449,116 @@ func getAnimeTerbaruHandler(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        episode_url  query  string  true  "URL lengkap dari halaman episode"
-// @Param        force_refresh  query  boolean  false  "Force refresh cache (opsional, belum diimplementasikan)"
+// @Param        force_refresh  query  boolean  false  "Abaikan katalog dan scrape ulang dari sumber"
+// @Param        resolve  query  boolean  false  "Ubah URL embed setiap server menjadi URL media langsung (mp4/HLS)"
 // @Success      200  {object}  repository.EpisodeDetailResponse "Detail episode berhasil diambil"
-// @Failure      400  {object}  map[string]string "Parameter episode_url tidak valid atau kosong"
+// @Failure      400  {object}  map[string]string "Parameter episode_url tidak valid, kosong, atau bukan domain sumber"
 // @Failure      404  {object}  map[string]string "Episode tidak ditemukan"
 // @Router       /api/v1/episode-detail/ [get]
 func getEpisodeDetailHandler(c *gin.Context) {
-	episodeURL := c.Query("episode_url")
-	if episodeURL == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'episode_url' wajib diisi."})
+	episodeURL, ok := episodeURLParam(c)
+	if !ok {
 		return
 	}
+	respondEpisodeDetail(c, episodeURL)
+}
+
+// episodeURLParam membaca dan memvalidasi query episode_url. Jika tidak valid, response 400 sudah ditulis.
+func episodeURLParam(c *gin.Context) (string, bool) {
+	episodeURL, apiErr := validEpisodeURL(c.Query("episode_url"))
+	if apiErr != nil {
+		apiErr.respond(c)
+		return "", false
+	}
+	return episodeURL, true
+}
+
+// validEpisodeURL memvalidasi URL halaman episode dan menulisnya ulang ke mirror aktif.
+func validEpisodeURL(episodeURL string) (string, *apiError) {
+	if episodeURL == "" {
+		return "", newAPIError(http.StatusBadRequest, "Parameter 'episode_url' wajib diisi.")
+	}
 	if _, err := url.ParseRequestURI(episodeURL); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'episode_url' bukan URL yang valid."})
+		return "", newAPIError(http.StatusBadRequest, "Parameter 'episode_url' bukan URL yang valid.")
+	}
+	// Hanya domain sumber dan mirror-nya yang boleh diambil, agar API tidak menjadi proxy ke alamat lain
+	if err := repository.CheckSourceURL(episodeURL); err != nil {
+		return "", newAPIError(http.StatusBadRequest, "Parameter 'episode_url' harus mengarah ke domain sumber ("+repository.SourceName()+"). Gunakan /api/v1/episode/{episode_slug} untuk mencari berdasarkan slug.")
+	}
+	// URL dari mirror lama diarahkan ke mirror yang sedang aktif
+	return repository.RewriteURL(episodeURL), nil
+}
+
+// episodeSlugPattern membatasi slug episode yang dipakai membangun URL sumber.
+var episodeSlugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// episodeSlugURL membangun URL halaman episode dari slug. Jika slug tidak valid, response 400 sudah ditulis.
+func episodeSlugURL(c *gin.Context, rawSlug string) (string, bool) {
+	episodeURL, apiErr := episodeURLFromSlug(rawSlug)
+	if apiErr != nil {
+		apiErr.respond(c)
+		return "", false
+	}
+	return episodeURL, true
+}
+
+// episodeURLFromSlug membangun URL halaman episode dari slug yang sudah divalidasi.
+func episodeURLFromSlug(rawSlug string) (string, *apiError) {
+	slug := strings.ToLower(strings.TrimSpace(rawSlug))
+	if len(slug) > 200 || !episodeSlugPattern.MatchString(slug) {
+		return "", newAPIError(http.StatusBadRequest, "Slug episode hanya boleh berisi huruf, angka, dan tanda hubung.")
+	}
+	return repository.EpisodeURL(slug), nil
+}
+
+// getEpisodeBySlugHandler menangani permintaan detail episode berdasarkan slug.
+// @Summary      Get Episode Detail by Slug
+// @Description  Mengambil detail lengkap sebuah episode berdasarkan slug (field episode_slug di daftar episode).
+// @Description  URL halaman episode dibangun dari domain sumber yang aktif, jadi klien tidak perlu mengirim URL lengkap.
+// @Tags         Episode Detail
+// @Produce      json
+// @Param        episode_slug   path   string   true   "Slug episode (contoh: naruto-episode-1)"
+// @Param        force_refresh  query  boolean  false  "Abaikan katalog dan scrape ulang dari sumber"
+// @Param        resolve        query  boolean  false  "Ubah URL embed setiap server menjadi URL media langsung (mp4/HLS)"
+// @Success      200  {object}  repository.EpisodeDetailResponse "Detail episode berhasil diambil"
+// @Failure      400  {object}  map[string]string "Slug episode tidak valid"
+// @Failure      404  {object}  map[string]string "Episode tidak ditemukan"
+// @Router       /api/v1/episode/{episode_slug} [get]
+func getEpisodeBySlugHandler(c *gin.Context) {
+	episodeURL, ok := episodeSlugURL(c, c.Param("episode_slug"))
+	if !ok {
 		return
 	}
+	respondEpisodeDetail(c, episodeURL)
+}
+
+// loadEpisodeDetail mengambil detail episode dari katalog atau situs sumber.
+// Jika gagal, response error sudah ditulis dan ok bernilai false.
+func loadEpisodeDetail(c *gin.Context, episodeURL string) (repository.EpisodeDetailData, bool) {
+	data, apiErr := episodeDetailData(episodeURL, isForceRefresh(c))
+	if apiErr != nil {
+		apiErr.respond(c)
+		return repository.EpisodeDetailData{}, false
+	}
+	return data, true
+}
 
-	// Panggil scraper baru yang sudah disempurnakan
-	scrapedData := repository.ScrapeEpisodeDetail(episodeURL)
+// episodeDetailData mengambil detail episode dari katalog jika belum kedaluwarsa, atau dari situs sumber.
+func episodeDetailData(episodeURL string, forceRefresh bool) (repository.EpisodeDetailData, *apiError) {
+	// Pakai katalog jika halaman episode belum kedaluwarsa
+	scrapedData, fromCatalog := repository.ScrapedEpisodeDetails{}, false
+	if !forceRefresh {
+		scrapedData, fromCatalog = repository.CachedEpisodeDetail(episodeURL, config.Current().Catalog.EpisodeTTL.Std())
+	}
+	if !fromCatalog {
+		if apiErr := robotsError(episodeURL); apiErr != nil {
+			return repository.EpisodeDetailData{}, apiErr
+		}
+		// Panggil scraper baru yang sudah disempurnakan
+		scrapedData = repository.ScrapeEpisodeDetail(episodeURL, freshOptions(forceRefresh)...)
+	}
 	if scrapedData.Title == "" {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Gagal mengambil data dari URL, mungkin halaman tidak ada."})
-		return
+		return repository.EpisodeDetailData{}, newAPIError(http.StatusNotFound, "Gagal mengambil data dari URL, mungkin halaman tidak ada.")
 	}
 
 	// Format data ke dalam response akhir
@@ -319,6 +1568,7 @@ func getEpisodeDetailHandler(c *gin.Context) {
 		StreamingServers: scrapedData.StreamingServers,
 		ReleaseInfo:      repository.FillStrIfEmpty(scrapedData.ReleaseInfo, "N/A"),
 		DownloadLinks:    scrapedData.DownloadLinks,
+		DownloadSource:   repository.EpisodeDownloadSource(scrapedData),
 		Navigation:       scrapedData.Navigation,
 		AnimeInfo:        scrapedData.AnimeInfo,
 		OtherEpisodes:    scrapedData.OtherEpisodes,
@@ -331,29 +1581,368 @@ func getEpisodeDetailHandler(c *gin.Context) {
 	if episodeDetailData.ThumbnailURL == "" {
 		episodeDetailData.ThumbnailURL = "https://placehold.co/200x300?text=No+Image"
 	}
+	return episodeDetailData, nil
+}
+
+// respondEpisodeDetail menulis detail episode untuk URL halaman episode yang sudah divalidasi.
+func respondEpisodeDetail(c *gin.Context, episodeURL string) {
+	resolve, _ := strconv.ParseBool(c.DefaultQuery("resolve", "false"))
+	response, apiErr := episodeDetail(c.Request.Context(), episodeURL, isForceRefresh(c), resolve)
+	if apiErr != nil {
+		apiErr.respond(c)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// episodeDetail menyusun response detail episode untuk URL halaman episode yang sudah divalidasi.
+func episodeDetail(ctx context.Context, episodeURL string, forceRefresh, resolve bool) (repository.EpisodeDetailResponse, *apiError) {
+	episodeDetailData, apiErr := episodeDetailData(episodeURL, forceRefresh)
+	if apiErr != nil {
+		return repository.EpisodeDetailResponse{}, apiErr
+	}
+
+	// URL media langsung diambil saat diminta saja; URL-nya bisa kedaluwarsa sehingga tidak disimpan di katalog
+	if resolve {
+		episodeDetailData.StreamingServers = repository.DefaultResolvers.ResolveServers(ctx, episodeDetailData.StreamingServers)
+	}
+	// Status hidup/mati dari cek link terakhir, tanpa request tambahan
+	episodeDetailData.StreamingServers = linkChecker.Annotate(episodeDetailData.StreamingServers)
 
 	// Validasi data dan set confidence score
 	confidenceScore := repository.ValidateEpisodeDetailData(episodeDetailData)
 
-	response := repository.EpisodeDetailResponse{
+	return repository.EpisodeDetailResponse{
 		ConfidenceScore: confidenceScore,
 		Data:            episodeDetailData,
 		Message:         "Data berhasil diambil",
-		Source:          "gomunime.co",
+		Source:          repository.SourceName(),
+	}, nil
+}
+
+// getEpisodeHealthHandler menangani pengecekan link streaming dan download satu episode.
+// @Summary      Check Episode Link Health
+// @Description  Mengecek setiap server streaming dan link download episode dengan HEAD (atau GET satu byte jika host menolak HEAD),
+// @Description  lalu mengembalikan status, latensi, dan content type per link. Hasil di-cache (stream_health.cache_ttl) dan
+// @Description  dipakai untuk field alive/checked_at di detail episode. Isi salah satu dari episode_url atau episode_slug.
+// @Tags         Episode Detail
+// @Produce      json
+// @Param        episode_url    query  string   false  "URL lengkap halaman episode di domain sumber"
+// @Param        episode_slug   query  string   false  "Slug episode (contoh: naruto-episode-1)"
+// @Param        force_refresh  query  boolean  false  "Abaikan katalog dan scrape ulang halaman episode"
+// @Success      200  {object}  linkhealth.EpisodeHealthResponse "Hasil cek link"
+// @Failure      400  {object}  map[string]string "Parameter episode tidak valid"
+// @Failure      404  {object}  map[string]string "Episode tidak ditemukan"
+// @Router       /api/v1/episode-detail/health [get]
+func getEpisodeHealthHandler(c *gin.Context) {
+	var episodeURL string
+	var ok bool
+	switch slug := c.Query("episode_slug"); {
+	case slug != "":
+		episodeURL, ok = episodeSlugURL(c, slug)
+	case c.Query("episode_url") != "":
+		episodeURL, ok = episodeURLParam(c)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Isi salah satu parameter 'episode_url' atau 'episode_slug'."})
+	}
+	if !ok {
+		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	episodeDetailData, ok := loadEpisodeDetail(c, episodeURL)
+	if !ok {
+		return
+	}
+	health := linkChecker.CheckEpisode(c.Request.Context(), episodeURL, episodeDetailData)
+	episodeDetailData.StreamingServers = linkChecker.Annotate(episodeDetailData.StreamingServers)
+
+	c.JSON(http.StatusOK, linkhealth.EpisodeHealthResponse{
+		ConfidenceScore: repository.ValidateEpisodeDetailData(episodeDetailData),
+		Data:            health,
+		Message: strconv.Itoa(health.Summary.AliveStreams) + " dari " + strconv.Itoa(health.Summary.Streams) + " server streaming dan " +
+			strconv.Itoa(health.Summary.AliveDownloads) + " dari " + strconv.Itoa(health.Summary.Downloads) + " link download hidup.",
+		Source: repository.SourceName(),
+	})
+}
+
+// playlistConcurrency membatasi jumlah episode yang diambil dan di-resolve bersamaan untuk satu playlist.
+const playlistConcurrency = 4
+
+// feedEpisodeDetail mengambil detail episode dari katalog jika masih segar (kecuali forceRefresh),
+// atau scrape jika robots.txt mengizinkan.
+func feedEpisodeDetail(episodeURL string, forceRefresh bool, opts []repository.ScrapeOption) (repository.ScrapedEpisodeDetails, bool) {
+	if !forceRefresh {
+		if data, ok := repository.CachedEpisodeDetail(episodeURL, config.Current().Catalog.EpisodeTTL.Std()); ok {
+			return data, true
+		}
+	}
+	var disallowed *repository.RobotsDisallowedError
+	if err := repository.CheckRobots(episodeURL); errors.As(err, &disallowed) {
+		return repository.ScrapedEpisodeDetails{}, false
+	}
+	data := repository.ScrapeEpisodeDetail(episodeURL, opts...)
+	return data, data.Title != ""
+}
+
+// getPlaylistHandler menangani export playlist M3U satu seri.
+// @Summary      Export Season Playlist (M3U)
+// @Description  Membuat playlist extended M3U berisi satu URL media langsung (mp4/HLS) per episode, urut dari episode pertama,
+// @Description  untuk diputar di VLC atau mpv. Detail episode diambil dari katalog (atau scrape jika kedaluwarsa) dan setiap
+// @Description  server di-resolve lewat resolver embed. Episode tanpa sumber yang bisa diputar dilewati dan ditulis sebagai komentar.
+// @Description  Seri panjang dibagi per 25 episode; pakai ?page= untuk halaman berikutnya. Header X-Playlist-Entries dan
+// @Description  X-Playlist-Skipped berisi jumlah episode, X-Playlist-Page dan X-Playlist-Total-Pages berisi posisi halaman.
+// @Tags         Anime Detail
+// @Produce      application/x-mpegurl
+// @Param        slug           path   string   true   "Slug anime (contoh: naruto)"
+// @Param        quality        query  string   false  "Kualitas pilihan, contoh: 720p"
+// @Param        server         query  string   false  "Potongan nama server pilihan, contoh: nakama"
+// @Param        page           query  int      false  "Halaman playlist, 25 episode per halaman (default: 1)"
+// @Param        force_refresh  query  boolean  false  "Abaikan katalog dan scrape ulang daftar episode; halaman episode tetap dari katalog jika belum kedaluwarsa"
+// @Success      200  {string}  string "Playlist M3U"
+// @Failure      400  {object}  map[string]string "Parameter tidak valid"
+// @Failure      404  {object}  map[string]string "Anime atau halaman tidak ditemukan, atau tidak ada episode yang bisa diputar"
+// @Router       /api/v1/anime/{slug}/playlist.m3u8 [get]
+func getPlaylistHandler(c *gin.Context) {
+	slug := strings.ToLower(strings.TrimSpace(c.Param("slug")))
+	if len(slug) > 200 || !episodeSlugPattern.MatchString(slug) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Slug anime hanya boleh berisi huruf, angka, dan tanda hubung."})
+		return
+	}
+	quality := strings.ToLower(strings.TrimSpace(c.Query("quality")))
+	if quality != "" && repository.QualityFromText(quality) != quality {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'quality' harus berupa resolusi seperti 360p, 480p, 720p, atau 1080p."})
+		return
+	}
+	server := strings.TrimSpace(c.Query("server"))
+	if len(server) > 50 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'server' maksimal 50 karakter."})
+		return
+	}
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'page' harus angka mulai dari 1."})
+		return
+	}
+
+	anime, _ := feedAnimeDetail(slug, isForceRefresh(c), scrapeOptions(c))
+	if anime.Judul == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Anime tidak ditemukan."})
+		return
+	}
+
+	builder := playlist.Builder{
+		// force_refresh hanya berlaku untuk halaman anime; satu request tidak boleh memaksa scrape ulang setiap episode
+		Load: func(_ context.Context, episodeURL string) (repository.ScrapedEpisodeDetails, bool) {
+			return feedEpisodeDetail(episodeURL, false, nil)
+		},
+		Resolve:     repository.DefaultResolvers.Resolve,
+		Concurrency: playlistConcurrency,
+	}
+	pl := builder.Build(c.Request.Context(), anime, playlist.Options{Quality: quality, Server: server, Page: page})
+	if pl.TotalPages > 0 && page > pl.TotalPages {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Halaman playlist tidak ada; total " + strconv.Itoa(pl.TotalPages) + " halaman."})
+		return
+	}
+	if len(pl.Entries) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tidak ada episode yang bisa diputar untuk anime ini."})
+		return
+	}
+
+	c.Header("Content-Type", "audio/x-mpegurl; charset=utf-8")
+	c.Header("Content-Disposition", `inline; filename="`+slug+`.m3u8"`)
+	c.Header("X-Playlist-Entries", strconv.Itoa(len(pl.Entries)))
+	c.Header("X-Playlist-Skipped", strconv.Itoa(len(pl.Skipped)))
+	c.Header("X-Playlist-Page", strconv.Itoa(pl.Page))
+	c.Header("X-Playlist-Total-Pages", strconv.Itoa(pl.TotalPages))
+	c.Status(http.StatusOK)
+	if err := pl.WriteM3U(c.Writer); err != nil {
+		log.Printf("Gagal menulis playlist %s: %v", slug, err)
+	}
+}
+
+// Batas request ke situs sumber untuk graf seri terkait: kandidat tanpa detail di katalog yang boleh di-scrape
+// per request, dan berapa yang di-scrape bersamaan.
+const (
+	relatedMaxFetch    = 5
+	relatedConcurrency = 2
+)
+
+// getRelatedHandler menangani graf seri terkait dan urutan menonton satu anime.
+// @Summary      Related Series & Watch Order
+// @Description  Menyusun graf seri terkait (season lain, movie, OVA/special) dari kotak seri terkait dan rekomendasi di halaman
+// @Description  detail serta pencarian judul di katalog. Rekomendasi hanya dipakai jika judulnya satu franchise (nomor season,
+// @Description  "Movie", dan "2nd Season" diabaikan saat membandingkan). Relasi terhadap anime ini: sequel, prequel, movie, atau
+// @Description  side_story. watch_order diurutkan menurut tanggal tayang jika semua anime punya tanggal, selain itu menurut nomor
+// @Description  season. Paling banyak 5 kandidat yang belum ada di katalog di-scrape per request.
+// @Tags         Anime Detail
+// @Produce      json
+// @Param        slug           path   string   true   "Slug anime (contoh: haikyuu)"
+// @Param        force_refresh  query  boolean  false  "Abaikan katalog dan scrape ulang detail anime ini"
+// @Success      200  {object}  related.Graph "Graf seri terkait"
+// @Failure      400  {object}  map[string]string "Slug tidak valid"
+// @Failure      404  {object}  map[string]string "Anime tidak ditemukan"
+// @Router       /api/v1/anime/{slug}/related [get]
+func getRelatedHandler(c *gin.Context) {
+	slug := strings.ToLower(strings.TrimSpace(c.Param("slug")))
+	if len(slug) > 200 || !episodeSlugPattern.MatchString(slug) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Slug anime hanya boleh berisi huruf, angka, dan tanda hubung."})
+		return
+	}
+
+	forceRefresh, opts := isForceRefresh(c), scrapeOptions(c)
+	resolver := related.Resolver{
+		Load: func(_ context.Context, animeSlug string) (repository.ScrapedAnimeDetails, bool) {
+			data, _ := feedAnimeDetail(animeSlug, forceRefresh && animeSlug == slug, opts)
+			return data, data.Judul != ""
+		},
+		Lookup: repository.CatalogAnimeBySlug,
+		Search: func(query string, limit int) []repository.CatalogAnime {
+			var list []repository.CatalogAnime
+			for _, hit := range search.Default.Current().Search(query, limit) {
+				list = append(list, hit.Anime)
+			}
+			return list
+		},
+		MaxFetch:    relatedMaxFetch,
+		Concurrency: relatedConcurrency,
+	}
+	graph, err := resolver.Resolve(c.Request.Context(), slug)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Anime dengan slug '" + slug + "' tidak ditemukan."})
+		return
+	}
+	c.JSON(http.StatusOK, graph)
+}
+
+// batchHandler menangani banyak lookup dalam satu request.
+// @Summary      Batch Lookup
+// @Description  Menjalankan beberapa operasi sekaligus: anime-detail (slug), episode-detail (slug atau url), dan search (query, page).
+// @Description  Setiap operasi menghasilkan response yang sama dengan endpoint tunggalnya, termasuk katalog dan confidence score,
+// @Description  beserta status HTTP masing-masing. Operasi identik hanya dijalankan sekali (deduplicated), dan operasi yang gagal
+// @Description  tidak menggagalkan operasi lain. Semua request batch berbagi batch.concurrency slot. Setiap operasi memakai satu unit
+// @Description  rate limit dan kuota harian API key, atau tanpa API key satu token rate limit kelas endpoint aslinya; begitu batas
+// @Description  habis, sisa operasi mendapat status 429.
+// @Tags         Batch
+// @Accept       json
+// @Produce      json
+// @Param        request  body  batch.Request  true  "Daftar operasi (maksimal batch.max_operations)"
+// @Success      200  {object}  batch.Response "Hasil per operasi"
+// @Failure      400  {object}  map[string]string "Body tidak valid atau operasi terlalu banyak"
+// @Router       /api/v1/batch [post]
+func batchHandler(c *gin.Context) {
+	var req batch.Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Body harus berupa JSON dengan field 'operations'."})
+		return
+	}
+	maxOps := config.Current().Batch.MaxOperations
+	if len(req.Operations) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Field 'operations' wajib berisi minimal satu operasi."})
+		return
+	}
+	if len(req.Operations) > maxOps {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Maksimal " + strconv.Itoa(maxOps) + " operasi per batch."})
+		return
+	}
+
+	results, summary := batchRunner.Run(c.Request.Context(), req.Operations, batchExecutor(c))
+	c.JSON(http.StatusOK, batch.Response{
+		Results: results,
+		Summary: summary,
+		Message: strconv.Itoa(summary.Succeeded) + " dari " + strconv.Itoa(summary.Total) + " operasi berhasil.",
+		Source:  repository.SourceName(),
+	})
 }
 
+// batchExecutor menjalankan operasi batch lewat fungsi yang sama dengan endpoint tunggalnya, sehingga validasi,
+// katalog, dan confidence score sama persis dengan request biasa. Setiap operasi dihitung terhadap rate limit
+// dan kuota harian API key (atau rate limit per IP tanpa API key); begitu batas habis, sisa operasi ditolak.
+func batchExecutor(c *gin.Context) batch.Executor {
+	client, rawKey := c.ClientIP(), ""
+	if hasAPIKey(c) {
+		rawKey = auth.ExtractKey(c, authOptions)
+	}
+	var (
+		mu     sync.Mutex
+		denied *apiError
+	)
+	charge := func(class string) *apiError {
+		mu.Lock()
+		defer mu.Unlock()
+		if denied != nil {
+			return denied
+		}
+		if rawKey != "" {
+			if decision := apiKeys.Authorize(rawKey, auth.ScopeRead); !decision.Allowed {
+				denied = newAPIError(decision.Status, decision.Reason)
+			}
+		} else if res, limited := ipLimiter.Allow(class, client); limited && !res.Allowed {
+			denied = newAPIError(http.StatusTooManyRequests, fmt.Sprintf("Terlalu banyak request, coba lagi dalam %d detik.", int(math.Ceil(res.RetryAfter.Seconds()))))
+		}
+		return denied
+	}
+
+	return func(ctx context.Context, op batch.Operation) (int, []byte) {
+		class := ratelimit.ClassDetail
+		if op.Op == batch.OpSearch {
+			class = ratelimit.ClassSearch
+		}
+		if apiErr := charge(class); apiErr != nil {
+			return batchResult(nil, apiErr)
+		}
+
+		switch op.Op {
+		case batch.OpAnimeDetail:
+			return batchResult(animeDetail(op.Slug, false))
+		case batch.OpEpisodeDetail:
+			episodeURL, apiErr := validEpisodeURL(op.URL)
+			if op.Slug != "" {
+				episodeURL, apiErr = episodeURLFromSlug(op.Slug)
+			}
+			if apiErr != nil {
+				return batchResult(nil, apiErr)
+			}
+			return batchResult(episodeDetail(ctx, episodeURL, false, false))
+		case batch.OpSearch:
+			page := op.Page
+			if page < 1 {
+				page = 1
+			}
+			return batchResult(searchAnime(op.Query, search.Filter{}, search.SortRelevance, page, defaultPageSize, false))
+		}
+		// Operasi sudah divalidasi batch.Runner sebelum dijalankan
+		return batchResult(nil, newAPIError(http.StatusBadRequest, "Operasi '"+op.Op+"' tidak dikenal."))
+	}
+}
+
+// batchResult mengubah hasil fungsi endpoint menjadi status dan body JSON satu operasi batch.
+func batchResult(response interface{}, apiErr *apiError) (int, []byte) {
+	if apiErr != nil {
+		body, _ := json.Marshal(apiErr.body)
+		return apiErr.status, body
+	}
+	body, err := json.Marshal(response)
+	if err != nil {
+		body, _ = json.Marshal(gin.H{"error": "Gagal menyusun response."})
+		return http.StatusInternalServerError, body
+	}
+	return http.StatusOK, body
+}
+
+// fallbackRecommendations adalah jumlah rekomendasi dari katalog lokal jika kotak rekomendasi di halaman kosong.
+const fallbackRecommendations = 6
+
 // GANTI FUNGSI LAMA ANDA DENGAN YANG INI
 // getAnimeDetailHandler menangani permintaan detail anime.
 // @Summary      Get Anime Detail
 // @Description  Mengambil detail lengkap sebuah anime berdasarkan slug seri atau slug episode.
+// @Description  Jika kotak Recommended Series di halaman kosong, rekomendasi dihitung dari katalog lokal (genre, studio, tipe,
+// @Description  kedekatan musim tayang, dan skor) dan setiap item berisi similarity serta explanation.
 // @Tags         Anime Detail
 // @Accept       json
 // @Produce      json
 // @Param        anime_slug  query  string  true  "Slug dari anime yang ingin dicari"
-// @Param        force_refresh  query  boolean  false  "Force refresh cache (opsional, belum diimplementasikan)"
+// @Param        force_refresh  query  boolean  false  "Abaikan katalog dan scrape ulang dari sumber"
 // @Success      200  {object}  repository.AnimeDetailResponse "Detail anime berhasil diambil"
 // @Failure      400  {object}  map[string]string "Parameter anime_slug tidak ditemukan"
 // @Failure      404  {object}  map[string]string "Anime tidak ditemukan"
@@ -364,43 +1953,63 @@ func getAnimeDetailHandler(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'anime_slug' wajib diisi."})
 		return
 	}
+	response, apiErr := animeDetail(originalSlug, isForceRefresh(c))
+	if apiErr != nil {
+		apiErr.respond(c)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// animeDetail mengambil detail anime dari katalog atau situs sumber, termasuk percobaan ulang dengan slug
+// episode yang dibersihkan, lalu menyusun response-nya.
+func animeDetail(originalSlug string, forceRefresh bool) (repository.AnimeDetailResponse, *apiError) {
+	animeTTL := config.Current().Catalog.AnimeTTL.Std()
 
-	// --- Percobaan Pertama ---
-	log.Printf("Mencoba mengambil detail untuk slug: %s", originalSlug)
-	scrapedData := repository.ScrapeAnimeDetail(originalSlug)
+	// --- Katalog ---
+	var scrapedData repository.ScrapedAnimeDetails
 	finalSlug := originalSlug
+	fromCatalog := false
+	if !forceRefresh {
+		scrapedData, fromCatalog = repository.CachedAnimeDetail(originalSlug, animeTTL)
+		if sanitizedSlug, wasSanitized := repository.SanitizeEpisodeSlug(originalSlug); !fromCatalog && wasSanitized {
+			if scrapedData, fromCatalog = repository.CachedAnimeDetail(sanitizedSlug, animeTTL); fromCatalog {
+				finalSlug = sanitizedSlug
+			}
+		}
+	}
+
+	if !fromCatalog {
+		if apiErr := robotsError(repository.AnimeDetailURL(originalSlug)); apiErr != nil {
+			return repository.AnimeDetailResponse{}, apiErr
+		}
+
+		// --- Percobaan Pertama ---
+		log.Printf("Mencoba mengambil detail untuk slug: %s", originalSlug)
+		scrapedData = repository.ScrapeAnimeDetail(originalSlug, freshOptions(forceRefresh)...)
+	}
 
 	// --- Percobaan Kedua (jika pertama gagal) ---
-	if scrapedData.Judul == "" {
+	if !fromCatalog && scrapedData.Judul == "" {
 		log.Printf("Gagal pada percobaan pertama, mencoba membersihkan slug.")
 		sanitizedSlug, wasSanitized := repository.SanitizeEpisodeSlug(originalSlug)
 
 		if wasSanitized {
 			log.Printf("Slug dibersihkan menjadi: %s. Mencoba lagi.", sanitizedSlug)
-			scrapedData = repository.ScrapeAnimeDetail(sanitizedSlug)
+			scrapedData = repository.ScrapeAnimeDetail(sanitizedSlug, freshOptions(forceRefresh)...)
 			finalSlug = sanitizedSlug
 		}
 	}
 
 	// --- Pengecekan Akhir ---
 	if scrapedData.Judul == "" {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Anime dengan slug '" + originalSlug + "' tidak ditemukan."})
-		return
+		return repository.AnimeDetailResponse{}, newAPIError(http.StatusNotFound, "Anime dengan slug '"+originalSlug+"' tidak ditemukan.")
 	}
 
 	// --- Format Data ke dalam Response Akhir ---
 	var episodeList []repository.EpisodeListItem
 	for _, ep := range scrapedData.EpisodeList {
-		episodeSlug := repository.GetSlugFromURL(ep.URL)
-		episodeTitle := repository.SlugToTitle(episodeSlug)
-		
-		episodeList = append(episodeList, repository.EpisodeListItem{
-			Episode:     repository.FillStrIfEmpty(ep.Episode, "N/A"),
-			Title:       episodeTitle,
-			URL:         ep.URL,
-			EpisodeSlug: episodeSlug,
-			ReleaseDate: repository.FillStrIfEmpty(ep.TanggalRilis, "N/A"),
-		})
+		episodeList = append(episodeList, repository.NewEpisodeListItem(ep))
 	}
 
 	// Format Rekomendasi
@@ -416,47 +2025,28 @@ func getAnimeDetailHandler(c *gin.Context) {
 		})
 	}
 
-	// =====================================================================
-	// LOGIKA FALLBACK JIKA REKOMENDASI KOSONG
-	// =====================================================================
+	// Jika kotak rekomendasi kosong, rekomendasi dihitung dari katalog lokal tanpa scrape tambahan
 	if len(recommendations) == 0 {
-		log.Println("Rekomendasi dari scraper kosong, mengambil fallback dari halaman utama.")
-
-		// 1. Ambil data dari halaman utama
-		fallbackAnime := repository.ScrapeLatestByPage(1)
-
-		if len(fallbackAnime) > 0 {
-			// 2. Acak urutan daftar fallback
-			rand.Seed(time.Now().UnixNano())
-			rand.Shuffle(len(fallbackAnime), func(i, j int) {
-				fallbackAnime[i], fallbackAnime[j] = fallbackAnime[j], fallbackAnime[i]
+		target := repository.CatalogAnime{
+			Slug:    finalSlug,
+			Type:    scrapedData.Details[repository.DetailType],
+			Score:   scrapedData.Skor,
+			Genres:  scrapedData.Genre,
+			Details: scrapedData.Details,
+		}
+		for _, rec := range recommend.Recommend(target, search.Default.Current().Docs(), fallbackRecommendations) {
+			recommendations = append(recommendations, repository.RecommendationItem{
+				Title:       rec.Anime.Title,
+				URL:         repository.AnimeDetailURL(rec.Anime.Slug),
+				AnimeSlug:   rec.Anime.Slug,
+				CoverURL:    repository.RewriteURL(rec.Anime.Cover),
+				Rating:      repository.FillStrIfEmpty(rec.Anime.Score, "N/A"),
+				Episode:     "N/A",
+				Similarity:  rec.Similarity,
+				Explanation: rec.Explanation,
 			})
-
-			// 3. Tentukan jumlah yang akan diambil (maksimal 3)
-			numToTake := 3
-			if len(fallbackAnime) < numToTake {
-				numToTake = len(fallbackAnime)
-			}
-
-			// 4. Ambil 3 item pertama dari hasil acak dan format
-			for i := 0; i < numToTake; i++ {
-				item := fallbackAnime[i]
-				// Hindari merekomendasikan anime yang sedang dilihat
-				if repository.GetSlugFromURL(item.Tautan) == finalSlug {
-					continue
-				}
-				recommendations = append(recommendations, repository.RecommendationItem{
-					Title:     item.Judul,
-					URL:       item.Tautan,
-					AnimeSlug: repository.GetSlugFromURL(item.Tautan),
-					CoverURL:  item.Thumbnail,
-					Rating:    repository.FillStrIfEmpty(item.Rating, "N/A"),
-					Episode:   repository.FillStrIfEmpty(item.Episode, "N/A"),
-				})
-			}
 		}
 	}
-	// =====================================================================
 
 	details := repository.Details{
 		Japanese:     repository.FillStrIfEmpty(scrapedData.Details["Japanese"], "N/A"),
@@ -466,16 +2056,16 @@ func getAnimeDetailHandler(c *gin.Context) {
 		Type:         repository.FillStrIfEmpty(scrapedData.Details["Type"], "N/A"),
 		Source:       repository.FillStrIfEmpty(scrapedData.Details["Source"], "N/A"),
 		Duration:     repository.FillStrIfEmpty(scrapedData.Details["Duration"], "N/A"),
-		TotalEpisode: repository.FillStrIfEmpty(scrapedData.Details["Episodes"], "N/A"),
+		TotalEpisode: repository.FillStrIfEmpty(repository.DetailValue(scrapedData.Details, repository.DetailEpisodes), "N/A"),
 		Season:       repository.FillStrIfEmpty(scrapedData.Details["Season"], "N/A"),
 		Studio:       repository.FillStrIfEmpty(scrapedData.Details["Studio"], "N/A"),
 		Producers:    repository.FillStrIfEmpty(scrapedData.Details["Producers"], "N/A"),
-		Released:     repository.FillStrIfEmpty(scrapedData.Details["Released"], "N/A"),
+		Released:     repository.FillStrIfEmpty(repository.DetailValue(scrapedData.Details, repository.DetailReleased), "N/A"),
 	}
 
 	animeDetailData := repository.AnimeDetailData{
 		Judul:           scrapedData.Judul,
-		URLAnime:        fmt.Sprintf("https://gomunime.co/anime/%s/", finalSlug),
+		URLAnime:        repository.AnimeDetailURL(finalSlug),
 		AnimeSlug:       finalSlug,
 		URLCover:        scrapedData.Thumbnail,
 		EpisodeList:     episodeList,
@@ -491,19 +2081,22 @@ func getAnimeDetailHandler(c *gin.Context) {
 			Score: repository.FillStrIfEmpty(scrapedData.Skor, "N/A"),
 			Users: "N/A",
 		},
+		AlternativeTitles: repository.AlternativeTitles(scrapedData.Details),
+		Director:          repository.FillStrIfEmpty(repository.DetailValue(scrapedData.Details, repository.DetailDirector), "N/A"),
+		Casts:             repository.DetailList(scrapedData.Details, repository.DetailCasts),
+		ReleasedOn:        repository.AnimeTimestamp(repository.DetailValue(scrapedData.Details, repository.DetailReleasedOn)),
+		UpdatedOn:         repository.AnimeTimestamp(repository.DetailValue(scrapedData.Details, repository.DetailUpdatedOn)),
 	}
 
 	// Validasi data dan set confidence score
 	confidenceScore := repository.ValidateAnimeDetailData(animeDetailData)
 
-	response := repository.AnimeDetailResponse{
+	return repository.AnimeDetailResponse{
 		ConfidenceScore: confidenceScore,
 		Data:            animeDetailData,
 		Message:         "Data berhasil diambil",
-		Source:          "gomunime.co",
-	}
-
-	c.JSON(http.StatusOK, response)
+		Source:          repository.SourceName(),
+	}, nil
 }
 
 // getMovieListHandler menangani permintaan untuk daftar film.
@@ -524,6 +2117,9 @@ func getMovieListHandler(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'page' harus berupa angka positif."})
 		return
 	}
+	if !checkRobotsAllowed(c, repository.LatestPageURL(page)) {
+		return
+	}
 
 	// Panggil scraper halaman utama, BUKAN scraper movie
 	latestItems := repository.ScrapeLatestByPage(page)
@@ -559,7 +2155,7 @@ func getMovieListHandler(c *gin.Context) {
 		ConfidenceScore: confidenceScore,
 		Data:            movies,
 		Message:         "Data berhasil diambil",
-		Source:          "gomunime.co",
+		Source:          repository.SourceName(),
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -572,7 +2168,7 @@ func getMovieListHandler(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        day  path  string  true  "Hari dalam bahasa Indonesia (e.g., senin, selasa)"
-// @Param        force_refresh  query  boolean  false  "Force refresh cache (opsional, belum diimplementasikan)"
+// @Param        force_refresh  query  boolean  false  "Ambil ulang dari sumber tanpa cache"
 // @Success      200  {object}  repository.JadwalHarianResponse "Jadwal rilis berhasil diambil"
 // @Failure      404  {object}  map[string]string "Hari tidak ditemukan"
 // @Failure      500  {object}  map[string]string "Error internal server"
@@ -580,9 +2176,12 @@ func getMovieListHandler(c *gin.Context) {
 func getJadwalRilisByDayHandler(c *gin.Context) {
 	// Ambil parameter hari dari URL dan ubah ke huruf kecil
 	requestedDay := strings.ToLower(c.Param("day"))
+	if !checkRobotsAllowed(c, repository.ScheduleURL()) {
+		return
+	}
 
 	// Scrape data jadwal
-	scheduleData := repository.ScrapeSchedule()
+	scheduleData := repository.ScrapeSchedule(scrapeOptions(c)...)
 	if scheduleData == nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal mengambil data jadwal dari sumber."})
 		return
@@ -632,7 +2231,7 @@ func getJadwalRilisByDayHandler(c *gin.Context) {
 		ConfidenceScore: confidenceScore,
 		Data:            animeList,
 		Message:         "Data berhasil diambil",
-		Source:          "gomunime.co",
+		Source:          repository.SourceName(),
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -644,16 +2243,17 @@ func getJadwalRilisByDayHandler(c *gin.Context) {
 // @Tags         Jadwal Rilis
 // @Accept       json
 // @Produce      json
-// @Param        force_refresh  query  boolean  false  "Force refresh cache (opsional, belum diimplementasikan)"
+// @Param        force_refresh  query  boolean  false  "Ambil ulang dari sumber tanpa cache"
 // @Success      200  {object}  map[string]interface{}  "Jadwal rilis berhasil diambil"
 // @Failure      500  {object}  map[string]string "Error internal server"
 // @Router       /api/v1/jadwal-rilis/ [get]
 func getJadwalRilisHandler(c *gin.Context) {
-	// Membaca query param (walaupun belum diimplementasikan, ini untuk dokumentasi)
-	_, _ = strconv.ParseBool(c.DefaultQuery("force_refresh", "false"))
+	if !checkRobotsAllowed(c, repository.ScheduleURL()) {
+		return
+	}
 
 	// Scrape data jadwal
-	scheduleData := repository.ScrapeSchedule()
+	scheduleData := repository.ScrapeSchedule(scrapeOptions(c)...)
 	if scheduleData == nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal mengambil data jadwal dari sumber."})
 		return
@@ -671,7 +2271,7 @@ func formatJadwalToMap(schedule []repository.ScrapedDaySchedule) map[string]inte
 	finalMap["confidence_score"] = 1.0
 	finalMap["data"] = make(map[string]interface{})
 	finalMap["message"] = "Data berhasil diambil"
-	finalMap["source"] = "gomunime.co"
+	finalMap["source"] = repository.SourceName()
 
 	// Proses setiap hari dari data scraper
 	for _, day := range schedule {
@@ -715,6 +2315,10 @@ func formatJadwalToMap(schedule []repository.ScrapedDaySchedule) map[string]inte
 // @Failure      500  {object}  map[string]string "Error internal server"
 // @Router       /api/v1/home/ [get]
 func getAnimeDataHandler(c *gin.Context) {
+	if !checkRobotsAllowed(c, repository.LatestPageURL(1), repository.ScheduleURL()) {
+		return
+	}
+
 	// ... (Kode untuk menjalankan scraper secara concurrent tetap sama)
 	var latestAnime []repository.ScrapedLatestAnime
 	var scheduleData []repository.ScrapedDaySchedule
@@ -824,6 +2428,6 @@ func formatData(latest []repository.ScrapedLatestAnime, schedule []repository.Sc
 		ConfidenceScore: confidenceScore,
 		Data:            homeData,
 		Message:         "Data berhasil diambil",
-		Source:          "gomunime.co",
+		Source:          repository.SourceName(),
 	}
 }
@@ -0,0 +1,279 @@
+// Package playlist membuat playlist M3U satu seri untuk diputar di VLC atau mpv, dengan satu URL media langsung per episode.
+package playlist
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"multiplescrape/repository"
+	"multiplescrape/watchlist"
+)
+
+// PageSize adalah jumlah episode per halaman playlist. Seri yang lebih panjang dibagi ke beberapa halaman
+// agar satu request tidak men-scrape dan me-resolve ratusan episode sekaligus.
+const PageSize = 25
+
+// maxServerAttempts membatasi jumlah server yang dicoba di-resolve per episode.
+const maxServerAttempts = 3
+
+// Options adalah preferensi stream untuk setiap episode.
+type Options struct {
+	Quality string // contoh: "720p"; kosong berarti kualitas pertama dari resolver
+	Server  string // potongan nama server, tidak peka huruf besar/kecil
+	Page    int    // halaman playlist mulai dari 1; nilai di bawah 1 dianggap 1
+}
+
+// Loader mengambil detail episode dari katalog atau situs sumber; ok false jika halaman tidak bisa diambil.
+type Loader func(ctx context.Context, episodeURL string) (repository.ScrapedEpisodeDetails, bool)
+
+// Resolver mengubah URL embed menjadi URL media langsung, seperti repository.ResolverRegistry.Resolve.
+type Resolver func(ctx context.Context, embedURL string) (string, []repository.MediaSource, error)
+
+// Builder menyusun playlist dengan jumlah episode yang diproses bersamaan dibatasi.
+type Builder struct {
+	Load        Loader
+	Resolve     Resolver
+	Concurrency int
+}
+
+// Entry adalah satu episode yang bisa diputar.
+type Entry struct {
+	Title     string
+	Thumbnail string
+	Server    string
+	Source    repository.MediaSource
+}
+
+// Skipped adalah episode yang dilewati beserta alasannya.
+type Skipped struct {
+	Title  string
+	Reason string
+}
+
+// Playlist adalah hasil Build, siap ditulis dengan WriteM3U.
+type Playlist struct {
+	Title      string
+	Entries    []Entry
+	Skipped    []Skipped
+	Page       int
+	TotalPages int // 0 jika seri belum punya episode
+}
+
+// Build mengambil setiap episode di halaman opts.Page sesuai urutan tayang, lalu memilih stream pertama yang bisa
+// di-resolve menjadi media langsung. Episode tanpa sumber yang bisa diputar dicatat di Skipped.
+func (b Builder) Build(ctx context.Context, anime repository.ScrapedAnimeDetails, opts Options) Playlist {
+	episodes := airingOrder(anime.EpisodeList)
+	pl := Playlist{Title: anime.Judul, Page: opts.Page, TotalPages: (len(episodes) + PageSize - 1) / PageSize}
+	if pl.Page < 1 {
+		pl.Page = 1
+	}
+	start := (pl.Page - 1) * PageSize
+	if start > len(episodes) {
+		start = len(episodes)
+	}
+	episodes = episodes[start:]
+	if len(episodes) > PageSize {
+		episodes = episodes[:PageSize]
+	}
+
+	concurrency := b.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	entries := make([]*Entry, len(episodes))
+	reasons := make([]string, len(episodes))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, ep := range episodes {
+		wg.Add(1)
+		go func(i int, ep repository.ScrapedEpisode) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				reasons[i] = ctx.Err().Error()
+				return
+			}
+			defer func() { <-sem }()
+			if err := ctx.Err(); err != nil {
+				reasons[i] = err.Error()
+				return
+			}
+			entries[i], reasons[i] = b.episode(ctx, anime, ep, opts)
+		}(i, ep)
+	}
+	wg.Wait()
+
+	for i, entry := range entries {
+		if entry != nil {
+			pl.Entries = append(pl.Entries, *entry)
+			continue
+		}
+		pl.Skipped = append(pl.Skipped, Skipped{Title: episodeTitle(anime, episodes[i]), Reason: reasons[i]})
+	}
+	return pl
+}
+
+// episode mengambil satu episode dan me-resolve server pilihannya. Jika gagal, alasan dikembalikan.
+func (b Builder) episode(ctx context.Context, anime repository.ScrapedAnimeDetails, ep repository.ScrapedEpisode, opts Options) (*Entry, string) {
+	data, ok := b.Load(ctx, ep.URL)
+	if !ok {
+		return nil, "halaman episode tidak bisa diambil"
+	}
+	servers := preferredServers(data.StreamingServers, opts)
+	if len(servers) == 0 {
+		return nil, "tidak ada server streaming"
+	}
+	if len(servers) > maxServerAttempts {
+		servers = servers[:maxServerAttempts]
+	}
+	for _, server := range servers {
+		_, sources, err := b.Resolve(ctx, server.StreamingURL)
+		if err != nil {
+			continue
+		}
+		if source, ok := pickSource(sources, opts.Quality); ok {
+			if source.Quality == "" {
+				source.Quality = repository.QualityFromText(server.ServerName)
+			}
+			return &Entry{
+				Title:     repository.FillStrIfEmpty(data.Title, episodeTitle(anime, ep)),
+				Thumbnail: repository.FillStrIfEmpty(data.ThumbnailURL, anime.Thumbnail),
+				Server:    server.ServerName,
+				Source:    source,
+			}, ""
+		}
+	}
+	return nil, "tidak ada sumber yang bisa diputar"
+}
+
+// airingOrder mengembalikan salinan daftar episode dari episode pertama. Situs sumber menampilkan episode terbaru
+// lebih dulu, jadi daftar dibalik jika nomor episode pertama lebih besar dari yang terakhir.
+func airingOrder(episodes []repository.ScrapedEpisode) []repository.ScrapedEpisode {
+	ordered := make([]repository.ScrapedEpisode, 0, len(episodes))
+	for _, ep := range episodes {
+		if ep.URL != "" {
+			ordered = append(ordered, ep)
+		}
+	}
+	if len(ordered) < 2 {
+		return ordered
+	}
+	first, okFirst := watchlist.EpisodeNumber(ordered[0].Episode)
+	last, okLast := watchlist.EpisodeNumber(ordered[len(ordered)-1].Episode)
+	if okFirst && okLast && first > last {
+		for i, j := 0, len(ordered)-1; i < j; i, j = i+1, j-1 {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		}
+	}
+	return ordered
+}
+
+// preferredServers mengurutkan server: yang cocok dengan nama server dan kualitas pilihan lebih dulu,
+// selebihnya tetap sesuai urutan halaman.
+func preferredServers(servers []repository.StreamingServer, opts Options) []repository.StreamingServer {
+	score := func(s repository.StreamingServer) int {
+		n := 0
+		if opts.Server != "" && strings.Contains(strings.ToLower(s.ServerName), strings.ToLower(opts.Server)) {
+			n += 2
+		}
+		if opts.Quality != "" && strings.EqualFold(repository.QualityFromText(s.ServerName), opts.Quality) {
+			n++
+		}
+		return n
+	}
+	ordered := make([]repository.StreamingServer, 0, len(servers))
+	for _, s := range servers {
+		if s.StreamingURL != "" {
+			ordered = append(ordered, s)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool { return score(ordered[i]) > score(ordered[j]) })
+	return ordered
+}
+
+// pickSource memilih sumber dengan kualitas yang diminta, atau sumber pertama yang bisa diputar.
+func pickSource(sources []repository.MediaSource, quality string) (repository.MediaSource, bool) {
+	var playable []repository.MediaSource
+	for _, s := range sources {
+		switch s.MimeType {
+		case repository.MimeMP4, repository.MimeHLS, repository.MimeWebM:
+			playable = append(playable, s)
+		}
+	}
+	if len(playable) == 0 {
+		return repository.MediaSource{}, false
+	}
+	for _, s := range playable {
+		if quality != "" && strings.EqualFold(s.Quality, quality) {
+			return s, true
+		}
+	}
+	return playable[0], true
+}
+
+func episodeTitle(anime repository.ScrapedAnimeDetails, ep repository.ScrapedEpisode) string {
+	if ep.Judul != "" {
+		return ep.Judul
+	}
+	return strings.TrimSpace(anime.Judul + " Episode " + ep.Episode)
+}
+
+// vlcOptions memetakan header yang wajib dikirim ke opsi #EXTVLCOPT yang dikenali VLC.
+var vlcOptions = map[string]string{
+	"Referer":    "http-referrer",
+	"User-Agent": "http-user-agent",
+}
+
+// WriteM3U menulis playlist dalam format extended M3U (UTF-8). Episode yang dilewati ditulis sebagai komentar
+// biasa sehingga diabaikan pemutar.
+func (pl Playlist) WriteM3U(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("#EXTM3U\n")
+	if pl.Title != "" {
+		fmt.Fprintf(&sb, "#PLAYLIST:%s\n", oneLine(pl.Title))
+	}
+	for _, entry := range pl.Entries {
+		sb.WriteString("#EXTINF:-1")
+		if entry.Thumbnail != "" {
+			fmt.Fprintf(&sb, ` tvg-logo="%s"`, attr(entry.Thumbnail))
+		}
+		if pl.Title != "" {
+			fmt.Fprintf(&sb, ` group-title="%s"`, attr(pl.Title))
+		}
+		fmt.Fprintf(&sb, ",%s\n", oneLine(entry.Title))
+
+		headers := make([]string, 0, len(entry.Source.Headers))
+		for name := range entry.Source.Headers {
+			if _, ok := vlcOptions[name]; ok {
+				headers = append(headers, name)
+			}
+		}
+		sort.Strings(headers)
+		for _, name := range headers {
+			fmt.Fprintf(&sb, "#EXTVLCOPT:%s=%s\n", vlcOptions[name], oneLine(entry.Source.Headers[name]))
+		}
+		sb.WriteString(oneLine(entry.Source.URL) + "\n")
+	}
+	for _, skipped := range pl.Skipped {
+		fmt.Fprintf(&sb, "# dilewati: %s (%s)\n", oneLine(skipped.Title), skipped.Reason)
+	}
+	if pl.TotalPages > 1 {
+		fmt.Fprintf(&sb, "# halaman %d dari %d (%d episode per halaman)\n", pl.Page, pl.TotalPages, PageSize)
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// oneLine membuang baris baru agar teks dari halaman sumber tidak bisa menyisipkan baris M3U.
+func oneLine(s string) string {
+	return strings.TrimSpace(strings.NewReplacer("\r", " ", "\n", " ").Replace(s))
+}
+
+func attr(s string) string {
+	return strings.ReplaceAll(oneLine(s), `"`, "'")
+}
@@ -0,0 +1,165 @@
+package playlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"multiplescrape/repository"
+)
+
+func testAnime() repository.ScrapedAnimeDetails {
+	return repository.ScrapedAnimeDetails{
+		Judul:     "Naruto",
+		Thumbnail: "https://gomunime.co/naruto.jpg",
+		// Urutan situs sumber: episode terbaru lebih dulu
+		EpisodeList: []repository.ScrapedEpisode{
+			{Episode: "3", URL: "https://gomunime.co/naruto-episode-3/"},
+			{Episode: "2", URL: "https://gomunime.co/naruto-episode-2/"},
+			{Episode: "1", URL: "https://gomunime.co/naruto-episode-1/"},
+		},
+	}
+}
+
+func testBuilder(calls *int32) Builder {
+	pages := map[string]repository.ScrapedEpisodeDetails{
+		"https://gomunime.co/naruto-episode-1/": {Title: "Naruto Episode 1", ThumbnailURL: "https://gomunime.co/ep1.jpg", StreamingServers: []repository.StreamingServer{
+			{ServerName: "Lain 480p", StreamingURL: "https://embed.example/480"},
+			{ServerName: "Nakama 720p", StreamingURL: "https://embed.example/720"},
+		}},
+		"https://gomunime.co/naruto-episode-2/": {Title: "Naruto Episode 2", StreamingServers: []repository.StreamingServer{
+			{ServerName: "Rusak 720p", StreamingURL: "https://embed.example/broken"},
+		}},
+	}
+	return Builder{
+		Load: func(_ context.Context, episodeURL string) (repository.ScrapedEpisodeDetails, bool) {
+			atomic.AddInt32(calls, 1)
+			data, ok := pages[episodeURL]
+			return data, ok
+		},
+		Resolve: func(_ context.Context, embedURL string) (string, []repository.MediaSource, error) {
+			switch embedURL {
+			case "https://embed.example/480":
+				return "direct", []repository.MediaSource{{URL: "https://cdn.example/480.mp4", Quality: "480p", MimeType: repository.MimeMP4}}, nil
+			case "https://embed.example/720":
+				return "mp4upload", []repository.MediaSource{{URL: "https://cdn.example/720.mp4", MimeType: repository.MimeMP4,
+					Headers: map[string]string{"Referer": "https://embed.example/", "X-Ignored": "1"}}}, nil
+			}
+			return "", nil, errors.New("host belum didukung")
+		},
+		Concurrency: 2,
+	}
+}
+
+func TestBuildOrdersEpisodesAndSkipsUnplayable(t *testing.T) {
+	var calls int32
+	pl := testBuilder(&calls).Build(context.Background(), testAnime(), Options{})
+
+	if len(pl.Entries) != 1 || pl.Entries[0].Title != "Naruto Episode 1" || pl.Entries[0].Source.URL != "https://cdn.example/480.mp4" {
+		t.Fatalf("episode 1 seharusnya memakai server pertama di halaman: %+v", pl.Entries)
+	}
+	if len(pl.Skipped) != 2 || pl.Skipped[0].Title != "Naruto Episode 2" || pl.Skipped[1].Title != "Naruto Episode 3" {
+		t.Errorf("episode tanpa sumber seharusnya dilewati sesuai urutan tayang: %+v", pl.Skipped)
+	}
+	if pl.Skipped[1].Reason != "halaman episode tidak bisa diambil" {
+		t.Errorf("alasan episode 3 tidak sesuai: %q", pl.Skipped[1].Reason)
+	}
+	if atomic.LoadInt32(&calls) != 3 {
+		t.Errorf("setiap episode seharusnya diambil sekali, didapat %d", calls)
+	}
+}
+
+func TestBuildPrefersServerAndQuality(t *testing.T) {
+	var calls int32
+	b := testBuilder(&calls)
+
+	byQuality := b.Build(context.Background(), testAnime(), Options{Quality: "720p"})
+	if got := byQuality.Entries[0]; got.Server != "Nakama 720p" || got.Source.Quality != "720p" {
+		t.Errorf("server 720p seharusnya dipilih dan kualitas diambil dari nama server: %+v", got)
+	}
+	byServer := b.Build(context.Background(), testAnime(), Options{Server: "NAKAMA"})
+	if got := byServer.Entries[0]; got.Server != "Nakama 720p" {
+		t.Errorf("nama server seharusnya dicocokkan tanpa peka huruf besar/kecil: %+v", got)
+	}
+}
+
+func TestBuildStopsWhenContextCancelled(t *testing.T) {
+	var calls int32
+	b := testBuilder(&calls)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	pl := b.Build(ctx, testAnime(), Options{})
+	if len(pl.Entries) != 0 || len(pl.Skipped) != 3 || pl.Skipped[0].Reason != context.Canceled.Error() {
+		t.Errorf("semua episode seharusnya dilewati, didapat %+v", pl)
+	}
+	if atomic.LoadInt32(&calls) != 0 {
+		t.Errorf("halaman episode tidak boleh diambil setelah request dibatalkan, didapat %d", calls)
+	}
+}
+
+func TestBuildPagesLongSeries(t *testing.T) {
+	var loaded []string
+	var mu sync.Mutex
+	anime := repository.ScrapedAnimeDetails{Judul: "One Piece"}
+	for i := 1; i <= PageSize*2+5; i++ {
+		anime.EpisodeList = append(anime.EpisodeList, repository.ScrapedEpisode{
+			Episode: strconv.Itoa(i),
+			URL:     fmt.Sprintf("https://gomunime.co/one-piece-episode-%d/", i),
+		})
+	}
+	b := Builder{
+		Load: func(_ context.Context, episodeURL string) (repository.ScrapedEpisodeDetails, bool) {
+			mu.Lock()
+			loaded = append(loaded, episodeURL)
+			mu.Unlock()
+			return repository.ScrapedEpisodeDetails{}, false
+		},
+		Concurrency: 4,
+	}
+
+	pl := b.Build(context.Background(), anime, Options{Page: 3})
+	if pl.Page != 3 || pl.TotalPages != 3 || len(pl.Skipped) != 5 || pl.Skipped[0].Title != "One Piece Episode 51" {
+		t.Errorf("halaman terakhir seharusnya berisi episode 51-55, didapat %+v", pl)
+	}
+	if len(loaded) != 5 {
+		t.Errorf("hanya episode di halaman yang diminta yang boleh diambil, didapat %d", len(loaded))
+	}
+
+	if pl := b.Build(context.Background(), anime, Options{Page: 9}); len(pl.Skipped) != 0 || pl.TotalPages != 3 {
+		t.Errorf("halaman di luar jangkauan seharusnya kosong, didapat %+v", pl)
+	}
+}
+
+func TestWriteM3U(t *testing.T) {
+	pl := Playlist{
+		Title: `Naruto "Shippuden"`,
+		Entries: []Entry{{
+			Title:     "Naruto Episode 1\n#EXTINF:-1,palsu",
+			Thumbnail: "https://gomunime.co/ep1.jpg",
+			Source:    repository.MediaSource{URL: "https://cdn.example/1.mp4", Headers: map[string]string{"Referer": "https://embed.example/", "X-Ignored": "1"}},
+		}},
+		Skipped:    []Skipped{{Title: "Naruto Episode 2", Reason: "tidak ada server streaming"}},
+		Page:       2,
+		TotalPages: 3,
+	}
+	var sb strings.Builder
+	if err := pl.WriteM3U(&sb); err != nil {
+		t.Fatal(err)
+	}
+	want := `#EXTM3U
+#PLAYLIST:Naruto "Shippuden"
+#EXTINF:-1 tvg-logo="https://gomunime.co/ep1.jpg" group-title="Naruto 'Shippuden'",Naruto Episode 1 #EXTINF:-1,palsu
+#EXTVLCOPT:http-referrer=https://embed.example/
+https://cdn.example/1.mp4
+# dilewati: Naruto Episode 2 (tidak ada server streaming)
+# halaman 2 dari 3 (25 episode per halaman)
+`
+	if sb.String() != want {
+		t.Errorf("output M3U:\n%s\nseharusnya:\n%s", sb.String(), want)
+	}
+}
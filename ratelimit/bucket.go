@@ -0,0 +1,83 @@
+// Package ratelimit menyediakan token bucket untuk membatasi laju request.
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Bucket adalah token bucket sederhana: terisi `rate` token per detik hingga `burst`.
+type Bucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewBucket membuat bucket penuh dengan laju isi ulang perSecond dan kapasitas burst.
+func NewBucket(perSecond float64, burst int) *Bucket {
+	return &Bucket{
+		rate:   perSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// PerMinute membuat bucket dengan kapasitas sama dengan jumlah request per menit.
+func PerMinute(requests int) *Bucket {
+	return NewBucket(float64(requests)/60, requests)
+}
+
+// Result adalah hasil satu pemeriksaan bucket, dipakai untuk header RateLimit-*.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	Reset      time.Duration
+}
+
+// Take mengambil `cost` token. Jika token tidak cukup, tidak ada yang diambil.
+func (b *Bucket) Take(cost float64) Result {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	res := Result{Limit: int(b.burst)}
+	if b.tokens >= cost {
+		b.tokens -= cost
+		res.Allowed = true
+	} else if b.rate > 0 {
+		res.RetryAfter = time.Duration((cost - b.tokens) / b.rate * float64(time.Second))
+	}
+	res.Remaining = int(math.Floor(b.tokens))
+	if b.rate > 0 {
+		res.Reset = time.Duration((b.burst - b.tokens) / b.rate * float64(time.Second))
+	}
+	return res
+}
+
+// Idle mengembalikan lama bucket tidak dipakai, untuk membersihkan bucket lama.
+func (b *Bucket) Idle() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return time.Since(b.last)
+}
+
+// WriteHeaders menulis header RateLimit-* (draft IETF) dan Retry-After jika ditolak.
+func WriteHeaders(h http.Header, res Result) {
+	h.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(int(math.Ceil(res.Reset.Seconds()))))
+	if !res.Allowed {
+		h.Set("Retry-After", strconv.Itoa(int(math.Ceil(res.RetryAfter.Seconds()))))
+	}
+}
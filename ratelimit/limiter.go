@@ -0,0 +1,122 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Kelas route. Detail paling mahal karena satu request memicu banyak request ke sumber.
+const (
+	ClassList   = "list"
+	ClassSearch = "search"
+	ClassDetail = "detail"
+)
+
+// bucketIdleTTL adalah lama bucket klien disimpan setelah terakhir dipakai.
+const bucketIdleTTL = 10 * time.Minute
+
+// Limiter membatasi request per IP klien dan per kelas route dengan token bucket.
+type Limiter struct {
+	mu        sync.Mutex
+	limits    map[string]int
+	buckets   map[string]*Bucket
+	throttled map[string]int64
+	lastSweep time.Time
+}
+
+// NewLimiter membuat limiter dari batas request per menit untuk setiap kelas.
+// Kelas dengan batas <= 0 tidak dibatasi.
+func NewLimiter(perMinute map[string]int) *Limiter {
+	return &Limiter{
+		limits:    perMinute,
+		buckets:   make(map[string]*Bucket),
+		throttled: make(map[string]int64),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow mengambil satu token dari bucket milik klien untuk kelas tertentu.
+func (l *Limiter) Allow(class, client string) (Result, bool) {
+	limit := l.limits[class]
+	if limit <= 0 {
+		return Result{Allowed: true}, false
+	}
+
+	l.mu.Lock()
+	l.sweep()
+	key := class + "|" + client
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = PerMinute(limit)
+		l.buckets[key] = bucket
+	}
+	l.mu.Unlock()
+
+	res := bucket.Take(1)
+	if !res.Allowed {
+		l.mu.Lock()
+		l.throttled[class]++
+		l.mu.Unlock()
+	}
+	return res, true
+}
+
+// sweep menghapus bucket yang lama tidak dipakai. Dipanggil dengan mu terkunci.
+func (l *Limiter) sweep() {
+	if time.Since(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = time.Now()
+	for key, bucket := range l.buckets {
+		if bucket.Idle() > bucketIdleTTL {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Stats mengembalikan jumlah klien yang dilacak dan jumlah request yang ditolak per kelas.
+func (l *Limiter) Stats() map[string]interface{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	throttled := make(map[string]int64, len(l.throttled))
+	for class, n := range l.throttled {
+		throttled[class] = n
+	}
+	return map[string]interface{}{
+		"limits_per_minute": l.limits,
+		"tracked_clients":   len(l.buckets),
+		"throttled":         throttled,
+	}
+}
+
+// Middleware membatasi route berdasarkan c.ClientIP(). Alamat dari X-Forwarded-For hanya
+// dipakai gin jika request datang dari trusted proxy (lihat router.SetTrustedProxies).
+// Request yang skip(c) bernilai true, misalnya yang memakai API key, tidak dibatasi di sini.
+func (l *Limiter) Middleware(class string, skip func(*gin.Context) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if skip != nil && skip(c) {
+			c.Next()
+			return
+		}
+
+		res, limited := l.Allow(class, c.ClientIP())
+		if !limited {
+			c.Next()
+			return
+		}
+		WriteHeaders(c.Writer.Header(), res)
+		if !res.Allowed {
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error": fmt.Sprintf("Terlalu banyak request, coba lagi dalam %d detik.", int(math.Ceil(res.RetryAfter.Seconds()))),
+			})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMiddlewareUsesTrustedProxyHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	if err := router.SetTrustedProxies([]string{"10.0.0.1"}); err != nil {
+		t.Fatal(err)
+	}
+	limiter := NewLimiter(map[string]int{ClassDetail: 2, ClassList: 0})
+	router.GET("/detail", limiter.Middleware(ClassDetail, nil), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/list", limiter.Middleware(ClassList, nil), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	do := func(path, remote, forwarded string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = remote + ":1234"
+		if forwarded != "" {
+			req.Header.Set("X-Forwarded-For", forwarded)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Dua klien berbeda di belakang proxy tepercaya punya bucket masing-masing
+	for i := 0; i < 2; i++ {
+		if w := do("/detail", "10.0.0.1", "203.0.113.7"); w.Code != http.StatusOK {
+			t.Fatalf("request ke-%d seharusnya 200, didapat %d", i+1, w.Code)
+		}
+	}
+	w := do("/detail", "10.0.0.1", "203.0.113.7")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("request ketiga seharusnya 429, didapat %d", w.Code)
+	}
+	if w.Header().Get("RateLimit-Limit") != "2" || w.Header().Get("Retry-After") == "" {
+		t.Errorf("header rate limit tidak lengkap: %v", w.Header())
+	}
+	if w := do("/detail", "10.0.0.1", "203.0.113.8"); w.Code != http.StatusOK {
+		t.Errorf("klien lain seharusnya tidak terkena limit, didapat %d", w.Code)
+	}
+
+	// Header X-Forwarded-For dari alamat yang tidak tepercaya diabaikan
+	for i := 0; i < 2; i++ {
+		do("/detail", "198.51.100.1", "192.0.2.1")
+	}
+	if w := do("/detail", "198.51.100.1", "192.0.2.99"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("spoof X-Forwarded-For seharusnya tetap terkena limit, didapat %d", w.Code)
+	}
+
+	// Kelas tanpa batas tidak pernah ditolak
+	for i := 0; i < 5; i++ {
+		if w := do("/list", "198.51.100.1", ""); w.Code != http.StatusOK {
+			t.Fatalf("kelas tanpa batas seharusnya 200, didapat %d", w.Code)
+		}
+	}
+}
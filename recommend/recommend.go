@@ -0,0 +1,151 @@
+// Package recommend memberi rekomendasi anime dari katalog lokal berdasarkan kemiripan isi: genre, studio,
+// tipe, kedekatan musim tayang, dan skor. Hasilnya deterministik dan tidak pernah men-scrape situs sumber.
+package recommend
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"multiplescrape/repository"
+	"multiplescrape/search"
+)
+
+// Bobot setiap sinyal; jumlahnya 1 sehingga skor akhir antara 0 dan 1.
+const (
+	weightGenre  = 0.45
+	weightStudio = 0.15
+	weightType   = 0.10
+	weightSeason = 0.15
+	weightScore  = 0.15
+)
+
+// seasonWindow adalah selisih tanggal tayang yang masih mendapat poin kedekatan musim.
+const seasonWindow = 5 * 365 * 24 * time.Hour
+
+// Recommendation adalah satu anime yang direkomendasikan beserta skor dan alasannya.
+type Recommendation struct {
+	Anime       repository.CatalogAnime
+	Similarity  float64 // 0 sampai 1
+	Explanation string
+}
+
+// profile adalah sinyal yang dibandingkan dari satu anime.
+type profile struct {
+	genres   map[string]string // huruf kecil -> nama asli
+	studios  map[string]string
+	typ      string // huruf kecil, untuk dibandingkan
+	typeName string
+	released time.Time
+	hasDate  bool
+	score    float64
+	hasScore bool
+}
+
+func newProfile(anime repository.CatalogAnime) profile {
+	typeName := strings.TrimSpace(repository.FillStrIfEmpty(anime.Type, anime.Details[repository.DetailType]))
+	p := profile{
+		genres:   lowerSet(anime.Genres),
+		studios:  lowerSet(repository.DetailList(anime.Details, repository.DetailStudio)),
+		typ:      strings.ToLower(typeName),
+		typeName: typeName,
+	}
+	p.released, p.hasDate = repository.AnimeReleaseDate(anime.Details)
+	p.score, p.hasScore = search.ParseScore(anime.Score)
+	return p
+}
+
+func lowerSet(values []string) map[string]string {
+	set := make(map[string]string, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			set[strings.ToLower(v)] = v
+		}
+	}
+	return set
+}
+
+// Recommend menilai setiap kandidat terhadap target dan mengembalikan paling banyak limit anime dengan skor
+// tertinggi. Jika target punya genre, kandidat tanpa genre yang sama dilewati. Skor sama diurutkan menurut slug.
+func Recommend(target repository.CatalogAnime, candidates []repository.CatalogAnime, limit int) []Recommendation {
+	base := newProfile(target)
+	var results []Recommendation
+	for _, anime := range candidates {
+		if anime.Slug == "" || anime.Slug == target.Slug {
+			continue
+		}
+		score, reasons := compare(base, newProfile(anime))
+		if score <= 0 {
+			continue
+		}
+		results = append(results, Recommendation{
+			Anime:       anime,
+			Similarity:  math.Round(score*1000) / 1000,
+			Explanation: strings.Join(reasons, "; "),
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Similarity != results[j].Similarity {
+			return results[i].Similarity > results[j].Similarity
+		}
+		return results[i].Anime.Slug < results[j].Anime.Slug
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// compare menghitung skor kemiripan kandidat dan alasan yang bisa dibaca pengguna.
+func compare(target, candidate profile) (float64, []string) {
+	var reasons []string
+	score := 0.0
+
+	if len(target.genres) > 0 {
+		shared := sharedValues(target.genres, candidate.genres)
+		if len(shared) == 0 {
+			return 0, nil
+		}
+		union := len(target.genres) + len(candidate.genres) - len(shared)
+		jaccard := float64(len(shared)) / float64(union)
+		score += weightGenre * jaccard
+		reasons = append(reasons, fmt.Sprintf("genre sama: %s (%.0f%%)", strings.Join(shared, ", "), jaccard*100))
+	}
+	if shared := sharedValues(target.studios, candidate.studios); len(shared) > 0 {
+		score += weightStudio
+		reasons = append(reasons, "studio sama: "+strings.Join(shared, ", "))
+	}
+	if target.typ != "" && target.typ == candidate.typ {
+		score += weightType
+		reasons = append(reasons, "tipe sama: "+candidate.typeName)
+	}
+	if target.hasDate && candidate.hasDate {
+		gap := target.released.Sub(candidate.released)
+		if gap < 0 {
+			gap = -gap
+		}
+		if gap < seasonWindow {
+			score += weightSeason * (1 - float64(gap)/float64(seasonWindow))
+			reasons = append(reasons, fmt.Sprintf("tayang berdekatan (%d)", candidate.released.Year()))
+		}
+	}
+	if candidate.hasScore {
+		score += weightScore * math.Min(candidate.score, 10) / 10
+		reasons = append(reasons, fmt.Sprintf("skor %.2f", candidate.score))
+	}
+	return score, reasons
+}
+
+// sharedValues mengembalikan nilai yang ada di kedua set, urut abjad, dengan penulisan dari set kedua.
+func sharedValues(a, b map[string]string) []string {
+	var shared []string
+	for key := range a {
+		if original, ok := b[key]; ok {
+			shared = append(shared, original)
+		}
+	}
+	sort.Strings(shared)
+	return shared
+}
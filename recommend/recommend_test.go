@@ -0,0 +1,69 @@
+package recommend
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"multiplescrape/repository"
+)
+
+func anime(slug, animeType, score, studio, released string, genres ...string) repository.CatalogAnime {
+	return repository.CatalogAnime{
+		Slug:    slug,
+		Title:   repository.SlugToTitle(slug),
+		Type:    animeType,
+		Score:   score,
+		Genres:  genres,
+		Details: map[string]string{"Studio": studio, "Released": released},
+	}
+}
+
+func TestRecommendRanksByContent(t *testing.T) {
+	target := anime("haikyuu", "TV", "8.5", "Production I.G", "Apr 6, 2014", "Sports", "School", "Comedy")
+	candidates := []repository.CatalogAnime{
+		target,
+		anime("kuroko-no-basket", "TV", "8.1", "Production I.G", "Apr 7, 2012", "Sports", "School", "Comedy"),
+		anime("ao-ashi", "TV", "8.0", "Production I.G", "Apr 9, 2022", "Sports"),
+		anime("yowamushi-pedal", "TV", "7.9", "TMS Entertainment", "Oct 8, 2013", "Sports", "Comedy"),
+		anime("overlord", "TV", "7.9", "Madhouse", "Jul 7, 2015", "Action", "Fantasy"),
+		anime("free", "", "", "", "", "sports"),
+	}
+
+	recs := Recommend(target, candidates, 0)
+	var order []string
+	for _, rec := range recs {
+		order = append(order, rec.Anime.Slug)
+	}
+	want := []string{"kuroko-no-basket", "yowamushi-pedal", "ao-ashi", "free"}
+	if !reflect.DeepEqual(order, want) {
+		t.Fatalf("urutan rekomendasi didapat %v, seharusnya %v", order, want)
+	}
+	top := recs[0]
+	for _, part := range []string{"genre sama: Comedy, School, Sports (100%)", "studio sama: Production I.G", "tipe sama: TV", "tayang berdekatan (2012)", "skor 8.10"} {
+		if !strings.Contains(top.Explanation, part) {
+			t.Errorf("explanation seharusnya berisi %q, didapat %q", part, top.Explanation)
+		}
+	}
+	if top.Similarity <= recs[1].Similarity || top.Similarity > 1 {
+		t.Errorf("similarity tidak sesuai: %v", recs)
+	}
+	if recs[3].Explanation != "genre sama: sports (33%)" {
+		t.Errorf("kandidat tanpa metadata lain hanya dinilai dari genre, didapat %q", recs[3].Explanation)
+	}
+}
+
+func TestRecommendIsDeterministic(t *testing.T) {
+	target := anime("a", "TV", "", "", "", "Action")
+	candidates := []repository.CatalogAnime{
+		anime("d", "TV", "", "", "", "Action"),
+		anime("b", "TV", "", "", "", "Action"),
+		anime("c", "TV", "", "", "", "Action"),
+	}
+	for i := 0; i < 5; i++ {
+		recs := Recommend(target, candidates, 2)
+		if len(recs) != 2 || recs[0].Anime.Slug != "b" || recs[1].Anime.Slug != "c" {
+			t.Fatalf("skor sama seharusnya diurutkan menurut slug dan dibatasi limit, didapat %+v", recs)
+		}
+	}
+}
@@ -0,0 +1,418 @@
+// Package related menyusun graf seri terkait (season lain, movie, OVA) dari satu anime beserta urutan menontonnya.
+// Kandidat diambil dari kotak seri terkait dan rekomendasi di halaman detail serta pencarian judul di katalog;
+// relasinya ditentukan dari kemiripan judul (nomor season, "Movie", "2nd Season") dan metadata studio/season.
+package related
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"multiplescrape/repository"
+)
+
+// Jenis relasi terhadap anime utama.
+const (
+	RelationSequel    = "sequel"
+	RelationPrequel   = "prequel"
+	RelationMovie     = "movie"
+	RelationSideStory = "side_story"
+)
+
+// Asal kandidat, diurutkan dari yang paling bisa dipercaya.
+const (
+	SourceRelatedBox     = "related_box"
+	SourceTitleMatch     = "title_match"
+	SourceRecommendation = "recommendation"
+)
+
+// Dasar urutan menonton.
+const (
+	OrderByReleaseDate  = "release_date"
+	OrderBySeasonNumber = "season_number"
+)
+
+// MaxNodes membatasi jumlah seri terkait di satu graf.
+const MaxNodes = 25
+
+// maxSearchResults membatasi hasil pencarian katalog per judul dasar.
+const maxSearchResults = 30
+
+// ErrNotFound dikembalikan jika detail anime utama tidak bisa diambil.
+var ErrNotFound = errors.New("anime tidak ditemukan")
+
+// Loader mengambil detail anime dari katalog atau situs sumber; ok false jika halaman tidak bisa diambil.
+type Loader func(ctx context.Context, slug string) (repository.ScrapedAnimeDetails, bool)
+
+// Lookup membaca anime dari katalog tanpa request ke situs sumber.
+type Lookup func(slug string) (repository.CatalogAnime, bool)
+
+// Searcher mencari anime di katalog berdasarkan judul, seperti search.Index.Search.
+type Searcher func(query string, limit int) []repository.CatalogAnime
+
+// Resolver menyusun graf seri terkait. Kandidat yang belum pernah di-scrape detailnya di-load paling
+// banyak MaxFetch buah agar satu request tidak men-scrape banyak halaman.
+type Resolver struct {
+	Load        Loader
+	Lookup      Lookup
+	Search      Searcher
+	MaxFetch    int
+	Concurrency int
+}
+
+// Node adalah satu anime di graf.
+type Node struct {
+	AnimeSlug string `json:"anime_slug" example:"haikyuu-second-season"`
+	Title     string `json:"title" example:"Haikyuu!! Second Season"`
+	URL       string `json:"url" example:"https://gomunime.co/anime/haikyuu-second-season/"`
+	CoverURL  string `json:"cover_url,omitempty"`
+	Type      string `json:"type,omitempty" example:"TV"`
+	Status    string `json:"status,omitempty" example:"Completed"`
+	Studio    string `json:"studio,omitempty" example:"Production I.G"`
+	Released  string `json:"released,omitempty" example:"Oct 4, 2015"`
+	// Season adalah nomor season dari judul; "Part 2" menjadi x.1.
+	Season float64 `json:"season,omitempty" example:"2"`
+	// Relation kosong untuk anime utama.
+	Relation   string   `json:"relation,omitempty" example:"sequel" enums:"sequel,prequel,movie,side_story"`
+	Root       bool     `json:"root,omitempty"`
+	Sources    []string `json:"sources,omitempty" example:"related_box,title_match"`
+	Confidence float64  `json:"confidence" example:"0.9"`
+	WatchOrder int      `json:"watch_order" example:"2"`
+
+	kind     string
+	released time.Time
+	details  map[string]string
+	genres   []string
+	titles   []string
+}
+
+// Edge menghubungkan dua anime: To adalah sequel, movie, atau side story dari From.
+type Edge struct {
+	From     string `json:"from" example:"haikyuu"`
+	To       string `json:"to" example:"haikyuu-second-season"`
+	Relation string `json:"relation" example:"sequel"`
+}
+
+// Graph adalah graf seri terkait. Nodes sudah urut sesuai watch order.
+type Graph struct {
+	AnimeSlug  string   `json:"anime_slug" example:"haikyuu"`
+	Title      string   `json:"title" example:"Haikyuu!!"`
+	Nodes      []Node   `json:"nodes"`
+	Edges      []Edge   `json:"edges"`
+	WatchOrder []string `json:"watch_order" example:"haikyuu,haikyuu-second-season"`
+	// OrderedBy bernilai release_date jika semua anime punya tanggal tayang, selain itu season_number.
+	OrderedBy string `json:"ordered_by" example:"release_date" enums:"release_date,season_number"`
+}
+
+// Resolve mengambil anime utama, mengumpulkan kandidat, dan menyusun graf beserta watch order.
+func (r Resolver) Resolve(ctx context.Context, slug string) (Graph, error) {
+	root, ok := r.Load(ctx, slug)
+	if !ok || root.Judul == "" {
+		return Graph{}, ErrNotFound
+	}
+	rootNode := nodeFromDetail(slug, root)
+	rootNode.Root = true
+
+	candidates := make(map[string]*Node)
+	add := func(slug, title, cover, source string) {
+		if slug == "" || slug == rootNode.AnimeSlug {
+			return
+		}
+		node, seen := candidates[slug]
+		if !seen {
+			anime, ok := repository.CatalogAnime{}, false
+			if r.Lookup != nil {
+				anime, ok = r.Lookup(slug)
+			}
+			if !ok {
+				anime = repository.CatalogAnime{Slug: slug, Title: title, Cover: cover}
+			}
+			node = newNode(anime)
+			candidates[slug] = node
+		}
+		node.addSource(source)
+	}
+	for _, item := range root.Terkait {
+		add(repository.GetSlugFromURL(item.URL), item.Judul, item.Thumbnail, SourceRelatedBox)
+	}
+	for _, item := range root.Rekomendasi {
+		add(repository.GetSlugFromURL(item.URL), item.Judul, item.Thumbnail, SourceRecommendation)
+	}
+	if r.Search != nil {
+		queried := make(map[string]bool)
+		for _, title := range rootNode.titles {
+			query := strings.Join(baseTokens(title), " ")
+			if query == "" || queried[query] {
+				continue
+			}
+			queried[query] = true
+			for _, anime := range r.Search(query, maxSearchResults) {
+				if titlesMatch(rootNode.titles, newNode(anime).titles) {
+					add(anime.Slug, anime.Title, anime.Cover, SourceTitleMatch)
+				}
+			}
+		}
+	}
+
+	// Rekomendasi hanya dipakai jika judulnya satu franchise; kotak seri terkait selalu dipercaya
+	var kept []*Node
+	for _, node := range candidates {
+		if titlesMatch(rootNode.titles, node.titles) {
+			node.addSource(SourceTitleMatch)
+		}
+		if node.hasSource(SourceRelatedBox) || node.hasSource(SourceTitleMatch) {
+			kept = append(kept, node)
+		}
+	}
+	sort.Slice(kept, func(i, j int) bool { return kept[i].AnimeSlug < kept[j].AnimeSlug })
+	r.fetchMissing(ctx, kept)
+	for _, node := range kept {
+		node.Confidence = confidence(rootNode, node)
+	}
+	sort.Slice(kept, func(i, j int) bool {
+		if kept[i].Confidence != kept[j].Confidence {
+			return kept[i].Confidence > kept[j].Confidence
+		}
+		return kept[i].AnimeSlug < kept[j].AnimeSlug
+	})
+	if len(kept) > MaxNodes {
+		kept = kept[:MaxNodes]
+	}
+
+	rootNode.Confidence = 1
+	nodes := append([]*Node{rootNode}, kept...)
+	return buildGraph(rootNode, nodes), nil
+}
+
+// fetchMissing me-load detail kandidat yang belum punya metadata, paling banyak MaxFetch kandidat
+// dengan urutan asal yang paling bisa dipercaya lebih dulu.
+func (r Resolver) fetchMissing(ctx context.Context, nodes []*Node) {
+	var missing []*Node
+	for _, node := range nodes {
+		if len(node.details) == 0 {
+			missing = append(missing, node)
+		}
+	}
+	sort.SliceStable(missing, func(i, j int) bool {
+		return missing[i].hasSource(SourceRelatedBox) && !missing[j].hasSource(SourceRelatedBox)
+	})
+	if len(missing) > r.MaxFetch {
+		missing = missing[:r.MaxFetch]
+	}
+
+	concurrency := r.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, node := range missing {
+		wg.Add(1)
+		go func(node *Node) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-sem }()
+			if ctx.Err() != nil {
+				return
+			}
+			if data, ok := r.Load(ctx, node.AnimeSlug); ok && data.Judul != "" {
+				loaded := nodeFromDetail(node.AnimeSlug, data)
+				loaded.Sources = node.Sources
+				*node = *loaded
+			}
+		}(node)
+	}
+	wg.Wait()
+}
+
+// confidence menilai seberapa yakin kandidat satu franchise dengan anime utama, antara 0 dan 1.
+func confidence(root, node *Node) float64 {
+	score := 0.0
+	if node.hasSource(SourceRelatedBox) {
+		score += 0.6
+	}
+	if node.hasSource(SourceTitleMatch) {
+		score += 0.5
+	}
+	if node.hasSource(SourceRecommendation) {
+		score += 0.1
+	}
+	if root.Studio != "" && strings.EqualFold(root.Studio, node.Studio) {
+		score += 0.2
+	}
+	if sharedGenres(root.genres, node.genres) {
+		score += 0.1
+	}
+	return math.Min(1, math.Round(score*100)/100)
+}
+
+// sharedGenres bernilai true jika minimal separuh genre anime yang lebih sedikit genrenya juga ada di anime lain.
+func sharedGenres(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	set := make(map[string]bool, len(b))
+	for _, g := range b {
+		set[strings.ToLower(g)] = true
+	}
+	shared := 0
+	for _, g := range a {
+		if set[strings.ToLower(g)] {
+			shared++
+		}
+	}
+	return shared*2 >= len(a)
+}
+
+// buildGraph mengurutkan anime sesuai watch order, menentukan relasi terhadap anime utama, dan
+// menyambungkan setiap entri TV dengan entri TV sebelumnya (sequel) serta movie dan side story dengan
+// entri TV terakhir sebelum mereka.
+func buildGraph(root *Node, nodes []*Node) Graph {
+	orderedBy := OrderByReleaseDate
+	for _, node := range nodes {
+		if node.released.IsZero() {
+			orderedBy = OrderBySeasonNumber
+			break
+		}
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, b := nodes[i], nodes[j]
+		if orderedBy == OrderByReleaseDate && !a.released.Equal(b.released) {
+			return a.released.Before(b.released)
+		}
+		if ka, kb := seasonKey(a), seasonKey(b); ka != kb {
+			return ka < kb
+		}
+		if ra, rb := kindRank(a.kind), kindRank(b.kind); ra != rb {
+			return ra < rb
+		}
+		return a.Title < b.Title
+	})
+
+	rootIndex := 0
+	for i, node := range nodes {
+		if node == root {
+			rootIndex = i
+		}
+	}
+	graph := Graph{AnimeSlug: root.AnimeSlug, Title: root.Title, OrderedBy: orderedBy, Nodes: make([]Node, 0, len(nodes)), Edges: make([]Edge, 0), WatchOrder: make([]string, 0, len(nodes))}
+	var lastTV *Node
+	for i, node := range nodes {
+		node.WatchOrder = i + 1
+		switch {
+		case node == root:
+		case node.kind == kindMovie:
+			node.Relation = RelationMovie
+		case node.kind == kindSideStory:
+			node.Relation = RelationSideStory
+		case i < rootIndex:
+			node.Relation = RelationPrequel
+		default:
+			node.Relation = RelationSequel
+		}
+
+		if node.kind == kindTV {
+			if lastTV != nil {
+				graph.Edges = append(graph.Edges, Edge{From: lastTV.AnimeSlug, To: node.AnimeSlug, Relation: RelationSequel})
+			}
+			lastTV = node
+		} else if i > 0 {
+			// Movie atau side story sebelum entri TV pertama menempel pada entri pertama
+			anchor := nodes[0]
+			if lastTV != nil {
+				anchor = lastTV
+			}
+			relation := RelationSideStory
+			if node.kind == kindMovie {
+				relation = RelationMovie
+			}
+			graph.Edges = append(graph.Edges, Edge{From: anchor.AnimeSlug, To: node.AnimeSlug, Relation: relation})
+		}
+		graph.Nodes = append(graph.Nodes, *node)
+		graph.WatchOrder = append(graph.WatchOrder, node.AnimeSlug)
+	}
+	return graph
+}
+
+// seasonKey mengurutkan entri menurut nomor season. Entri TV tanpa nomor dianggap season 1,
+// sedangkan movie dan side story tanpa nomor diletakkan setelah semua season bernomor.
+func seasonKey(node *Node) float64 {
+	if node.Season > 0 {
+		return node.Season
+	}
+	if node.kind == kindTV {
+		return 1
+	}
+	return math.MaxFloat64
+}
+
+func kindRank(kind string) int {
+	switch kind {
+	case kindTV:
+		return 0
+	case kindSideStory:
+		return 1
+	}
+	return 2
+}
+
+func (n *Node) addSource(source string) {
+	if !n.hasSource(source) {
+		n.Sources = append(n.Sources, source)
+	}
+}
+
+func (n *Node) hasSource(source string) bool {
+	for _, s := range n.Sources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeFromDetail membuat node dari hasil scrape detail anime.
+func nodeFromDetail(slug string, data repository.ScrapedAnimeDetails) *Node {
+	return newNode(repository.CatalogAnime{
+		Slug:    slug,
+		Title:   data.Judul,
+		URL:     repository.AnimeDetailURL(slug),
+		Cover:   data.Thumbnail,
+		Status:  data.Details[repository.DetailStatus],
+		Type:    data.Details[repository.DetailType],
+		Genres:  data.Genre,
+		Details: data.Details,
+	})
+}
+
+// newNode membuat node dari record katalog.
+func newNode(anime repository.CatalogAnime) *Node {
+	node := &Node{
+		AnimeSlug: anime.Slug,
+		Title:     anime.Title,
+		URL:       repository.AnimeDetailURL(anime.Slug),
+		CoverURL:  anime.Cover,
+		Type:      anime.Type,
+		Status:    anime.Status,
+		Studio:    anime.Details[repository.DetailStudio],
+		Released:  repository.DetailValue(anime.Details, repository.DetailReleased),
+		kind:      kindOf(anime.Type, anime.Title),
+		details:   anime.Details,
+		genres:    anime.Genres,
+		titles:    append([]string{anime.Title}, repository.AlternativeTitles(anime.Details)...),
+	}
+	node.Season, _ = seasonNumber(anime.Title)
+	node.released, _ = repository.AnimeReleaseDate(anime.Details)
+	return node
+}
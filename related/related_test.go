@@ -0,0 +1,127 @@
+package related
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"multiplescrape/repository"
+)
+
+func detail(title, animeType, released string, rekomendasi, terkait []string) repository.ScrapedAnimeDetails {
+	data := repository.ScrapedAnimeDetails{
+		Judul:   title,
+		Genre:   []string{"Sports", "School"},
+		Details: map[string]string{"Type": animeType, "Studio": "Production I.G", "Released": released},
+	}
+	for _, slug := range rekomendasi {
+		data.Rekomendasi = append(data.Rekomendasi, repository.ScrapedRecommendation{Judul: repository.SlugToTitle(slug), URL: "https://gomunime.co/anime/" + slug + "/"})
+	}
+	for _, slug := range terkait {
+		data.Terkait = append(data.Terkait, repository.ScrapedRecommendation{Judul: repository.SlugToTitle(slug), URL: "https://gomunime.co/anime/" + slug + "/"})
+	}
+	return data
+}
+
+func TestResolveBuildsWatchOrder(t *testing.T) {
+	pages := map[string]repository.ScrapedAnimeDetails{
+		"haikyuu":                            detail("Haikyuu!!", "TV", "Apr 6, 2014", []string{"overlord-iii", "haikyuu-second-season"}, []string{"haikyuu-lev-genkenai", "haikyuu-movie-gomisuteba-no-kessen"}),
+		"haikyuu-lev-genkenai":               detail("Haikyuu!! Lev Genkenai!", "OVA", "Jul 4, 2014", nil, nil),
+		"haikyuu-movie-gomisuteba-no-kessen": detail("Haikyuu!! Movie: Gomisuteba no Kessen", "Movie", "Feb 16, 2024", nil, nil),
+	}
+	catalog := map[string]repository.CatalogAnime{
+		"haikyuu-second-season": {Slug: "haikyuu-second-season", Title: "Haikyuu!! Second Season", Type: "TV",
+			Details: map[string]string{"Studio": "Production I.G", "Released": "Oct 4, 2015"}},
+		"haikyuu-to-the-top": {Slug: "haikyuu-to-the-top", Title: "Haikyuu!! To the Top", Type: "TV",
+			Details: map[string]string{"Studio": "Production I.G", "Season": "Winter 2020"}},
+		"kuroko-no-basket": {Slug: "kuroko-no-basket", Title: "Kuroko no Basket", Type: "TV",
+			Details: map[string]string{"Studio": "Production I.G", "Released": "Apr 7, 2012"}},
+	}
+	var loads int32
+	resolver := Resolver{
+		Load: func(_ context.Context, slug string) (repository.ScrapedAnimeDetails, bool) {
+			atomic.AddInt32(&loads, 1)
+			data, ok := pages[slug]
+			return data, ok
+		},
+		Lookup: func(slug string) (repository.CatalogAnime, bool) {
+			anime, ok := catalog[slug]
+			return anime, ok
+		},
+		Search: func(query string, _ int) []repository.CatalogAnime {
+			if query != "haikyuu" {
+				t.Errorf("kueri pencarian seharusnya judul dasar, didapat %q", query)
+			}
+			return []repository.CatalogAnime{catalog["haikyuu-to-the-top"], catalog["kuroko-no-basket"]}
+		},
+		MaxFetch:    5,
+		Concurrency: 2,
+	}
+
+	graph, err := resolver.Resolve(context.Background(), "haikyuu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantOrder := []string{"haikyuu", "haikyuu-lev-genkenai", "haikyuu-second-season", "haikyuu-to-the-top", "haikyuu-movie-gomisuteba-no-kessen"}
+	if !reflect.DeepEqual(graph.WatchOrder, wantOrder) || graph.OrderedBy != OrderByReleaseDate {
+		t.Fatalf("watch order didapat %v (%s), seharusnya %v", graph.WatchOrder, graph.OrderedBy, wantOrder)
+	}
+	wantRelations := []string{"", RelationSideStory, RelationSequel, RelationSequel, RelationMovie}
+	for i, node := range graph.Nodes {
+		if node.Relation != wantRelations[i] || node.WatchOrder != i+1 {
+			t.Errorf("%s: relasi %q urutan %d, seharusnya %q urutan %d", node.AnimeSlug, node.Relation, node.WatchOrder, wantRelations[i], i+1)
+		}
+	}
+	if !graph.Nodes[0].Root || graph.Nodes[0].Confidence != 1 {
+		t.Errorf("anime utama seharusnya ditandai root: %+v", graph.Nodes[0])
+	}
+	// rekomendasi 0.1 + judul 0.5 + studio sama 0.2; record katalog ini tidak punya genre
+	if second := graph.Nodes[2]; !reflect.DeepEqual(second.Sources, []string{SourceRecommendation, SourceTitleMatch}) || second.Confidence != 0.8 {
+		t.Errorf("rekomendasi dengan judul satu franchise seharusnya dipakai: %+v", second)
+	}
+	wantEdges := []Edge{
+		{From: "haikyuu", To: "haikyuu-lev-genkenai", Relation: RelationSideStory},
+		{From: "haikyuu", To: "haikyuu-second-season", Relation: RelationSequel},
+		{From: "haikyuu-second-season", To: "haikyuu-to-the-top", Relation: RelationSequel},
+		{From: "haikyuu-to-the-top", To: "haikyuu-movie-gomisuteba-no-kessen", Relation: RelationMovie},
+	}
+	if !reflect.DeepEqual(graph.Edges, wantEdges) {
+		t.Errorf("edge didapat %+v, seharusnya %+v", graph.Edges, wantEdges)
+	}
+	if n := atomic.LoadInt32(&loads); n != 3 {
+		t.Errorf("hanya anime utama dan kandidat tanpa metadata yang di-load, didapat %d load", n)
+	}
+}
+
+func TestResolveFallsBackToSeasonNumber(t *testing.T) {
+	resolver := Resolver{
+		Load: func(_ context.Context, slug string) (repository.ScrapedAnimeDetails, bool) {
+			if slug != "haikyuu-2nd-season" {
+				t.Errorf("kandidat tidak boleh di-load jika MaxFetch 0, didapat %s", slug)
+			}
+			return detail("Haikyuu!! 2nd Season", "TV", "", []string{"haikyuu-movie", "haikyuu", "haikyuu-3rd-season"}, nil), true
+		},
+	}
+	graph, err := resolver.Resolve(context.Background(), "haikyuu-2nd-season")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantOrder := []string{"haikyuu", "haikyuu-2nd-season", "haikyuu-3rd-season", "haikyuu-movie"}
+	if !reflect.DeepEqual(graph.WatchOrder, wantOrder) || graph.OrderedBy != OrderBySeasonNumber {
+		t.Fatalf("watch order didapat %v (%s), seharusnya %v", graph.WatchOrder, graph.OrderedBy, wantOrder)
+	}
+	if graph.Nodes[0].Relation != RelationPrequel || graph.Nodes[2].Relation != RelationSequel || graph.Nodes[3].Relation != RelationMovie {
+		t.Errorf("relasi tidak sesuai: %+v", graph.Nodes)
+	}
+}
+
+func TestResolveNotFound(t *testing.T) {
+	resolver := Resolver{Load: func(context.Context, string) (repository.ScrapedAnimeDetails, bool) {
+		return repository.ScrapedAnimeDetails{}, false
+	}}
+	if _, err := resolver.Resolve(context.Background(), "hilang"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("seharusnya ErrNotFound, didapat %v", err)
+	}
+}
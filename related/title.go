@@ -0,0 +1,124 @@
+package related
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"multiplescrape/search"
+)
+
+// Jenis entri di graf. Entri TV membentuk alur utama; movie dan side story menempel pada alur itu.
+const (
+	kindTV        = "tv"
+	kindMovie     = "movie"
+	kindSideStory = "side_story"
+)
+
+// Penanda season dan jenis di judul, misalnya "Season 2", "2nd Season", "Second Season", "S3", "Part 2",
+// "III", "Movie", dan "OVA".
+var (
+	seasonNumberPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\bseason\s*(\d+)\b`),
+		regexp.MustCompile(`(?i)\b(\d+)(?:st|nd|rd|th)\s+season\b`),
+		regexp.MustCompile(`(?i)\bs(\d+)\b`),
+	}
+	ordinalSeason = regexp.MustCompile(`(?i)\b(second|third|fourth|fifth)\s+season\b`)
+	partNumber    = regexp.MustCompile(`(?i)\b(?:part|cour)\s*(\d+)\b`)
+	romanNumber   = regexp.MustCompile(`(?i)\b(ii|iii|iv)\b`)
+	movieTitle    = regexp.MustCompile(`(?i)\b(?:movie|gekijouban|gekijoban|film)\b`)
+	sideTitle     = regexp.MustCompile(`(?i)\b(?:ova|oad|ona|specials?|sp|recap)\b`)
+	titleMarkers  = regexp.MustCompile(`(?i)\b(?:(?:the\s+)?final\s+season|season\s*\d+|\d+(?:st|nd|rd|th)\s+season|(?:second|third|fourth|fifth)\s+season|s\d+|(?:part|cour)\s*\d+|ii|iii|iv|(?:the\s+)?movie|gekijouban|gekijoban|film|ova|oad|ona|specials?|sp|recap|sub(?:title)?\s+indo(?:nesia)?|nonton\s+anime)\b`)
+)
+
+var (
+	romanValues   = map[string]float64{"ii": 2, "iii": 3, "iv": 4}
+	ordinalValues = map[string]float64{"second": 2, "third": 3, "fourth": 4, "fifth": 5}
+)
+
+// baseTokens mengembalikan term judul tanpa penanda season dan jenis, misalnya
+// "Haikyuu!! 2nd Season" dan "Haikyuu!! Movie" sama-sama menjadi ["haikyuu"].
+func baseTokens(title string) []string {
+	return search.Tokenize(titleMarkers.ReplaceAllString(title, " "))
+}
+
+// sameFranchise bernilai true jika term dasar salah satu judul adalah awalan term dasar judul lainnya,
+// sehingga "Haikyuu!!" cocok dengan "Haikyuu!! To the Top" tetapi "K-On!" tidak cocok dengan "Konosuba".
+func sameFranchise(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// titlesMatch mencocokkan semua pasangan judul (judul utama dan judul alternatif) dari dua anime.
+func titlesMatch(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if sameFranchise(baseTokens(x), baseTokens(y)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// seasonNumber mengembalikan nomor season dari judul. "Part 2" menambah 0.1 per part agar berurutan
+// di dalam season yang sama. ok false jika judul tidak bernomor.
+func seasonNumber(title string) (float64, bool) {
+	season, ok := 0.0, false
+	for _, pattern := range seasonNumberPatterns {
+		if match := pattern.FindStringSubmatch(title); match != nil {
+			season, _ = strconv.ParseFloat(match[1], 64)
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		if match := ordinalSeason.FindStringSubmatch(title); match != nil {
+			season, ok = ordinalValues[strings.ToLower(match[1])], true
+		}
+	}
+	if !ok {
+		if match := romanNumber.FindStringSubmatch(title); match != nil {
+			season, ok = romanValues[strings.ToLower(match[1])], true
+		}
+	}
+	if match := partNumber.FindStringSubmatch(title); match != nil {
+		part, _ := strconv.ParseFloat(match[1], 64)
+		if !ok {
+			season, ok = 1, true
+		}
+		if part > 1 {
+			season += (part - 1) / 10
+		}
+	}
+	return season, ok
+}
+
+// kindOf menentukan jenis entri dari tipe di halaman detail, atau dari judul jika tipenya kosong.
+func kindOf(animeType, title string) string {
+	switch strings.ToLower(strings.TrimSpace(animeType)) {
+	case "movie":
+		return kindMovie
+	case "ova", "ona", "special", "oad":
+		return kindSideStory
+	case "tv":
+		return kindTV
+	}
+	switch {
+	case movieTitle.MatchString(title):
+		return kindMovie
+	case sideTitle.MatchString(title):
+		return kindSideStory
+	}
+	return kindTV
+}
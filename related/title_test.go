@@ -0,0 +1,63 @@
+package related
+
+import "testing"
+
+func TestSeasonNumber(t *testing.T) {
+	cases := map[string]float64{
+		"Haikyuu!! Second Season":               2,
+		"Haikyuu!! 2nd Season":                  2,
+		"Kimetsu no Yaiba Season 3":             3,
+		"Overlord III":                          3,
+		"Shingeki no Kyojin S3 Part 2":          3.1,
+		"Tensei shitara Slime Datta Ken Part 2": 1.1,
+		"Mob Psycho 100":                        0,
+	}
+	for title, want := range cases {
+		got, ok := seasonNumber(title)
+		if got != want || ok != (want > 0) {
+			t.Errorf("%s: didapat %v (%v), seharusnya %v", title, got, ok, want)
+		}
+	}
+}
+
+func TestSameFranchise(t *testing.T) {
+	match := [][2]string{
+		{"Haikyuu!!", "Haikyuu!! 2nd Season"},
+		{"Haikyuu!!", "Haikyuu!! Movie: Gomisuteba no Kessen"},
+		{"Haikyuu!! To the Top", "Haikyuu!!"},
+		{"Re:Zero kara Hajimeru Isekai Seikatsu", "Re:Zero kara Hajimeru Isekai Seikatsu Season 2 Sub Indo"},
+		{"Overlord", "Overlord III"},
+	}
+	for _, pair := range match {
+		if !sameFranchise(baseTokens(pair[0]), baseTokens(pair[1])) {
+			t.Errorf("%q dan %q seharusnya satu franchise", pair[0], pair[1])
+		}
+	}
+	differ := [][2]string{
+		{"K-On!", "Kono Subarashii Sekai ni Shukufuku wo!"},
+		{"One Piece", "One Punch Man"},
+		{"Boku no Hero Academia", "Boku no Kokoro no Yabai Yatsu"},
+		{"Movie", "OVA"},
+	}
+	for _, pair := range differ {
+		if sameFranchise(baseTokens(pair[0]), baseTokens(pair[1])) {
+			t.Errorf("%q dan %q seharusnya beda franchise", pair[0], pair[1])
+		}
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	cases := []struct{ animeType, title, want string }{
+		{"Movie", "Haikyuu!! Gomisuteba no Kessen", kindMovie},
+		{"", "Haikyuu!! Movie: Gomisuteba no Kessen", kindMovie},
+		{"OVA", "Haikyuu!! Lev Genkenai!", kindSideStory},
+		{"", "Haikyuu!! Specials", kindSideStory},
+		{"TV", "Haikyuu!! The Movie Recap", kindTV},
+		{"", "Haikyuu!! 2nd Season", kindTV},
+	}
+	for _, c := range cases {
+		if got := kindOf(c.animeType, c.title); got != c.want {
+			t.Errorf("%q/%q: didapat %s, seharusnya %s", c.animeType, c.title, got, c.want)
+		}
+	}
+}
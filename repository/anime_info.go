@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Kunci map Details yang diisi scraper detail anime. Nilai berisi banyak item (Casts, Producers,
+// judul alternatif) digabung dengan ", ".
+const (
+	DetailStatus      = "Status"
+	DetailStudio      = "Studio"
+	DetailReleased    = "Released"
+	DetailDuration    = "Duration"
+	DetailSeason      = "Season"
+	DetailType        = "Type"
+	DetailEpisodes    = "Episodes"
+	DetailReleasedOn  = "Released on"
+	DetailUpdatedOn   = "Updated on"
+	DetailProducers   = "Producers"
+	DetailDirector    = "Director"
+	DetailCasts       = "Casts"
+	DetailSource      = "Source"
+	DetailJapanese    = "Japanese"
+	DetailEnglish     = "English"
+	DetailSynonyms    = "Synonyms"
+	DetailAlternative = "Alternative"
+)
+
+// AltTitleKeys adalah kunci map Details yang berisi judul alternatif.
+var AltTitleKeys = []string{DetailJapanese, DetailEnglish, DetailSynonyms, DetailAlternative}
+
+// legacyDetailKeys memetakan kunci lama di katalog ke kunci yang sekarang dipakai.
+var legacyDetailKeys = map[string][]string{
+	DetailReleased: {"Released:"},
+	DetailEpisodes: {"Total Episode"},
+}
+
+// animeInfoLabels memetakan label di tabel .spe (huruf kecil, tanpa titik dua) ke kunci Details.
+// Label terjemahan bahasa Indonesia ikut dikenali.
+var animeInfoLabels = map[string]string{
+	"status":          DetailStatus,
+	"studio":          DetailStudio,
+	"studios":         DetailStudio,
+	"released":        DetailReleased,
+	"rilis":           DetailReleased,
+	"dirilis":         DetailReleased,
+	"duration":        DetailDuration,
+	"durasi":          DetailDuration,
+	"season":          DetailSeason,
+	"musim":           DetailSeason,
+	"type":            DetailType,
+	"tipe":            DetailType,
+	"episodes":        DetailEpisodes,
+	"episode":         DetailEpisodes,
+	"total episode":   DetailEpisodes,
+	"released on":     DetailReleasedOn,
+	"dirilis pada":    DetailReleasedOn,
+	"updated on":      DetailUpdatedOn,
+	"diperbarui pada": DetailUpdatedOn,
+	"producers":       DetailProducers,
+	"produser":        DetailProducers,
+	"director":        DetailDirector,
+	"directors":       DetailDirector,
+	"sutradara":       DetailDirector,
+	"casts":           DetailCasts,
+	"cast":            DetailCasts,
+	"pemeran":         DetailCasts,
+	"source":          DetailSource,
+	"sumber":          DetailSource,
+	"japanese":        DetailJapanese,
+	"english":         DetailEnglish,
+	"synonyms":        DetailSynonyms,
+	"sinonim":         DetailSynonyms,
+}
+
+// parseAnimeInfo membaca setiap span di tabel .spe dengan format "Label: nilai" ke map Details.
+// Nilai diambil dari atribut datetime elemen <time>, teks semua link, atau teks setelah titik dua.
+// Label yang tidak dikenal dan nilai kosong diabaikan.
+func parseAnimeInfo(spe *goquery.Selection, details map[string]string) {
+	spe.Find("span").Each(func(_ int, s *goquery.Selection) {
+		text := strings.TrimSpace(s.Text())
+		colon := strings.Index(text, ":")
+		if colon < 0 {
+			return
+		}
+		key, ok := animeInfoLabels[strings.ToLower(strings.TrimSpace(text[:colon]))]
+		if !ok {
+			return
+		}
+
+		var value string
+		if t := s.Find("time").First(); t.Length() > 0 {
+			value = AnimeTimestamp(FillStrIfEmpty(strings.TrimSpace(t.AttrOr("datetime", "")), t.Text()))
+		} else if links := s.Find("a"); links.Length() > 0 {
+			var names []string
+			links.Each(func(_ int, a *goquery.Selection) {
+				if name := strings.TrimSpace(a.Text()); name != "" {
+					names = append(names, name)
+				}
+			})
+			value = strings.Join(names, ", ")
+		} else {
+			value = strings.TrimSpace(text[colon+1:])
+		}
+		if value != "" {
+			details[key] = value
+		}
+	})
+}
+
+// parseAlternativeTitles menyimpan teks span.alter ke Details. Judul berhuruf Jepang dipakai sebagai
+// judul Japanese jika tabel .spe tidak menyebutkannya.
+func parseAlternativeTitles(text string, details map[string]string) {
+	titles := splitDetailList(text)
+	if len(titles) == 0 {
+		return
+	}
+	details[DetailAlternative] = strings.Join(titles, ", ")
+	if details[DetailJapanese] != "" {
+		return
+	}
+	for _, title := range titles {
+		if hasJapaneseScript(title) {
+			details[DetailJapanese] = title
+			return
+		}
+	}
+}
+
+// splitDetailList memecah nilai berisi banyak item yang dipisah koma atau titik koma.
+func splitDetailList(text string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ';' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func hasJapaneseScript(text string) bool {
+	for _, r := range text {
+		if unicode.In(r, unicode.Hiragana, unicode.Katakana, unicode.Han) {
+			return true
+		}
+	}
+	return false
+}
+
+// DetailValue mengembalikan nilai Details untuk kunci tersebut, termasuk kunci lama yang masih
+// tersimpan di katalog (misalnya "Released:" dan "Total Episode").
+func DetailValue(details map[string]string, key string) string {
+	if value := strings.TrimSpace(details[key]); value != "" {
+		return value
+	}
+	for _, legacy := range legacyDetailKeys[key] {
+		if value := strings.TrimSpace(details[legacy]); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// DetailList mengembalikan nilai Details berisi banyak item sebagai slice, misalnya daftar pemeran.
+func DetailList(details map[string]string, key string) []string {
+	return splitDetailList(DetailValue(details, key))
+}
+
+// AlternativeTitles mengembalikan semua judul alternatif dari Details tanpa duplikat (tidak peka huruf besar).
+func AlternativeTitles(details map[string]string) []string {
+	titles := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, key := range AltTitleKeys {
+		for _, title := range DetailList(details, key) {
+			if lower := strings.ToLower(title); !seen[lower] {
+				seen[lower] = true
+				titles = append(titles, title)
+			}
+		}
+	}
+	return titles
+}
+
+// Format tanggal yang dipakai tema situs sumber untuk "Released on", "Updated on", dan "Released".
+// Format bulan-tahun saja ada di akhir, untuk metadata Released seperti "Oct 2019".
+var animeDateLayouts = []string{
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+	"2006-01-02",
+	"January 2006",
+	"Jan 2006",
+}
+
+// parseAnimeDate membaca tanggal dengan salah satu format animeDateLayouts.
+func parseAnimeDate(raw string) (time.Time, bool) {
+	for _, layout := range animeDateLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// AnimeTimestamp menormalkan tanggal rilis/pembaruan ke RFC 3339 jika ada jam, atau "2006-01-02" jika
+// hanya tanggal. Teks yang tidak dikenali dikembalikan apa adanya.
+func AnimeTimestamp(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t.Format(time.RFC3339)
+	}
+	if t, ok := parseAnimeDate(raw); ok {
+		return t.Format("2006-01-02")
+	}
+	return raw
+}
+
+// Musim tayang di metadata "Season" (misalnya "Fall 2024") ke bulan awalnya.
+var (
+	airSeason   = regexp.MustCompile(`(?i)\b(winter|spring|summer|fall|autumn)\s+(\d{4})\b`)
+	airMonths   = map[string]time.Month{"winter": time.January, "spring": time.April, "summer": time.July, "fall": time.October, "autumn": time.October}
+	releaseYear = regexp.MustCompile(`\b(19|20)\d{2}\b`)
+)
+
+// AnimeReleaseDate mengembalikan tanggal tayang dari metadata Released, atau dari Season ("Fall 2024") jika
+// Released kosong. Jika hanya tahun yang diketahui, tanggalnya 1 Januari tahun itu.
+func AnimeReleaseDate(details map[string]string) (time.Time, bool) {
+	released := DetailValue(details, DetailReleased)
+	// "Oct 3, 2019 to Mar 26, 2020": yang dipakai tanggal mulai tayang
+	if i := strings.Index(strings.ToLower(released), " to "); i >= 0 {
+		released = released[:i]
+	}
+	released = strings.TrimSpace(released)
+	if t, ok := parseAnimeDate(released); ok {
+		return t, true
+	}
+	if match := airSeason.FindStringSubmatch(DetailValue(details, DetailSeason)); match != nil {
+		year, _ := strconv.Atoi(match[2])
+		return time.Date(year, airMonths[strings.ToLower(match[1])], 1, 0, 0, 0, 0, time.UTC), true
+	}
+	if year := releaseYear.FindString(released); year != "" {
+		y, _ := strconv.Atoi(year)
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC), true
+	}
+	return time.Time{}, false
+}
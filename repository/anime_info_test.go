@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const animeInfoPage = `<html><body><article class="post-180"><div class="bigcontent">
+<div class="thumbook"><div class="thumb"><img src="/cover.jpg"></div></div>
+<div class="infox"><h1 class="entry-title">Haikyuu!! Movie: Gomisuteba no Kessen</h1>
+<span class="alter">ハイキュー!! ゴミ捨て場の決戦, Haikyu!! The Dumpster Battle; Haikyuu Final</span>
+<div class="spe">
+<span><b>Status:</b> Completed</span>
+<span><b>Studio:</b> <a href="/studio/production-i-g/">Production I.G</a></span>
+<span><b>Released:</b> Feb 16, 2024</span>
+<span><b>Episodes:</b> 1</span>
+<span><b>Director:</b> <a href="/director/susumu-mitsunaka/">Susumu Mitsunaka</a></span>
+<span><b>Casts:</b> <a href="/cast/ayumu-murase/">Ayumu Murase</a>, <a href="/cast/kaito-ishikawa/">Kaito Ishikawa</a></span>
+<span><b>English:</b> Haikyu!! The Dumpster Battle</span>
+<span class="split"><b>Released on:</b> <time itemprop="datePublished" datetime="2024-10-05T10:00:00+07:00">October 5, 2024</time></span>
+<span class="split"><b>Updated on:</b> <time itemprop="dateModified">November 1, 2024</time></span>
+<span><b>Posted by:</b> admin</span>
+</div></div></div></article></body></html>`
+
+func TestScrapeAnimeDetailInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(animeInfoPage))
+	}))
+	defer server.Close()
+	useStubSource(t, server.URL)
+
+	details := ScrapeAnimeDetail("haikyuu-movie").Details
+	want := map[string]string{
+		DetailStatus:      "Completed",
+		DetailStudio:      "Production I.G",
+		DetailReleased:    "Feb 16, 2024",
+		DetailEpisodes:    "1",
+		DetailDirector:    "Susumu Mitsunaka",
+		DetailCasts:       "Ayumu Murase, Kaito Ishikawa",
+		DetailEnglish:     "Haikyu!! The Dumpster Battle",
+		DetailJapanese:    "ハイキュー!! ゴミ捨て場の決戦",
+		DetailAlternative: "ハイキュー!! ゴミ捨て場の決戦, Haikyu!! The Dumpster Battle, Haikyuu Final",
+		DetailReleasedOn:  "2024-10-05T10:00:00+07:00",
+		DetailUpdatedOn:   "2024-11-01",
+	}
+	for key, value := range want {
+		if details[key] != value {
+			t.Errorf("%s: didapat %q, seharusnya %q", key, details[key], value)
+		}
+	}
+	if _, ok := details["Posted by"]; ok {
+		t.Errorf("label yang tidak dikenal seharusnya diabaikan: %v", details)
+	}
+
+	titles := AlternativeTitles(details)
+	wantTitles := []string{"ハイキュー!! ゴミ捨て場の決戦", "Haikyu!! The Dumpster Battle", "Haikyuu Final"}
+	if !reflect.DeepEqual(titles, wantTitles) {
+		t.Errorf("judul alternatif didapat %q, seharusnya %q", titles, wantTitles)
+	}
+}
+
+func TestDetailValueReadsLegacyKeys(t *testing.T) {
+	legacy := map[string]string{"Released:": "Aug 2, 2024", "Total Episode": "12"}
+	if got := DetailValue(legacy, DetailReleased); got != "Aug 2, 2024" {
+		t.Errorf("kunci lama 'Released:' seharusnya terbaca, didapat %q", got)
+	}
+	if got := DetailValue(legacy, DetailEpisodes); got != "12" {
+		t.Errorf("kunci lama 'Total Episode' seharusnya terbaca, didapat %q", got)
+	}
+	if got := DetailList(legacy, DetailCasts); got == nil || len(got) != 0 {
+		t.Errorf("daftar kosong seharusnya slice kosong, didapat %#v", got)
+	}
+}
+
+func TestAnimeTimestamp(t *testing.T) {
+	cases := map[string]string{
+		"2024-10-05T10:00:00+07:00": "2024-10-05T10:00:00+07:00",
+		"October 5, 2024":           "2024-10-05",
+		"Oct 5, 2024":               "2024-10-05",
+		"5 Oktober 2024":            "5 Oktober 2024",
+		"":                          "",
+	}
+	for raw, want := range cases {
+		if got := AnimeTimestamp(raw); got != want {
+			t.Errorf("%q: didapat %q, seharusnya %q", raw, got, want)
+		}
+	}
+}
+
+func TestAnimeReleaseDate(t *testing.T) {
+	cases := []struct {
+		details map[string]string
+		want    time.Time
+		ok      bool
+	}{
+		{map[string]string{"Released": "Oct 3, 2019 to Mar 26, 2020"}, time.Date(2019, 10, 3, 0, 0, 0, 0, time.UTC), true},
+		{map[string]string{"Released:": "Feb 16, 2024"}, time.Date(2024, 2, 16, 0, 0, 0, 0, time.UTC), true},
+		{map[string]string{"Released": "Oct 2019"}, time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC), true},
+		{map[string]string{"Season": "Fall 2015"}, time.Date(2015, 10, 1, 0, 0, 0, 0, time.UTC), true},
+		{map[string]string{"Released": "2012"}, time.Date(2012, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{map[string]string{"Released": "?"}, time.Time{}, false},
+	}
+	for _, c := range cases {
+		got, ok := AnimeReleaseDate(c.details)
+		if ok != c.ok || !got.Equal(c.want) {
+			t.Errorf("%v: didapat %v (%v), seharusnya %v", c.details, got, ok, c.want)
+		}
+	}
+}
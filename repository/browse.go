@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gocolly/colly/v2"
+
+	"multiplescrape/config"
+)
+
+// Nilai filter yang diterima halaman arsip /anime/ tema situs sumber.
+var (
+	BrowseOrders   = []string{"update", "latest", "title", "titlereverse", "popular", "rating"}
+	BrowseStatuses = []string{"ongoing", "completed", "hiatus", "upcoming"}
+	BrowseTypes    = []string{"tv", "movie", "ova", "ona", "special", "bd", "music"}
+)
+
+// BrowseOptions adalah filter untuk halaman arsip anime situs sumber. Letter memakai daftar A-Z
+// (huruf A-Z, "0-9", atau "." untuk simbol) dan tidak bisa digabung dengan filter lain.
+type BrowseOptions struct {
+	Order   string
+	Status  string
+	Type    string
+	Genres  []string // slug genre, misalnya "action"
+	Seasons []string // slug season, misalnya "fall-2024"
+	Letter  string
+	Page    int
+}
+
+// ValidBrowseLetter mengecek apakah letter dikenal daftar A-Z situs sumber.
+func ValidBrowseLetter(letter string) bool {
+	if letter == "0-9" || letter == "." {
+		return true
+	}
+	return len(letter) == 1 && letter[0] >= 'A' && letter[0] <= 'Z'
+}
+
+// BrowseURL membangun URL halaman arsip (atau daftar A-Z) situs sumber untuk filter tertentu.
+func BrowseURL(opts BrowseOptions) string {
+	page := opts.Page
+	if page < 1 {
+		page = 1
+	}
+
+	if opts.Letter != "" {
+		path := baseURL() + "az-list/"
+		if page > 1 {
+			path += fmt.Sprintf("page/%d/", page)
+		}
+		return path + "?show=" + url.QueryEscape(opts.Letter)
+	}
+
+	// Urutan parameter dibuat tetap agar URL yang sama memakai cache disk yang sama
+	var params []string
+	add := func(key, value string) {
+		if value != "" {
+			params = append(params, url.QueryEscape(key)+"="+url.QueryEscape(value))
+		}
+	}
+	if page > 1 {
+		add("page", strconv.Itoa(page))
+	}
+	for _, genre := range opts.Genres {
+		add("genre[]", genre)
+	}
+	for _, season := range opts.Seasons {
+		add("season[]", season)
+	}
+	add("status", opts.Status)
+	add("type", opts.Type)
+	add("order", opts.Order)
+
+	target := baseURL() + "anime/"
+	if len(params) > 0 {
+		target += "?" + strings.Join(params, "&")
+	}
+	return target
+}
+
+// ScrapedBrowsePage adalah satu halaman arsip anime hasil scrape.
+type ScrapedBrowsePage struct {
+	Items       []ScrapedSearchResult
+	HasNextPage bool
+}
+
+// ScrapeBrowse mengambil satu halaman arsip anime dengan urutan sesuai situs sumber.
+// Setiap item dilengkapi sinopsis, skor, status, dan genre dari tooltip ajax; jika tooltip gagal,
+// item tetap dikembalikan dengan data dari kartu arsip saja.
+func ScrapeBrowse(opts BrowseOptions, scrapeOpts ...ScrapeOption) ScrapedBrowsePage {
+	var (
+		page ScrapedBrowsePage
+		mu   sync.Mutex
+	)
+
+	c := createOptimizedCollector(true, config.Current().Scraper.PageParallelism, scrapeOpts...)
+
+	c.OnHTML("div.ingfo", func(e *colly.HTMLElement) {
+		index := e.Request.Ctx.GetAny("index").(int)
+		var (
+			score, synopsis, status string
+			genres                  []string
+		)
+		e.ForEach("div.minginfo span.l", func(_ int, el *colly.HTMLElement) {
+			if el.DOM.Find("i.fa-star").Length() > 0 {
+				score = strings.TrimSpace(el.Text)
+			}
+		})
+		synopsis = strings.TrimSpace(e.ChildText("div.ingdesc .contexcerpt"))
+		e.ForEach(".linginfo span", func(_ int, s *colly.HTMLElement) {
+			text := s.Text
+			if strings.HasPrefix(text, "Genres:") {
+				s.ForEach("a", func(_ int, a *colly.HTMLElement) {
+					genres = append(genres, a.Text)
+				})
+			} else if strings.HasPrefix(text, "Status:") {
+				status = strings.TrimSpace(strings.TrimPrefix(text, "Status:"))
+			}
+		})
+
+		mu.Lock()
+		item := &page.Items[index]
+		item.Skor = score
+		item.Sinopsis = synopsis
+		item.Status = FillStrIfEmpty(status, item.Status)
+		if len(genres) > 0 {
+			item.Genres = genres
+		}
+		mu.Unlock()
+	})
+
+	c.OnHTML("div.listupd article.bs", func(e *colly.HTMLElement) {
+		link := e.DOM.Find("a.tip")
+		href := link.AttrOr("href", "")
+		if href == "" {
+			return
+		}
+		thumbURL := e.ChildAttr("img", "data-src")
+		if thumbURL == "" {
+			thumbURL = e.ChildAttr("img", "src")
+		}
+		if thumbURL != "" {
+			thumbURL = e.Request.AbsoluteURL(thumbURL)
+		}
+		// span.epx di kartu berisi label episode, bukan status. Status hanya ditandai lewat div.status
+		// (biasanya untuk Completed); sisanya diisi dari tooltip.
+		item := ScrapedSearchResult{
+			Judul:     strings.TrimSpace(link.AttrOr("title", e.ChildText("h2"))),
+			Tautan:    e.Request.AbsoluteURL(href),
+			Thumbnail: thumbURL,
+			Status:    strings.TrimSpace(e.ChildText("div.status")),
+			Tipe:      e.ChildText("div.typez"),
+			Genres:    []string{},
+		}
+
+		mu.Lock()
+		page.Items = append(page.Items, item)
+		index := len(page.Items) - 1
+		mu.Unlock()
+
+		postID, exists := link.Attr("rel")
+		if !exists {
+			return
+		}
+		ctx := colly.NewContext()
+		ctx.Put("index", index)
+		payload := fmt.Sprintf("action=tooltip_action&id=%s", postID)
+		if err := c.Request("POST", ajaxURL(), strings.NewReader(payload), ctx, nil); err != nil {
+			log.Printf("Failed AJAX request: %v", err)
+		}
+	})
+
+	// Tema memakai tombol "Next" di .hpage untuk arsip dan .pagination untuk daftar A-Z
+	c.OnHTML(".hpage a.r, .pagination a.next", func(e *colly.HTMLElement) {
+		mu.Lock()
+		page.HasNextPage = true
+		mu.Unlock()
+	})
+
+	c.OnError(func(r *colly.Response, err error) {
+		log.Println("Error scraping browse:", err, "| URL:", r.Request.URL)
+	})
+
+	targetURL := BrowseURL(opts)
+	log.Printf("Visiting page: %s", targetURL)
+	c.Visit(targetURL)
+	c.Wait()
+
+	page.Items = rewriteSearchURLs(page.Items)
+	catalogRecordSearch(page.Items)
+	return page
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"multiplescrape/config"
+)
+
+const browseListingHTML = `<html><body><div class="listupd">
+<article class="bs"><a class="tip" rel="11" title="Zeta" href="/anime/zeta/"><img src="/zeta.jpg"><span class="epx">Ep 5</span><div class="typez">TV</div></a></article>
+<article class="bs"><a class="tip" rel="22" title="Alpha" href="/anime/alpha/"><div class="status Completed">Completed</div><img data-src="/alpha.jpg"><span class="epx">Ep 12</span><div class="typez">Movie</div></a></article>
+</div><div class="hpage"><a class="r" href="/anime/?page=2">Next</a></div></body></html>`
+
+const browseTooltipHTML = `<div class="ingfo"><div class="minginfo"><span class="l"><i class="fa-star"></i> 8.5</span></div>
+<div class="ingdesc"><div class="contexcerpt">Sinopsis Zeta.</div></div>
+<div class="linginfo"><span>Status: Ongoing</span><span>Genres: <a>Action</a>, <a>Comedy</a></span></div></div>`
+
+// useStubSource mengarahkan scraper ke server uji tanpa cache disk dan tanpa jeda.
+func useStubSource(t *testing.T, base string, extra ...string) {
+	t.Helper()
+	useMirrorConfig(t, base, extra...)
+	previous := config.Current()
+	cfg := *previous
+	cfg.Scraper.CacheDir = ""
+	cfg.Scraper.MinDelay = 0
+	config.Set(&cfg)
+	t.Cleanup(func() { config.Set(previous) })
+}
+
+func TestBrowseURL(t *testing.T) {
+	useMirrorConfig(t, "https://gomunime.co")
+
+	cases := map[string]BrowseOptions{
+		"https://gomunime.co/anime/": {},
+		"https://gomunime.co/anime/?page=3&genre%5B%5D=action&genre%5B%5D=comedy&season%5B%5D=fall-2024&status=ongoing&type=tv&order=popular": {
+			Page: 3, Genres: []string{"action", "comedy"}, Seasons: []string{"fall-2024"}, Status: "ongoing", Type: "tv", Order: "popular",
+		},
+		"https://gomunime.co/az-list/?show=N":          {Letter: "N"},
+		"https://gomunime.co/az-list/page/2/?show=0-9": {Letter: "0-9", Page: 2},
+	}
+	for want, opts := range cases {
+		if got := BrowseURL(opts); got != want {
+			t.Errorf("%+v: didapat %s, seharusnya %s", opts, got, want)
+		}
+	}
+}
+
+func TestScrapeBrowseKeepsOrderAndEnriches(t *testing.T) {
+	var listingQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/anime/":
+			listingQuery = r.URL.RawQuery
+			fmt.Fprint(w, browseListingHTML)
+		case r.Method == http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			if strings.Contains(string(body), "id=11") {
+				fmt.Fprint(w, browseTooltipHTML)
+				return
+			}
+			// Tooltip Alpha gagal; item tetap harus muncul dari data kartu arsip
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	useStubSource(t, server.URL)
+
+	page := ScrapeBrowse(BrowseOptions{Type: "tv", Genres: []string{"action"}})
+	if listingQuery != "genre%5B%5D=action&type=tv" {
+		t.Errorf("filter seharusnya diteruskan ke situs sumber, didapat query %q", listingQuery)
+	}
+	if !page.HasNextPage {
+		t.Error("tombol Next seharusnya terdeteksi")
+	}
+	if len(page.Items) != 2 || page.Items[0].Judul != "Zeta" || page.Items[1].Judul != "Alpha" {
+		t.Fatalf("urutan item seharusnya sama dengan situs sumber, didapat %+v", page.Items)
+	}
+
+	zeta := page.Items[0]
+	if zeta.Skor != "8.5" || zeta.Sinopsis != "Sinopsis Zeta." || zeta.Status != "Ongoing" || len(zeta.Genres) != 2 || zeta.Tautan != server.URL+"/anime/zeta/" {
+		t.Errorf("item Zeta seharusnya dilengkapi tooltip, didapat %+v", zeta)
+	}
+	alpha := page.Items[1]
+	if alpha.Status != "Completed" || alpha.Tipe != "Movie" || alpha.Thumbnail != server.URL+"/alpha.jpg" {
+		t.Errorf("item Alpha seharusnya memakai data kartu arsip, didapat %+v", alpha)
+	}
+}
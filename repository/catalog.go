@@ -0,0 +1,746 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"multiplescrape/events"
+)
+
+// Bucket bbolt yang dipakai katalog.
+var (
+	bucketAnime        = []byte("anime")
+	bucketEpisodes     = []byte("episodes")
+	bucketStreams      = []byte("streams")
+	bucketEpisodePages = []byte("episode_pages")
+	bucketSchedule     = []byte("schedule")
+
+	catalogBuckets = [][]byte{bucketAnime, bucketEpisodes, bucketStreams, bucketEpisodePages, bucketSchedule}
+)
+
+// ErrCatalogClosed dikembalikan jika katalog belum dibuka.
+var ErrCatalogClosed = errors.New("katalog belum dibuka")
+
+// CatalogAnime adalah data anime yang tersimpan di katalog.
+// DetailScrapedAt hanya terisi jika halaman detail lengkap pernah di-scrape.
+type CatalogAnime struct {
+	Slug            string                  `json:"slug"`
+	Title           string                  `json:"title"`
+	URL             string                  `json:"url"`
+	Cover           string                  `json:"cover"`
+	Synopsis        string                  `json:"synopsis,omitempty"`
+	Score           string                  `json:"score,omitempty"`
+	Status          string                  `json:"status,omitempty"`
+	Type            string                  `json:"type,omitempty"`
+	Genres          []string                `json:"genres,omitempty"`
+	Details         map[string]string       `json:"details,omitempty"`
+	Recommendations []ScrapedRecommendation `json:"recommendations,omitempty"`
+	Related         []ScrapedRecommendation `json:"related,omitempty"`
+	FirstSeen       time.Time               `json:"first_seen"`
+	LastSeen        time.Time               `json:"last_seen"`
+	DetailScrapedAt time.Time               `json:"detail_scraped_at,omitempty"`
+	// LatestEpisodeAt adalah waktu episode terbaru anime ini pertama kali terlihat.
+	LatestEpisodeAt time.Time `json:"latest_episode_at,omitempty"`
+}
+
+// CatalogEpisode adalah satu episode dari sebuah anime.
+// Position mengikuti urutan daftar episode di halaman sumber (0 = paling atas).
+type CatalogEpisode struct {
+	AnimeSlug   string    `json:"anime_slug"`
+	Slug        string    `json:"slug"`
+	Number      string    `json:"number"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	ReleaseDate string    `json:"release_date,omitempty"`
+	Position    int       `json:"position"`
+	FirstSeen   time.Time `json:"first_seen"`
+	LastSeen    time.Time `json:"last_seen"`
+}
+
+// CatalogStream adalah satu server streaming dari sebuah episode.
+type CatalogStream struct {
+	EpisodeSlug string    `json:"episode_slug"`
+	ServerName  string    `json:"server_name"`
+	URL         string    `json:"url"`
+	FirstSeen   time.Time `json:"first_seen"`
+	LastSeen    time.Time `json:"last_seen"`
+}
+
+// catalogScheduleSlot menyimpan hari dan jam tayang terakhir sebuah anime di jadwal.
+type catalogScheduleSlot struct {
+	Day         string    `json:"day"`
+	ReleaseTime string    `json:"release_time"`
+	LastSeen    time.Time `json:"last_seen"`
+}
+
+func (s catalogScheduleSlot) String() string {
+	return strings.TrimSpace(s.Day + " " + s.ReleaseTime)
+}
+
+// catalogEpisodePage menyimpan hasil scrape halaman episode untuk menjawab request berikutnya.
+type catalogEpisodePage struct {
+	Data      ScrapedEpisodeDetails `json:"data"`
+	ScrapedAt time.Time             `json:"scraped_at"`
+}
+
+var (
+	catalogMu sync.RWMutex
+	catalogDB *bolt.DB
+
+	// catalogVersion bertambah setiap kali katalog dibuka atau berubah,
+	// agar indeks turunan (misalnya pencarian lokal) tahu kapan harus dibangun ulang.
+	catalogVersion uint64
+)
+
+// OpenCatalog membuka (atau membuat) file katalog bbolt.
+func OpenCatalog(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 2 * time.Second})
+	if err != nil {
+		return err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range catalogBuckets {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return err
+	}
+
+	catalogMu.Lock()
+	catalogDB = db
+	catalogMu.Unlock()
+	atomic.AddUint64(&catalogVersion, 1)
+	return nil
+}
+
+// CloseCatalog menutup katalog. Aman dipanggil walaupun katalog belum dibuka.
+func CloseCatalog() error {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	if catalogDB == nil {
+		return nil
+	}
+	err := catalogDB.Close()
+	catalogDB = nil
+	atomic.AddUint64(&catalogVersion, 1)
+	return err
+}
+
+func catalogUpdate(fn func(tx *bolt.Tx) error) error {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	if catalogDB == nil {
+		return ErrCatalogClosed
+	}
+	return catalogDB.Update(fn)
+}
+
+func catalogView(fn func(tx *bolt.Tx) error) error {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	if catalogDB == nil {
+		return ErrCatalogClosed
+	}
+	return catalogDB.View(fn)
+}
+
+func getJSON(b *bolt.Bucket, key string, v interface{}) bool {
+	raw := b.Get([]byte(key))
+	if raw == nil {
+		return false
+	}
+	return json.Unmarshal(raw, v) == nil
+}
+
+func putJSON(b *bolt.Bucket, key string, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return b.Put([]byte(key), raw)
+}
+
+// animeSlugFromURL mengambil slug anime dari URL anime atau URL episode.
+func animeSlugFromURL(rawURL string) string {
+	slug := GetSlugFromURL(rawURL)
+	if strings.Contains(rawURL, "/anime/") {
+		return slug
+	}
+	if sanitized, ok := SanitizeEpisodeSlug(slug); ok {
+		return sanitized
+	}
+	return slug
+}
+
+// mergeAnime memperbarui record lama dengan nilai baru yang tidak kosong.
+func mergeAnime(existing CatalogAnime, update CatalogAnime, now time.Time) CatalogAnime {
+	if existing.Slug == "" {
+		update.FirstSeen = now
+		update.LastSeen = now
+		return update
+	}
+	setIf := func(dst *string, v string) {
+		if v != "" {
+			*dst = v
+		}
+	}
+	setIf(&existing.Title, update.Title)
+	setIf(&existing.URL, update.URL)
+	setIf(&existing.Cover, update.Cover)
+	setIf(&existing.Synopsis, update.Synopsis)
+	setIf(&existing.Score, update.Score)
+	setIf(&existing.Status, update.Status)
+	setIf(&existing.Type, update.Type)
+	if len(update.Genres) > 0 {
+		existing.Genres = update.Genres
+	}
+	if len(update.Details) > 0 {
+		existing.Details = update.Details
+	}
+	if len(update.Recommendations) > 0 {
+		existing.Recommendations = update.Recommendations
+	}
+	if len(update.Related) > 0 {
+		existing.Related = update.Related
+	}
+	if !update.DetailScrapedAt.IsZero() {
+		existing.DetailScrapedAt = update.DetailScrapedAt
+	}
+	existing.LastSeen = now
+	return existing
+}
+
+// changeSet mengumpulkan event perubahan selama satu transaksi katalog.
+// Event baru dipublikasikan setelah transaksi berhasil di-commit.
+// Saat katalog masih kosong (scrape pertama), perubahan hanya dijadikan baseline tanpa event.
+type changeSet struct {
+	baseline bool
+	events   []events.Event
+}
+
+func newChangeSet(tx *bolt.Tx) *changeSet {
+	k, _ := tx.Bucket(bucketAnime).Cursor().First()
+	return &changeSet{baseline: k == nil}
+}
+
+func (cs *changeSet) add(e events.Event) {
+	if !cs.baseline {
+		cs.events = append(cs.events, e)
+	}
+}
+
+func (cs *changeSet) publish() {
+	for _, e := range cs.events {
+		events.Publish(e)
+	}
+}
+
+// catalogWrite menjalankan transaksi tulis lalu mempublikasikan event yang terkumpul.
+func catalogWrite(what string, fn func(tx *bolt.Tx, changes *changeSet) error) {
+	var changes *changeSet
+	err := catalogUpdate(func(tx *bolt.Tx) error {
+		changes = newChangeSet(tx)
+		return fn(tx, changes)
+	})
+	if err != nil {
+		if err != ErrCatalogClosed {
+			log.Printf("Gagal menyimpan %s ke katalog: %v", what, err)
+		}
+		return
+	}
+	atomic.AddUint64(&catalogVersion, 1)
+	changes.publish()
+}
+
+// upsertAnime menyimpan record anime dan mengembalikan record sebelum dan sesudah digabung.
+// Event new_anime tidak dibuat di sini karena pencarian dan jadwal juga memuat anime lama;
+// hanya listing terbaru yang menandakan anime benar-benar baru rilis.
+func upsertAnime(tx *bolt.Tx, update CatalogAnime, now time.Time, changes *changeSet) (CatalogAnime, CatalogAnime, error) {
+	if update.Slug == "" {
+		return CatalogAnime{}, CatalogAnime{}, nil
+	}
+	b := tx.Bucket(bucketAnime)
+	var existing CatalogAnime
+	getJSON(b, update.Slug, &existing)
+	merged := mergeAnime(existing, update, now)
+
+	if isCompleted(update.Status) && existing.Status != "" && !isCompleted(existing.Status) {
+		changes.add(events.Event{
+			Type:       events.AnimeCompleted,
+			AnimeSlug:  merged.Slug,
+			AnimeTitle: merged.Title,
+			Genres:     merged.Genres,
+			Status:     merged.Status,
+		})
+	}
+	return existing, merged, putJSON(b, update.Slug, merged)
+}
+
+func isCompleted(status string) bool {
+	return strings.EqualFold(strings.TrimSpace(status), "Completed")
+}
+
+// upsertEpisode menyimpan satu episode. Position negatif berarti posisi tidak diketahui
+// (dari listing): posisi lama dipertahankan, episode baru ditaruh paling atas.
+func upsertEpisode(tx *bolt.Tx, update CatalogEpisode, now time.Time) (bool, error) {
+	b := tx.Bucket(bucketEpisodes)
+	key := update.AnimeSlug + "/" + update.Slug
+	var record CatalogEpisode
+	isNew := !getJSON(b, key, &record)
+	if isNew {
+		record = CatalogEpisode{AnimeSlug: update.AnimeSlug, Slug: update.Slug, Position: -1, FirstSeen: now}
+	}
+	if update.Number != "" {
+		record.Number = update.Number
+	}
+	if update.Title != "" {
+		record.Title = update.Title
+	}
+	if update.ReleaseDate != "" {
+		record.ReleaseDate = update.ReleaseDate
+	}
+	if update.Position >= 0 {
+		record.Position = update.Position
+	}
+	record.URL = update.URL
+	record.LastSeen = now
+	return isNew, putJSON(b, key, record)
+}
+
+// touchLatestEpisode mencatat bahwa anime baru saja mendapat episode yang belum dikenal.
+func touchLatestEpisode(tx *bolt.Tx, anime CatalogAnime, now time.Time) error {
+	anime.LatestEpisodeAt = now
+	return putJSON(tx.Bucket(bucketAnime), anime.Slug, anime)
+}
+
+func newEpisodeEvent(anime CatalogAnime, ep CatalogEpisode) events.Event {
+	return events.Event{
+		Type:       events.NewEpisode,
+		AnimeSlug:  anime.Slug,
+		AnimeTitle: anime.Title,
+		Genres:     anime.Genres,
+		Status:     anime.Status,
+		Episode:    ep.Number,
+		EpisodeURL: ep.URL,
+	}
+}
+
+// catalogRecordListing menyimpan kartu anime dari halaman listing (pencarian, jadwal).
+func catalogRecordListing(items []CatalogAnime) {
+	if len(items) == 0 {
+		return
+	}
+	now := time.Now()
+	catalogWrite("listing", func(tx *bolt.Tx, changes *changeSet) error {
+		for _, item := range items {
+			if _, _, err := upsertAnime(tx, item, now, changes); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// catalogRecordLatest menyimpan listing terbaru. Setiap kartu adalah episode,
+// sehingga episode yang belum dikenal dari anime yang sudah ada menjadi event new_episode,
+// dan anime yang belum ada di katalog menjadi event new_anime.
+func catalogRecordLatest(items []ScrapedLatestAnime) {
+	if len(items) == 0 {
+		return
+	}
+	now := time.Now()
+	catalogWrite("listing terbaru", func(tx *bolt.Tx, changes *changeSet) error {
+		for _, item := range items {
+			animeSlug := animeSlugFromURL(item.Tautan)
+			existing, merged, err := upsertAnime(tx, CatalogAnime{
+				Slug:     animeSlug,
+				Title:    item.Judul,
+				Cover:    item.Thumbnail,
+				Synopsis: item.Deskripsi,
+				Score:    item.Rating,
+				Status:   item.Status,
+				Type:     item.Tipe,
+				Genres:   item.Genres,
+			}, now, changes)
+			if err != nil {
+				return err
+			}
+			if animeSlug == "" {
+				continue
+			}
+			if existing.Slug == "" {
+				changes.add(events.Event{
+					Type:       events.NewAnime,
+					AnimeSlug:  merged.Slug,
+					AnimeTitle: merged.Title,
+					Genres:     merged.Genres,
+					Status:     merged.Status,
+				})
+			}
+			if strings.Contains(item.Tautan, "/anime/") {
+				continue
+			}
+
+			episode := CatalogEpisode{
+				AnimeSlug: animeSlug,
+				Slug:      GetSlugFromURL(item.Tautan),
+				Number:    item.Episode,
+				URL:       item.Tautan,
+				Position:  -1,
+			}
+			isNew, err := upsertEpisode(tx, episode, now)
+			if err != nil {
+				return err
+			}
+			if !isNew {
+				continue
+			}
+			if existing.Slug != "" {
+				changes.add(newEpisodeEvent(merged, episode))
+			}
+			if err := touchLatestEpisode(tx, merged, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func catalogRecordSearch(items []ScrapedSearchResult) {
+	records := make([]CatalogAnime, 0, len(items))
+	for _, item := range items {
+		records = append(records, SearchResultAnime(item))
+	}
+	catalogRecordListing(records)
+}
+
+// SearchResultAnime mengubah hasil pencarian situs sumber menjadi record anime katalog.
+func SearchResultAnime(item ScrapedSearchResult) CatalogAnime {
+	return CatalogAnime{
+		Slug:     animeSlugFromURL(item.Tautan),
+		Title:    item.Judul,
+		URL:      item.Tautan,
+		Cover:    item.Thumbnail,
+		Synopsis: item.Sinopsis,
+		Score:    item.Skor,
+		Status:   item.Status,
+		Type:     item.Tipe,
+		Genres:   item.Genres,
+	}
+}
+
+// catalogRecordSchedule menyimpan jadwal rilis. Anime yang baru masuk jadwal atau
+// pindah hari/jam dilaporkan sebagai schedule_changed.
+func catalogRecordSchedule(days []ScrapedDaySchedule) {
+	if len(days) == 0 {
+		return
+	}
+	now := time.Now()
+	catalogWrite("jadwal", func(tx *bolt.Tx, changes *changeSet) error {
+		slots := tx.Bucket(bucketSchedule)
+		// Jadwal pertama kali disimpan hanya menjadi baseline
+		first, _ := slots.Cursor().First()
+		scheduleBaseline := first == nil
+
+		for _, day := range days {
+			for _, item := range day.AnimeList {
+				slug := animeSlugFromURL(item.Tautan)
+				_, merged, err := upsertAnime(tx, CatalogAnime{
+					Slug:  slug,
+					Title: item.Judul,
+					URL:   item.Tautan,
+					Cover: item.Thumbnail,
+				}, now, changes)
+				if err != nil {
+					return err
+				}
+				if slug == "" {
+					continue
+				}
+
+				var previous catalogScheduleSlot
+				known := getJSON(slots, slug, &previous)
+				slot := catalogScheduleSlot{Day: day.Hari, ReleaseTime: item.WaktuRilis, LastSeen: now}
+				if !scheduleBaseline && (!known || previous.Day != slot.Day || previous.ReleaseTime != slot.ReleaseTime) {
+					changes.add(events.Event{
+						Type:        events.ScheduleChanged,
+						AnimeSlug:   slug,
+						AnimeTitle:  merged.Title,
+						Genres:      merged.Genres,
+						Status:      merged.Status,
+						Day:         slot.Day,
+						ReleaseTime: slot.ReleaseTime,
+						Previous:    previous.String(),
+					})
+				}
+				if err := putJSON(slots, slug, slot); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// catalogRecordAnimeDetail menyimpan hasil ScrapeAnimeDetail beserta daftar episodenya.
+// Episode baru hanya dilaporkan jika detail anime ini pernah di-scrape sebelumnya.
+func catalogRecordAnimeDetail(slug string, data ScrapedAnimeDetails) {
+	if slug == "" || data.Judul == "" {
+		return
+	}
+	now := time.Now()
+	catalogWrite("detail anime "+slug, func(tx *bolt.Tx, changes *changeSet) error {
+		existing, merged, err := upsertAnime(tx, CatalogAnime{
+			Slug:            slug,
+			Title:           data.Judul,
+			URL:             AnimeDetailURL(slug),
+			Cover:           data.Thumbnail,
+			Synopsis:        data.Sinopsis,
+			Score:           data.Skor,
+			Status:          data.Details["Status"],
+			Type:            data.Details["Type"],
+			Genres:          data.Genre,
+			Details:         data.Details,
+			Recommendations: data.Rekomendasi,
+			Related:         data.Terkait,
+			DetailScrapedAt: now,
+		}, now, changes)
+		if err != nil {
+			return err
+		}
+
+		hasNew := false
+		for i, ep := range data.EpisodeList {
+			episode := CatalogEpisode{
+				AnimeSlug:   slug,
+				Slug:        GetSlugFromURL(ep.URL),
+				Number:      ep.Episode,
+				Title:       ep.Judul,
+				URL:         ep.URL,
+				ReleaseDate: ep.TanggalRilis,
+				Position:    i,
+			}
+			isNew, err := upsertEpisode(tx, episode, now)
+			if err != nil {
+				return err
+			}
+			if isNew && !existing.DetailScrapedAt.IsZero() {
+				changes.add(newEpisodeEvent(merged, episode))
+			}
+			hasNew = hasNew || isNew
+		}
+		if hasNew {
+			return touchLatestEpisode(tx, merged, now)
+		}
+		return nil
+	})
+}
+
+// catalogRecordEpisodeDetail menyimpan halaman episode dan server streaming-nya.
+// Server baru dilaporkan sebagai stream_added jika halaman episode pernah di-scrape sebelumnya.
+func catalogRecordEpisodeDetail(episodeURL string, data ScrapedEpisodeDetails) {
+	if data.Title == "" {
+		return
+	}
+	episodeSlug := GetSlugFromURL(episodeURL)
+	now := time.Now()
+	catalogWrite("episode "+episodeSlug, func(tx *bolt.Tx, changes *changeSet) error {
+		pages := tx.Bucket(bucketEpisodePages)
+		pageExisted := pages.Get([]byte(episodeSlug)) != nil
+		if err := putJSON(pages, episodeSlug, catalogEpisodePage{Data: data, ScrapedAt: now}); err != nil {
+			return err
+		}
+
+		streams := tx.Bucket(bucketStreams)
+		for _, server := range data.StreamingServers {
+			key := episodeSlug + "|" + server.StreamingURL
+			var record CatalogStream
+			if !getJSON(streams, key, &record) {
+				record = CatalogStream{EpisodeSlug: episodeSlug, URL: server.StreamingURL, FirstSeen: now}
+				if pageExisted {
+					changes.add(events.Event{
+						Type:       events.StreamAdded,
+						AnimeSlug:  animeSlugFromURL(episodeURL),
+						AnimeTitle: data.AnimeInfo.Title,
+						Genres:     data.AnimeInfo.Genres,
+						EpisodeURL: episodeURL,
+						ServerName: server.ServerName,
+						StreamURL:  server.StreamingURL,
+					})
+				}
+			}
+			record.ServerName = server.ServerName
+			record.LastSeen = now
+			if err := putJSON(streams, key, record); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CatalogVersion mengembalikan nomor versi katalog yang berubah setiap kali isinya berubah.
+func CatalogVersion() uint64 {
+	return atomic.LoadUint64(&catalogVersion)
+}
+
+// CatalogEnabled bernilai true jika katalog sudah dibuka.
+func CatalogEnabled() bool {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	return catalogDB != nil
+}
+
+// CatalogHasEpisodePage mengecek apakah halaman episode pernah di-scrape.
+func CatalogHasEpisodePage(episodeURL string) bool {
+	found := false
+	catalogView(func(tx *bolt.Tx) error {
+		found = tx.Bucket(bucketEpisodePages).Get([]byte(GetSlugFromURL(episodeURL))) != nil
+		return nil
+	})
+	return found
+}
+
+// CatalogAnimeBySlug mengembalikan record anime dari katalog.
+func CatalogAnimeBySlug(slug string) (CatalogAnime, bool) {
+	var record CatalogAnime
+	found := false
+	catalogView(func(tx *bolt.Tx) error {
+		found = getJSON(tx.Bucket(bucketAnime), slug, &record)
+		return nil
+	})
+	return record, found
+}
+
+// CatalogEpisodes mengembalikan episode sebuah anime sesuai urutan di halaman sumber.
+func CatalogEpisodes(animeSlug string) []CatalogEpisode {
+	var episodes []CatalogEpisode
+	prefix := []byte(animeSlug + "/")
+	catalogView(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketEpisodes).Cursor()
+		for k, v := c.Seek(prefix); k != nil && strings.HasPrefix(string(k), string(prefix)); k, v = c.Next() {
+			var ep CatalogEpisode
+			if json.Unmarshal(v, &ep) == nil {
+				episodes = append(episodes, ep)
+			}
+		}
+		return nil
+	})
+	sort.SliceStable(episodes, func(i, j int) bool { return episodes[i].Position < episodes[j].Position })
+	return episodes
+}
+
+// CatalogStreams mengembalikan semua server streaming yang pernah terlihat untuk sebuah episode.
+func CatalogStreams(episodeSlug string) []CatalogStream {
+	var streams []CatalogStream
+	prefix := []byte(episodeSlug + "|")
+	catalogView(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketStreams).Cursor()
+		for k, v := c.Seek(prefix); k != nil && strings.HasPrefix(string(k), string(prefix)); k, v = c.Next() {
+			var s CatalogStream
+			if json.Unmarshal(v, &s) == nil {
+				streams = append(streams, s)
+			}
+		}
+		return nil
+	})
+	return streams
+}
+
+// CatalogAllAnime memanggil fn untuk setiap anime di katalog. Berhenti jika fn mengembalikan false.
+func CatalogAllAnime(fn func(CatalogAnime) bool) {
+	catalogView(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketAnime).ForEach(func(_, v []byte) error {
+			var record CatalogAnime
+			if json.Unmarshal(v, &record) != nil {
+				return nil
+			}
+			if !fn(record) {
+				return errStopIteration
+			}
+			return nil
+		})
+	})
+}
+
+var errStopIteration = errors.New("stop")
+
+// CatalogStats mengembalikan jumlah record per bucket untuk monitoring.
+func CatalogStats() map[string]int {
+	stats := make(map[string]int)
+	catalogView(func(tx *bolt.Tx) error {
+		for _, name := range catalogBuckets {
+			stats[string(name)] = tx.Bucket(name).Stats().KeyN
+		}
+		return nil
+	})
+	return stats
+}
+
+// CachedAnimeDetail mengembalikan detail anime dari katalog jika di-scrape dalam maxAge terakhir.
+func CachedAnimeDetail(slug string, maxAge time.Duration) (ScrapedAnimeDetails, bool) {
+	record, ok := CatalogAnimeBySlug(slug)
+	if !ok || record.DetailScrapedAt.IsZero() || time.Since(record.DetailScrapedAt) > maxAge {
+		return ScrapedAnimeDetails{}, false
+	}
+
+	data := ScrapedAnimeDetails{
+		Judul:       record.Title,
+		Thumbnail:   record.Cover,
+		Skor:        record.Score,
+		Sinopsis:    record.Synopsis,
+		Genre:       record.Genres,
+		Details:     record.Details,
+		Rekomendasi: record.Recommendations,
+		Terkait:     record.Related,
+	}
+	if data.Details == nil {
+		data.Details = make(map[string]string)
+	}
+	for _, ep := range CatalogEpisodes(slug) {
+		// Episode yang sudah tidak muncul di scrape terakhir dilewati
+		if ep.LastSeen.Before(record.DetailScrapedAt) {
+			continue
+		}
+		data.EpisodeList = append(data.EpisodeList, ScrapedEpisode{
+			Episode:      ep.Number,
+			Judul:        ep.Title,
+			URL:          ep.URL,
+			TanggalRilis: ep.ReleaseDate,
+		})
+	}
+	// URL di katalog memakai host saat di-scrape; arahkan ke mirror yang sedang aktif
+	return rewriteAnimeDetailURLs(data), true
+}
+
+// CachedEpisodeDetail mengembalikan halaman episode dari katalog jika di-scrape dalam maxAge terakhir.
+func CachedEpisodeDetail(episodeURL string, maxAge time.Duration) (ScrapedEpisodeDetails, bool) {
+	var page catalogEpisodePage
+	found := false
+	catalogView(func(tx *bolt.Tx) error {
+		found = getJSON(tx.Bucket(bucketEpisodePages), GetSlugFromURL(episodeURL), &page)
+		return nil
+	})
+	if !found || time.Since(page.ScrapedAt) > maxAge {
+		return ScrapedEpisodeDetails{}, false
+	}
+	return rewriteEpisodeDetailURLs(page.Data), true
+}
@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"multiplescrape/events"
+)
+
+func openTestCatalog(t *testing.T) {
+	t.Helper()
+	if err := OpenCatalog(filepath.Join(t.TempDir(), "catalog.db")); err != nil {
+		t.Fatalf("gagal membuka katalog: %v", err)
+	}
+	t.Cleanup(func() { CloseCatalog() })
+}
+
+func TestCatalogAnimeDetailRoundTrip(t *testing.T) {
+	openTestCatalog(t)
+
+	catalogRecordAnimeDetail("naruto", ScrapedAnimeDetails{
+		Judul:    "Naruto",
+		Skor:     "8.0",
+		Sinopsis: "Ninja.",
+		Genre:    []string{"Action"},
+		Details:  map[string]string{"Status": "Completed", "Type": "TV"},
+		EpisodeList: []ScrapedEpisode{
+			{Episode: "2", URL: "https://gomunime.co/naruto-episode-2/"},
+			{Episode: "1", URL: "https://gomunime.co/naruto-episode-1/"},
+		},
+	})
+
+	data, ok := CachedAnimeDetail("naruto", time.Hour)
+	if !ok {
+		t.Fatal("detail seharusnya tersedia di katalog")
+	}
+	if data.Judul != "Naruto" || data.Details["Status"] != "Completed" {
+		t.Errorf("data katalog tidak sesuai: %+v", data)
+	}
+	if len(data.EpisodeList) != 2 || data.EpisodeList[0].Episode != "2" {
+		t.Errorf("urutan episode seharusnya dipertahankan, didapat %+v", data.EpisodeList)
+	}
+
+	if _, ok := CachedAnimeDetail("naruto", 0); ok {
+		t.Error("detail yang melewati TTL tidak boleh dipakai")
+	}
+}
+
+func TestCachedAnimeDetailRewritesMirrorURLs(t *testing.T) {
+	openTestCatalog(t)
+	useMirrorConfig(t, "https://old.example", "https://new.example")
+
+	catalogRecordAnimeDetail("naruto", ScrapedAnimeDetails{
+		Judul:       "Naruto",
+		Thumbnail:   "https://old.example/wp-content/naruto.jpg",
+		EpisodeList: []ScrapedEpisode{{Episode: "1", URL: "https://old.example/naruto-episode-1/"}},
+		Rekomendasi: []ScrapedRecommendation{{Judul: "Bleach", URL: "https://old.example/anime/bleach/", Thumbnail: "https://old.example/wp-content/bleach.jpg"}},
+		Terkait:     []ScrapedRecommendation{{Judul: "Boruto", URL: "https://old.example/anime/boruto/", Thumbnail: "https://old.example/wp-content/boruto.jpg"}},
+	})
+	// Failover ke mirror lain setelah detail tersimpan
+	mirrors.mu.Lock()
+	mirrors.active = "https://new.example/"
+	mirrors.mu.Unlock()
+
+	data, ok := CachedAnimeDetail("naruto", time.Hour)
+	if !ok {
+		t.Fatal("detail seharusnya tersedia di katalog")
+	}
+	urls := []string{
+		data.Thumbnail,
+		data.EpisodeList[0].URL,
+		data.Rekomendasi[0].URL, data.Rekomendasi[0].Thumbnail,
+		data.Terkait[0].URL, data.Terkait[0].Thumbnail,
+	}
+	for _, u := range urls {
+		if !strings.HasPrefix(u, "https://new.example/") {
+			t.Errorf("URL katalog seharusnya memakai mirror aktif, didapat %s", u)
+		}
+	}
+}
+
+func TestCatalogListingDoesNotCountAsDetail(t *testing.T) {
+	openTestCatalog(t)
+
+	catalogRecordSearch([]ScrapedSearchResult{{
+		Judul:  "One Piece",
+		Tautan: "https://gomunime.co/anime/one-piece/",
+		Skor:   "9.0",
+	}})
+	catalogRecordLatest([]ScrapedLatestAnime{{
+		Judul:  "One Piece",
+		Tautan: "https://gomunime.co/one-piece-episode-1100/",
+		Status: "Ongoing",
+	}})
+
+	record, ok := CatalogAnimeBySlug("one-piece")
+	if !ok {
+		t.Fatal("anime dari listing seharusnya tersimpan")
+	}
+	if record.Score != "9.0" || record.Status != "Ongoing" {
+		t.Errorf("listing seharusnya digabung, didapat %+v", record)
+	}
+	if _, ok := CachedAnimeDetail("one-piece", time.Hour); ok {
+		t.Error("anime yang hanya muncul di listing tidak boleh dianggap detail lengkap")
+	}
+}
+
+func TestCatalogEpisodeStreams(t *testing.T) {
+	openTestCatalog(t)
+
+	episodeURL := "https://gomunime.co/naruto-episode-1/"
+	catalogRecordEpisodeDetail(episodeURL, ScrapedEpisodeDetails{
+		Title: "Naruto Episode 1",
+		StreamingServers: []StreamingServer{
+			{ServerName: "Nakama 720p", StreamingURL: "https://pixeldrain.com/u/a"},
+			{ServerName: "Nakama 1080p", StreamingURL: "https://pixeldrain.com/u/b"},
+		},
+	})
+
+	if got := len(CatalogStreams("naruto-episode-1")); got != 2 {
+		t.Errorf("seharusnya ada 2 stream, didapat %d", got)
+	}
+	data, ok := CachedEpisodeDetail(episodeURL, time.Hour)
+	if !ok || data.Title != "Naruto Episode 1" {
+		t.Errorf("halaman episode seharusnya tersedia di katalog, didapat %+v", data)
+	}
+	if stats := CatalogStats(); stats["streams"] != 2 || stats["episode_pages"] != 1 {
+		t.Errorf("statistik katalog tidak sesuai: %v", stats)
+	}
+}
+
+func TestCatalogClosedIsNoop(t *testing.T) {
+	catalogRecordSearch([]ScrapedSearchResult{{Judul: "X", Tautan: "https://gomunime.co/anime/x/"}})
+	if _, ok := CatalogAnimeBySlug("x"); ok {
+		t.Error("katalog yang belum dibuka tidak boleh menyimpan data")
+	}
+}
+
+func collectEvents(t *testing.T) func() []events.Event {
+	t.Helper()
+	var mu sync.Mutex
+	var got []events.Event
+	cancel := events.Default.Subscribe(func(e events.Event) {
+		mu.Lock()
+		got = append(got, e)
+		mu.Unlock()
+	})
+	t.Cleanup(cancel)
+	return func() []events.Event {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]events.Event{}, got...)
+	}
+}
+
+func eventTypes(list []events.Event) []string {
+	var types []string
+	for _, e := range list {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+func TestCatalogChangeDetection(t *testing.T) {
+	openTestCatalog(t)
+	received := collectEvents(t)
+
+	// Scrape pertama hanya menjadi baseline
+	catalogRecordLatest([]ScrapedLatestAnime{{
+		Judul:  "Naruto",
+		Tautan: "https://gomunime.co/naruto-episode-1/",
+		Status: "Ongoing",
+	}})
+	if got := received(); len(got) != 0 {
+		t.Fatalf("baseline tidak boleh menghasilkan event, didapat %v", eventTypes(got))
+	}
+
+	catalogRecordLatest([]ScrapedLatestAnime{
+		{Judul: "Naruto", Tautan: "https://gomunime.co/naruto-episode-2/", Episode: "2", Status: "Completed"},
+		{Judul: "Bleach", Tautan: "https://gomunime.co/bleach-episode-1/", Episode: "1"},
+	})
+	got := received()
+	if len(got) != 3 {
+		t.Fatalf("seharusnya 3 event, didapat %v", eventTypes(got))
+	}
+	if got[0].Type != events.AnimeCompleted || got[1].Type != events.NewEpisode || got[1].Episode != "2" || got[2].Type != events.NewAnime {
+		t.Errorf("urutan/isi event tidak sesuai: %+v", got)
+	}
+
+	// Episode yang sama tidak dilaporkan dua kali
+	catalogRecordLatest([]ScrapedLatestAnime{{Judul: "Naruto", Tautan: "https://gomunime.co/naruto-episode-2/"}})
+	if n := len(received()); n != 3 {
+		t.Errorf("episode lama tidak boleh menghasilkan event baru, total %d", n)
+	}
+}
+
+func TestCatalogNewAnimeOnlyFromLatest(t *testing.T) {
+	openTestCatalog(t)
+	catalogRecordListing([]CatalogAnime{{Slug: "naruto", Title: "Naruto"}})
+	received := collectEvents(t)
+
+	catalogRecordSearch([]ScrapedSearchResult{{Judul: "One Piece", Tautan: "https://gomunime.co/anime/one-piece/"}})
+	catalogRecordSchedule([]ScrapedDaySchedule{{
+		Hari:      "Minggu",
+		AnimeList: []ScrapedAnimeSchedule{{Judul: "Bleach", Tautan: "https://gomunime.co/anime/bleach/", WaktuRilis: "21:00"}},
+	}})
+	for _, e := range received() {
+		if e.Type == events.NewAnime {
+			t.Fatalf("anime dari pencarian atau jadwal tidak boleh dilaporkan new_anime: %+v", e)
+		}
+	}
+
+	catalogRecordLatest([]ScrapedLatestAnime{{Judul: "Frieren", Tautan: "https://gomunime.co/frieren-episode-1/", Episode: "1"}})
+	got := received()
+	if last := got[len(got)-1]; last.Type != events.NewAnime || last.AnimeSlug != "frieren" {
+		t.Errorf("anime baru dari listing terbaru seharusnya new_anime, didapat %+v", got)
+	}
+}
+
+func TestCatalogStreamAdded(t *testing.T) {
+	openTestCatalog(t)
+	catalogRecordListing([]CatalogAnime{{Slug: "naruto", Title: "Naruto"}})
+	received := collectEvents(t)
+
+	episodeURL := "https://gomunime.co/naruto-episode-1/"
+	page := ScrapedEpisodeDetails{
+		Title:            "Naruto Episode 1",
+		StreamingServers: []StreamingServer{{ServerName: "A", StreamingURL: "https://pixeldrain.com/u/a"}},
+	}
+	catalogRecordEpisodeDetail(episodeURL, page)
+	if n := len(received()); n != 0 {
+		t.Fatalf("scrape pertama halaman episode tidak boleh menghasilkan stream_added, didapat %d", n)
+	}
+
+	page.StreamingServers = append(page.StreamingServers, StreamingServer{ServerName: "B", StreamingURL: "https://pixeldrain.com/u/b"})
+	catalogRecordEpisodeDetail(episodeURL, page)
+	got := received()
+	if len(got) != 1 || got[0].Type != events.StreamAdded || got[0].ServerName != "B" || got[0].AnimeSlug != "naruto" {
+		t.Errorf("seharusnya satu stream_added untuk server B, didapat %+v", got)
+	}
+}
+
+func TestCatalogScheduleChanged(t *testing.T) {
+	openTestCatalog(t)
+	schedule := []ScrapedDaySchedule{{
+		Hari:      "Senin",
+		AnimeList: []ScrapedAnimeSchedule{{Judul: "Naruto", Tautan: "https://gomunime.co/anime/naruto/", WaktuRilis: "20:00"}},
+	}}
+	catalogRecordSchedule(schedule)
+	received := collectEvents(t)
+
+	catalogRecordSchedule(schedule)
+	if n := len(received()); n != 0 {
+		t.Fatalf("jadwal yang sama tidak boleh menghasilkan event, didapat %d", n)
+	}
+
+	schedule[0].Hari = "Selasa"
+	catalogRecordSchedule(schedule)
+	got := received()
+	if len(got) != 1 || got[0].Type != events.ScheduleChanged || got[0].Day != "Selasa" || got[0].Previous != "Senin 20:00" {
+		t.Errorf("seharusnya satu schedule_changed dari Senin ke Selasa, didapat %+v", got)
+	}
+}
+
+func TestCatalogLatestEpisodeAt(t *testing.T) {
+	openTestCatalog(t)
+
+	catalogRecordSearch([]ScrapedSearchResult{{Judul: "Naruto", Tautan: "https://gomunime.co/anime/naruto/"}})
+	if record, _ := CatalogAnimeBySlug("naruto"); !record.LatestEpisodeAt.IsZero() {
+		t.Fatal("anime tanpa episode tidak boleh punya latest_episode_at")
+	}
+
+	catalogRecordLatest([]ScrapedLatestAnime{{Judul: "Naruto", Tautan: "https://gomunime.co/naruto-episode-1/"}})
+	first, _ := CatalogAnimeBySlug("naruto")
+	if first.LatestEpisodeAt.IsZero() {
+		t.Fatal("episode baru seharusnya mengisi latest_episode_at")
+	}
+
+	catalogRecordLatest([]ScrapedLatestAnime{{Judul: "Naruto", Tautan: "https://gomunime.co/naruto-episode-1/"}})
+	if again, _ := CatalogAnimeBySlug("naruto"); !again.LatestEpisodeAt.Equal(first.LatestEpisodeAt) {
+		t.Error("episode yang sudah dikenal tidak boleh mengubah latest_episode_at")
+	}
+}
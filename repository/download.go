@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Asal data download_links di detail episode.
+const (
+	DownloadSourcePage   = "page"            // bagian download di halaman episode
+	DownloadSourceStream = "stream_fallback" // diturunkan dari server streaming karena halaman tidak punya bagian download
+	DownloadSourceNone   = "none"
+
+	// StreamFallbackFormat adalah kunci format untuk link download cadangan dari server streaming.
+	StreamFallbackFormat = "Stream (fallback)"
+)
+
+var (
+	formatTokenPattern = regexp.MustCompile(`(?i)\b(mkv|mp4|x265|x264|hevc|av1|webm|10bit)\b`)
+	resolutionPattern  = regexp.MustCompile(`(?i)\b(\d{3,4})p\b`)
+	resolutionAliases  = regexp.MustCompile(`(?i)\b(4k|fhd|hd|sd)\b`)
+)
+
+// formatLabels menyeragamkan penulisan token format, misalnya "mkv" menjadi "MKV" dan "X265" menjadi "x265".
+var formatLabels = map[string]string{
+	"mkv": "MKV", "mp4": "MP4", "x265": "x265", "x264": "x264",
+	"hevc": "HEVC", "av1": "AV1", "webm": "WEBM", "10bit": "10bit",
+}
+
+// downloadFormat mengambil label format dari judul blok download seperti "Download Naruto Episode 1 [MKV]"
+// atau "MP4 x265". Judul tanpa token format yang dikenal dipakai apa adanya tanpa awalan "Download".
+func downloadFormat(text string) string {
+	var tokens []string
+	seen := make(map[string]bool)
+	for _, match := range formatTokenPattern.FindAllString(text, -1) {
+		label := formatLabels[strings.ToLower(match)]
+		if !seen[label] {
+			seen[label] = true
+			tokens = append(tokens, label)
+		}
+	}
+	if len(tokens) > 0 {
+		return strings.Join(tokens, " ")
+	}
+	text = strings.TrimSpace(text)
+	if len(text) >= len("download") && strings.EqualFold(text[:len("download")], "download") {
+		text = strings.TrimSpace(text[len("download"):])
+	}
+	return FillStrIfEmpty(text, "Unknown")
+}
+
+// downloadResolution mengambil resolusi seperti "720p" atau "FHD" dari label baris download.
+func downloadResolution(text string) string {
+	if match := resolutionPattern.FindStringSubmatch(text); match != nil {
+		return match[1] + "p"
+	}
+	if match := resolutionAliases.FindString(text); match != "" {
+		return strings.ToUpper(match)
+	}
+	return FillStrIfEmpty(strings.TrimSpace(text), "Unknown")
+}
+
+// downloadCollector mengumpulkan link download per format dan resolusi tanpa duplikat.
+type downloadCollector struct {
+	links    map[string]map[string][]DownloadProvider
+	seen     map[string]bool
+	absolute func(string) string
+}
+
+func newDownloadCollector(absolute func(string) string) *downloadCollector {
+	return &downloadCollector{
+		links:    make(map[string]map[string][]DownloadProvider),
+		seen:     make(map[string]bool),
+		absolute: absolute,
+	}
+}
+
+// addAnchors menambahkan semua <a> di row sebagai provider untuk format dan resolusi tertentu.
+func (d *downloadCollector) addAnchors(format, resolution string, row *goquery.Selection) {
+	row.Find("a[href]").Each(func(_ int, a *goquery.Selection) {
+		d.add(format, resolution, a.Text(), a.AttrOr("href", ""))
+	})
+}
+
+func (d *downloadCollector) add(format, resolution, provider, href string) {
+	href = strings.TrimSpace(href)
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+		return
+	}
+	link := d.absolute(href)
+	key := format + "|" + resolution + "|" + link
+	if d.seen[key] {
+		return
+	}
+	d.seen[key] = true
+
+	provider = strings.TrimSpace(provider)
+	if provider == "" {
+		if u, err := url.Parse(link); err == nil {
+			provider = strings.TrimPrefix(u.Hostname(), "www.")
+		}
+	}
+	if d.links[format] == nil {
+		d.links[format] = make(map[string][]DownloadProvider)
+	}
+	d.links[format][resolution] = append(d.links[format][resolution], DownloadProvider{Provider: provider, URL: link})
+}
+
+// parseDownloadSection membaca bagian download halaman episode. Tiga tata letak tema yang umum didukung:
+//
+//   - .soraddlx: judul format di .sorattlx, lalu satu .soraurlx per resolusi berisi <strong> dan link provider
+//   - .download-eps: <p> berisi format diikuti <ul> dengan satu <li> per resolusi
+//   - .dlbox: tabel <li> dengan span.q (format dan resolusi), span.w (provider), dan span.e a (link)
+//
+// absolute dipakai untuk mengubah href relatif menjadi URL lengkap.
+func parseDownloadSection(doc *goquery.Selection, absolute func(string) string) map[string]map[string][]DownloadProvider {
+	d := newDownloadCollector(absolute)
+
+	doc.Find(".soraddlx").Each(func(_ int, block *goquery.Selection) {
+		format := downloadFormat(block.Find(".sorattlx").First().Text())
+		block.Find(".soraurlx").Each(func(_ int, row *goquery.Selection) {
+			d.addAnchors(format, downloadResolution(row.Find("strong").First().Text()), row)
+		})
+	})
+
+	doc.Find(".download-eps").Each(func(_ int, block *goquery.Selection) {
+		format := downloadFormat(block.Find("p").First().Text())
+		block.Children().Each(func(_ int, child *goquery.Selection) {
+			switch goquery.NodeName(child) {
+			case "p":
+				format = downloadFormat(child.Text())
+			case "ul":
+				child.Find("li").Each(func(_ int, row *goquery.Selection) {
+					d.addAnchors(format, downloadResolution(row.Find("strong").First().Text()), row)
+				})
+			}
+		})
+	})
+
+	doc.Find(".dlbox li").Each(func(_ int, row *goquery.Selection) {
+		if row.HasClass("head") {
+			return
+		}
+		label := row.Find("span.q").Text()
+		href := row.Find("span.e a").AttrOr("href", "")
+		d.add(downloadFormat(label), downloadResolution(formatTokenPattern.ReplaceAllString(label, "")), row.Find("span.w").Text(), href)
+	})
+
+	return d.links
+}
+
+// streamFallbackDownloads menurunkan link download cadangan dari server streaming, dikelompokkan
+// berdasarkan resolusi di nama server. Dipakai hanya jika halaman tidak punya bagian download.
+func streamFallbackDownloads(servers []StreamingServer) map[string]map[string][]DownloadProvider {
+	links := make(map[string]map[string][]DownloadProvider)
+	if len(servers) == 0 {
+		return links
+	}
+	byResolution := make(map[string][]DownloadProvider)
+	for _, server := range servers {
+		resolution := FillStrIfEmpty(QualityFromText(server.ServerName), "HD")
+		byResolution[resolution] = append(byResolution[resolution], DownloadProvider{
+			Provider: server.ServerName,
+			URL:      server.StreamingURL,
+		})
+	}
+	links[StreamFallbackFormat] = byResolution
+	return links
+}
+
+// EpisodeDownloadSource mengembalikan asal download_links. Data katalog lama yang belum punya
+// DownloadSource dikenali dari kunci format cadangan.
+func EpisodeDownloadSource(data ScrapedEpisodeDetails) string {
+	switch {
+	case data.DownloadSource != "":
+		return data.DownloadSource
+	case len(data.DownloadLinks) == 0:
+		return DownloadSourceNone
+	case data.DownloadLinks[StreamFallbackFormat] != nil || data.DownloadLinks["MP4 (from Stream)"] != nil:
+		return DownloadSourceStream
+	}
+	return DownloadSourcePage
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func parseDownloadHTML(t *testing.T, html string) map[string]map[string][]DownloadProvider {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parseDownloadSection(doc.Selection, func(href string) string {
+		if strings.HasPrefix(href, "/") {
+			return "https://gomunime.co" + href
+		}
+		return href
+	})
+}
+
+func TestParseDownloadSectionSoraddlx(t *testing.T) {
+	links := parseDownloadHTML(t, `<div class="soraddlx">
+<div class="sorattlx"><h3>Download Naruto Episode 1 [MKV]</h3></div>
+<div class="soraurlx"><strong>480p</strong><a href="https://gofile.io/d/a">Gofile</a><a href="https://pixeldrain.com/u/b">Pixeldrain</a></div>
+<div class="soraurlx"><strong>720p HD</strong><a href="#">Segera</a><a href="/go/c">Mirror</a></div>
+</div>
+<div class="soraddlx"><div class="sorattlx"><h3>Download x265 [MP4]</h3></div>
+<div class="soraurlx"><strong>1080p</strong><a href="https://krakenfiles.com/view/d"></a></div></div>`)
+
+	if got := links["MKV"]["480p"]; len(got) != 2 || got[0].Provider != "Gofile" || got[1].URL != "https://pixeldrain.com/u/b" {
+		t.Errorf("link MKV 480p tidak sesuai: %+v", got)
+	}
+	if got := links["MKV"]["720p"]; len(got) != 1 || got[0].URL != "https://gomunime.co/go/c" {
+		t.Errorf("href '#' seharusnya dilewati dan href relatif dibuat absolut: %+v", got)
+	}
+	if got := links["x265 MP4"]["1080p"]; len(got) != 1 || got[0].Provider != "krakenfiles.com" {
+		t.Errorf("provider tanpa teks seharusnya diambil dari host: %+v", links)
+	}
+}
+
+func TestParseDownloadSectionDownloadEpsAndDlbox(t *testing.T) {
+	links := parseDownloadHTML(t, `<div class="download-eps"><p><b>Format MP4</b></p>
+<ul><li><strong>360p</strong><a href="https://a.example/1">Zippy</a><a href="https://a.example/1">Zippy</a></li>
+<li><strong>FHD</strong><a href="javascript:void(0)">x</a><a href="https://a.example/2">Mega</a></li></ul>
+<p><b>Format MKV</b></p><ul><li><strong>720p</strong><a href="https://a.example/3">Gdrive</a></li></ul></div>
+<div class="dlbox"><ul><li class="head"><span class="q">Kualitas</span></li>
+<li><span class="q">MKV 1080p</span><span class="w">Mediafire</span><span class="e"><a href="https://a.example/4">Unduh</a></span></li></ul></div>`)
+
+	if got := links["MP4"]["360p"]; len(got) != 1 {
+		t.Errorf("link duplikat seharusnya hanya muncul sekali: %+v", got)
+	}
+	if got := links["MP4"]["FHD"]; len(got) != 1 || got[0].Provider != "Mega" {
+		t.Errorf("link javascript: seharusnya dilewati: %+v", got)
+	}
+	if got := links["MKV"]["720p"]; len(got) != 1 || got[0].Provider != "Gdrive" {
+		t.Errorf("format seharusnya berganti mengikuti <p> berikutnya: %+v", links)
+	}
+	if got := links["MKV"]["1080p"]; len(got) != 1 || got[0].Provider != "Mediafire" || got[0].URL != "https://a.example/4" {
+		t.Errorf("baris .dlbox tidak terbaca: %+v", links["MKV"])
+	}
+}
+
+func episodeStubServer(t *testing.T, downloadSection string) *httptest.Server {
+	t.Helper()
+	iframe := base64.StdEncoding.EncodeToString([]byte(`<iframe src="https://pixeldrain.com/u/x"></iframe>`))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/naruto-episode-1/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `<html><body><h1 class="entry-title">Naruto Episode 1</h1>
+<select class="mirror"><option value="%s">Nakama 720p</option></select>%s</body></html>`, iframe, downloadSection)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestScrapeEpisodeDetailPrefersDownloadSection(t *testing.T) {
+	server := episodeStubServer(t, `<div class="soraddlx"><div class="sorattlx"><h3>MKV</h3></div>
+<div class="soraurlx"><strong>720p</strong><a href="https://gofile.io/d/a">Gofile</a></div></div>`)
+	useStubSource(t, server.URL)
+
+	data := ScrapeEpisodeDetail(server.URL + "/naruto-episode-1/")
+	if data.DownloadSource != DownloadSourcePage || len(data.DownloadLinks) != 1 || len(data.DownloadLinks["MKV"]["720p"]) != 1 {
+		t.Errorf("link download dari halaman seharusnya dipakai: %s %+v", data.DownloadSource, data.DownloadLinks)
+	}
+}
+
+func TestScrapeEpisodeDetailLabelsStreamFallback(t *testing.T) {
+	server := episodeStubServer(t, "")
+	useStubSource(t, server.URL)
+
+	data := ScrapeEpisodeDetail(server.URL + "/naruto-episode-1/")
+	if data.DownloadSource != DownloadSourceStream {
+		t.Errorf("download_source seharusnya %s, didapat %q", DownloadSourceStream, data.DownloadSource)
+	}
+	if got := data.DownloadLinks[StreamFallbackFormat]["720p"]; len(got) != 1 || got[0].URL != "https://pixeldrain.com/u/x" {
+		t.Errorf("cadangan dari server streaming tidak sesuai: %+v", data.DownloadLinks)
+	}
+}
+
+func TestEpisodeDownloadSourceForOlderCatalogData(t *testing.T) {
+	legacy := ScrapedEpisodeDetails{DownloadLinks: map[string]map[string][]DownloadProvider{"MP4 (from Stream)": {"HD": nil}}}
+	if got := EpisodeDownloadSource(legacy); got != DownloadSourceStream {
+		t.Errorf("kunci cadangan lama seharusnya dikenali, didapat %s", got)
+	}
+	if got := EpisodeDownloadSource(ScrapedEpisodeDetails{}); got != DownloadSourceNone {
+		t.Errorf("tanpa link seharusnya none, didapat %s", got)
+	}
+}
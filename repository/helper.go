@@ -77,6 +77,44 @@ func SlugToTitle(slug string) string {
 	return strings.Join(words, " ")
 }
 
+// NewSearchResultItem memformat anime dari katalog menjadi item hasil pencarian untuk response API.
+func NewSearchResultItem(anime CatalogAnime) SearchResultItem {
+	return SearchResultItem{
+		Judul:     anime.Title,
+		URLAnime:  RewriteURL(anime.URL),
+		AnimeSlug: anime.Slug,
+		URLCover:  RewriteURL(anime.Cover),
+		Status:    FillStrIfEmpty(anime.Status, "N/A"),
+		Tipe:      FillStrIfEmpty(anime.Type, "N/A"),
+		Skor:      FillStrIfEmpty(anime.Score, "N/A"),
+		Sinopsis:  FillStrIfEmpty(anime.Synopsis, "Sinopsis tidak tersedia."),
+		Genre:     anime.Genres,
+		Penonton:  "N/A",
+	}
+}
+
+// NewSuggestItem memformat anime katalog menjadi saran autocomplete.
+func NewSuggestItem(anime CatalogAnime) SuggestItem {
+	return SuggestItem{
+		Slug:  anime.Slug,
+		Title: anime.Title,
+		Cover: RewriteURL(anime.Cover),
+		Type:  FillStrIfEmpty(anime.Type, "N/A"),
+	}
+}
+
+// NewEpisodeListItem memformat episode hasil scrape menjadi item daftar episode untuk response API.
+func NewEpisodeListItem(ep ScrapedEpisode) EpisodeListItem {
+	episodeSlug := GetSlugFromURL(ep.URL)
+	return EpisodeListItem{
+		Episode:     FillStrIfEmpty(ep.Episode, "N/A"),
+		Title:       SlugToTitle(episodeSlug),
+		URL:         ep.URL,
+		EpisodeSlug: episodeSlug,
+		ReleaseDate: FillStrIfEmpty(ep.TanggalRilis, "N/A"),
+	}
+}
+
 // ValidateHomeData memvalidasi data untuk endpoint home
 func ValidateHomeData(data HomeData) float64 {
 	score := 1.0
@@ -202,10 +240,19 @@ func ValidateEpisodeDetailData(data EpisodeDetailData) float64 {
 	}
 	
 	// Validasi streaming servers
+	dead := 0
 	for _, server := range data.StreamingServers {
 		if server.StreamingURL == "" {
 			return 0.0
 		}
+		if server.Alive != nil && !*server.Alive {
+			dead++
+		}
+	}
+
+	// Semua server sudah dicek dan tidak ada yang hidup
+	if dead == len(data.StreamingServers) {
+		return 0.0
 	}
 	
 	return 1.0
@@ -0,0 +1,401 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"multiplescrape/config"
+)
+
+// failoverThreshold adalah jumlah error berturut-turut sebelum mirror aktif diprobe ulang.
+const failoverThreshold = 3
+
+// MirrorStatus adalah hasil probe terakhir untuk satu kandidat domain.
+type MirrorStatus struct {
+	BaseURL      string    `json:"base_url"`
+	Healthy      bool      `json:"healthy"`
+	StatusCode   int       `json:"status_code,omitempty"`
+	LatencyMs    int64     `json:"latency_ms"`
+	RedirectedTo string    `json:"redirected_to,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	CheckedAt    time.Time `json:"checked_at"`
+}
+
+// mirrorManager memilih domain sumber yang aktif dari daftar kandidat.
+type mirrorManager struct {
+	mu         sync.Mutex
+	active     string
+	discovered []string
+	status     map[string]MirrorStatus
+	failures   int
+	probing    bool
+	client     *http.Client
+}
+
+var mirrors = &mirrorManager{
+	status: make(map[string]MirrorStatus),
+	client: &http.Client{
+		Timeout: 15 * time.Second,
+		// Redirect tidak diikuti agar perpindahan domain bisa dideteksi.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	},
+}
+
+// normalizeBaseURL mengubah URL menjadi bentuk "scheme://host/".
+func normalizeBaseURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host + "/"
+}
+
+// candidates mengembalikan base_domain, mirror dari konfigurasi,
+// dan domain hasil redirect yang ditemukan saat probe, tanpa duplikat.
+func (m *mirrorManager) candidates() []string {
+	src := config.Current().Source
+	seen := make(map[string]bool)
+	var out []string
+	for _, raw := range append(append([]string{src.BaseDomain}, src.Mirrors...), m.discovered...) {
+		if base := normalizeBaseURL(raw); base != "" && !seen[base] {
+			seen[base] = true
+			out = append(out, base)
+		}
+	}
+	return out
+}
+
+// ActiveBaseURL mengembalikan domain sumber yang sedang dipakai, diakhiri "/".
+func ActiveBaseURL() string {
+	mirrors.mu.Lock()
+	defer mirrors.mu.Unlock()
+	if mirrors.active != "" {
+		return mirrors.active
+	}
+	return normalizeBaseURL(config.Current().Source.BaseDomain)
+}
+
+// MirrorStatuses mengembalikan domain aktif beserta hasil probe setiap kandidat.
+func MirrorStatuses() (string, []MirrorStatus) {
+	active := ActiveBaseURL()
+
+	mirrors.mu.Lock()
+	defer mirrors.mu.Unlock()
+	var statuses []MirrorStatus
+	for _, base := range mirrors.candidates() {
+		status, ok := mirrors.status[base]
+		if !ok {
+			status = MirrorStatus{BaseURL: base}
+		}
+		statuses = append(statuses, status)
+	}
+	return active, statuses
+}
+
+// StartMirrorProber menjalankan probe berkala di background. Fungsi yang dikembalikan menghentikannya.
+func StartMirrorProber(interval time.Duration) func() {
+	stop := make(chan struct{})
+	go func() {
+		ProbeMirrors()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				ProbeMirrors()
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return func() { close(stop) }
+}
+
+// ProbeMirrors memeriksa semua kandidat lalu memilih domain aktif.
+// Domain aktif dipertahankan selama masih sehat; jika redirect ke host lain yang sehat, host tujuan dipakai.
+func ProbeMirrors() string {
+	mirrors.mu.Lock()
+	if mirrors.probing {
+		mirrors.mu.Unlock()
+		return ActiveBaseURL()
+	}
+	mirrors.probing = true
+	candidates := mirrors.candidates()
+	mirrors.mu.Unlock()
+
+	results := make(map[string]MirrorStatus, len(candidates))
+	for _, base := range candidates {
+		results[base] = mirrors.probe(base)
+	}
+
+	current := ActiveBaseURL()
+	// Tujuan redirect dari domain aktif ikut diprobe; hanya dipakai jika benar-benar sehat.
+	redirect := ""
+	if status, ok := results[current]; ok && !status.Healthy && status.RedirectedTo != "" {
+		redirect = status.RedirectedTo
+		if _, probed := results[redirect]; !probed {
+			results[redirect] = mirrors.probe(redirect)
+		}
+	}
+
+	mirrors.mu.Lock()
+	defer mirrors.mu.Unlock()
+	mirrors.probing = false
+	mirrors.failures = 0
+	for base, status := range results {
+		mirrors.status[base] = status
+	}
+
+	next := ""
+	if status, ok := results[current]; ok && status.Healthy {
+		next = current
+	} else if redirect != "" && results[redirect].Healthy {
+		next = redirect
+		if !slices.Contains(candidates, next) {
+			mirrors.discovered = append(mirrors.discovered, next)
+		}
+	} else {
+		for _, base := range candidates {
+			if results[base].Healthy {
+				next = base
+				break
+			}
+		}
+	}
+
+	if next != "" && next != current {
+		log.Printf("Mirror aktif berpindah: %s -> %s", current, next)
+		mirrors.active = next
+	}
+	if next == "" {
+		log.Printf("Tidak ada mirror yang sehat, tetap memakai %s", current)
+		return current
+	}
+	return next
+}
+
+func (m *mirrorManager) probe(base string) MirrorStatus {
+	status := MirrorStatus{BaseURL: base, CheckedAt: time.Now()}
+
+	req, err := http.NewRequest(http.MethodGet, base, nil)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	req.Header.Set("User-Agent", userAgent())
+
+	start := time.Now()
+	resp, err := m.client.Do(req)
+	status.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	defer resp.Body.Close()
+	status.StatusCode = resp.StatusCode
+
+	switch {
+	case resp.StatusCode >= 300 && resp.StatusCode < 400:
+		location, err := resp.Location()
+		if err != nil {
+			status.Error = "redirect tanpa Location"
+			return status
+		}
+		target := normalizeBaseURL(location.String())
+		if target == base {
+			// Redirect di host yang sama (mis. http -> path lain) tetap dianggap sehat.
+			status.Healthy = true
+			return status
+		}
+		status.RedirectedTo = target
+		status.Error = fmt.Sprintf("redirect ke %s", target)
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		status.Healthy = true
+	default:
+		status.Error = fmt.Sprintf("status HTTP %d", resp.StatusCode)
+	}
+	return status
+}
+
+// reportSourceError dipanggil dari OnError collector. Setelah beberapa kegagalan
+// berturut-turut pada domain aktif, probe ulang dijalankan di background.
+func reportSourceError(statusCode int, requestURL *url.URL) {
+	if requestURL == nil || normalizeBaseURL(requestURL.String()) != ActiveBaseURL() {
+		return
+	}
+	// 404 berarti halaman tidak ada, bukan domain yang mati.
+	if statusCode == http.StatusNotFound {
+		return
+	}
+
+	mirrors.mu.Lock()
+	mirrors.failures++
+	trigger := mirrors.failures >= failoverThreshold && !mirrors.probing
+	mirrors.mu.Unlock()
+
+	if trigger {
+		go ProbeMirrors()
+	}
+}
+
+// reportSourceSuccess dipanggil dari OnResponse collector. Respons sukses dari domain aktif
+// mengosongkan hitungan kegagalan, sehingga failover hanya terjadi setelah error berturut-turut.
+func reportSourceSuccess(requestURL *url.URL) {
+	if requestURL == nil || normalizeBaseURL(requestURL.String()) != ActiveBaseURL() {
+		return
+	}
+	mirrors.mu.Lock()
+	mirrors.failures = 0
+	mirrors.mu.Unlock()
+}
+
+// ErrHostNotAllowed dikembalikan jika URL tidak mengarah ke domain sumber.
+var ErrHostNotAllowed = errors.New("host bukan domain sumber")
+
+// sameSourceHost membandingkan host (beserta port) tanpa membedakan awalan "www.".
+func sameSourceHost(a, b string) bool {
+	a = strings.TrimPrefix(strings.ToLower(a), "www.")
+	b = strings.TrimPrefix(strings.ToLower(b), "www.")
+	return a == b
+}
+
+// IsSourceHost mengecek apakah host termasuk domain sumber: base_domain, mirror dari konfigurasi,
+// atau mirror yang ditemukan lewat redirect saat probe.
+func IsSourceHost(host string) bool {
+	if host == "" {
+		return false
+	}
+	mirrors.mu.Lock()
+	candidates := mirrors.candidates()
+	mirrors.mu.Unlock()
+	for _, base := range append(candidates, ActiveBaseURL()) {
+		if candidate, err := url.Parse(base); err == nil && sameSourceHost(host, candidate.Host) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckSourceURL memastikan URL dari klien aman diambil: http/https, tanpa userinfo,
+// dan host-nya termasuk domain sumber. Ini mencegah API dipakai sebagai proxy ke alamat lain.
+func CheckSourceURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: scheme %q tidak didukung", ErrHostNotAllowed, u.Scheme)
+	}
+	if u.User != nil || !IsSourceHost(u.Host) {
+		return fmt.Errorf("%w: %s", ErrHostNotAllowed, u.Host)
+	}
+	return nil
+}
+
+// RewriteURL mengganti host kandidat mirror mana pun dengan mirror aktif,
+// sehingga semua URL di response konsisten. URL host lain tidak diubah.
+func RewriteURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+
+	active, err := url.Parse(ActiveBaseURL())
+	if err != nil || u.Host == active.Host {
+		return raw
+	}
+
+	mirrors.mu.Lock()
+	candidates := mirrors.candidates()
+	mirrors.mu.Unlock()
+	for _, base := range candidates {
+		candidate, err := url.Parse(base)
+		if err != nil {
+			continue
+		}
+		if sameSourceHost(u.Host, candidate.Host) {
+			u.Scheme = active.Scheme
+			u.Host = active.Host
+			return u.String()
+		}
+	}
+	return raw
+}
+
+// --- Rewrite per tipe hasil scraper ---
+
+func rewriteLatestURLs(items []ScrapedLatestAnime) []ScrapedLatestAnime {
+	for i := range items {
+		items[i].Tautan = RewriteURL(items[i].Tautan)
+		items[i].Thumbnail = RewriteURL(items[i].Thumbnail)
+	}
+	return items
+}
+
+func rewriteScheduleURLs(days []ScrapedDaySchedule) []ScrapedDaySchedule {
+	for i := range days {
+		for j := range days[i].AnimeList {
+			days[i].AnimeList[j].Tautan = RewriteURL(days[i].AnimeList[j].Tautan)
+			days[i].AnimeList[j].Thumbnail = RewriteURL(days[i].AnimeList[j].Thumbnail)
+		}
+	}
+	return days
+}
+
+func rewriteSearchURLs(items []ScrapedSearchResult) []ScrapedSearchResult {
+	for i := range items {
+		items[i].Tautan = RewriteURL(items[i].Tautan)
+		items[i].Thumbnail = RewriteURL(items[i].Thumbnail)
+	}
+	return items
+}
+
+func rewriteAnimeDetailURLs(data ScrapedAnimeDetails) ScrapedAnimeDetails {
+	data.Thumbnail = RewriteURL(data.Thumbnail)
+	for i := range data.EpisodeList {
+		data.EpisodeList[i].URL = RewriteURL(data.EpisodeList[i].URL)
+	}
+	for i := range data.Rekomendasi {
+		data.Rekomendasi[i].URL = RewriteURL(data.Rekomendasi[i].URL)
+		data.Rekomendasi[i].Thumbnail = RewriteURL(data.Rekomendasi[i].Thumbnail)
+	}
+	for i := range data.Terkait {
+		data.Terkait[i].URL = RewriteURL(data.Terkait[i].URL)
+		data.Terkait[i].Thumbnail = RewriteURL(data.Terkait[i].Thumbnail)
+	}
+	return data
+}
+
+func rewriteEpisodeDetailURLs(data ScrapedEpisodeDetails) ScrapedEpisodeDetails {
+	data.ThumbnailURL = RewriteURL(data.ThumbnailURL)
+	data.AnimeInfo.ThumbnailURL = RewriteURL(data.AnimeInfo.ThumbnailURL)
+	data.Navigation.PreviousEpisodeURL = RewriteURL(data.Navigation.PreviousEpisodeURL)
+	data.Navigation.AllEpisodesURL = RewriteURL(data.Navigation.AllEpisodesURL)
+	data.Navigation.NextEpisodeURL = RewriteURL(data.Navigation.NextEpisodeURL)
+	for _, link := range []*EpisodeLink{data.Navigation.Previous, data.Navigation.Next} {
+		if link != nil {
+			link.URL = RewriteURL(link.URL)
+		}
+	}
+	for i := range data.OtherEpisodes {
+		data.OtherEpisodes[i].URL = RewriteURL(data.OtherEpisodes[i].URL)
+		data.OtherEpisodes[i].ThumbnailURL = RewriteURL(data.OtherEpisodes[i].ThumbnailURL)
+	}
+	for _, qualities := range data.DownloadLinks {
+		for _, providers := range qualities {
+			for i := range providers {
+				providers[i].URL = RewriteURL(providers[i].URL)
+			}
+		}
+	}
+	return data
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"multiplescrape/config"
+)
+
+func useMirrorConfig(t *testing.T, base string, extra ...string) {
+	t.Helper()
+	previous := config.Current()
+	cfg := *previous
+	cfg.Source.BaseDomain = base
+	cfg.Source.Mirrors = extra
+	config.Set(&cfg)
+
+	reset := func() {
+		mirrors.mu.Lock()
+		mirrors.active = ""
+		mirrors.discovered = nil
+		mirrors.failures = 0
+		mirrors.status = make(map[string]MirrorStatus)
+		mirrors.mu.Unlock()
+	}
+	reset()
+	t.Cleanup(func() {
+		config.Set(previous)
+		reset()
+	})
+}
+
+func TestProbeMirrorsFailsOver(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer up.Close()
+
+	useMirrorConfig(t, down.URL, up.URL)
+
+	if got := ProbeMirrors(); got != up.URL+"/" {
+		t.Fatalf("mirror aktif seharusnya %s/, didapat %s", up.URL, got)
+	}
+	if got := SourceName(); got != "127.0.0.1" {
+		t.Errorf("source seharusnya host mirror, didapat %s", got)
+	}
+
+	rewritten := RewriteURL(down.URL + "/anime/naruto/")
+	if rewritten != up.URL+"/anime/naruto/" {
+		t.Errorf("URL mirror lama seharusnya ditulis ulang, didapat %s", rewritten)
+	}
+	if other := RewriteURL("https://pixeldrain.com/u/abc"); other != "https://pixeldrain.com/u/abc" {
+		t.Errorf("URL host lain tidak boleh diubah, didapat %s", other)
+	}
+}
+
+func TestProbeMirrorsFollowsRedirect(t *testing.T) {
+	moved := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer moved.Close()
+	old := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, moved.URL+"/", http.StatusMovedPermanently)
+	}))
+	defer old.Close()
+
+	useMirrorConfig(t, old.URL)
+
+	if got := ProbeMirrors(); got != moved.URL+"/" {
+		t.Fatalf("mirror seharusnya pindah ke tujuan redirect %s/, didapat %s", moved.URL, got)
+	}
+	_, statuses := MirrorStatuses()
+	if len(statuses) != 2 {
+		t.Errorf("domain hasil redirect seharusnya masuk daftar kandidat, didapat %d", len(statuses))
+	}
+}
+
+func TestProbeMirrorsSkipsUnhealthyRedirect(t *testing.T) {
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer broken.Close()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer up.Close()
+	old := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, broken.URL+"/", http.StatusMovedPermanently)
+	}))
+	defer old.Close()
+
+	useMirrorConfig(t, old.URL, up.URL)
+
+	if got := ProbeMirrors(); got != up.URL+"/" {
+		t.Fatalf("tujuan redirect yang rusak tidak boleh dipakai, seharusnya %s/, didapat %s", up.URL, got)
+	}
+	if IsSourceHost(strings.TrimPrefix(broken.URL, "http://")) {
+		t.Errorf("tujuan redirect yang rusak tidak boleh masuk daftar domain sumber")
+	}
+}
+
+func TestReportSourceSuccessResetsFailures(t *testing.T) {
+	useMirrorConfig(t, "http://127.0.0.1:1")
+	active, _ := url.Parse(ActiveBaseURL() + "anime/naruto/")
+
+	for i := 0; i < failoverThreshold-1; i++ {
+		reportSourceError(http.StatusBadGateway, active)
+	}
+	reportSourceSuccess(active)
+	reportSourceError(http.StatusBadGateway, active)
+
+	mirrors.mu.Lock()
+	failures := mirrors.failures
+	mirrors.mu.Unlock()
+	if failures != 1 {
+		t.Errorf("respons sukses seharusnya mengosongkan hitungan kegagalan, didapat %d", failures)
+	}
+}
+
+func TestCheckSourceURL(t *testing.T) {
+	useMirrorConfig(t, "https://gomunime.co", "https://gomunime.tv")
+
+	allowed := []string{
+		"https://gomunime.co/naruto-episode-1/",
+		"http://www.gomunime.co/naruto-episode-1/",
+		"https://GOMUNIME.TV/naruto-episode-1/",
+	}
+	for _, raw := range allowed {
+		if err := CheckSourceURL(raw); err != nil {
+			t.Errorf("%s seharusnya diizinkan: %v", raw, err)
+		}
+	}
+
+	blocked := []string{
+		"http://127.0.0.1:8080/admin",
+		"http://169.254.169.254/latest/meta-data/",
+		"https://gomunime.co.evil.com/",
+		"https://gomunime.co@evil.com/",
+		"https://user@gomunime.co/",
+		"file:///etc/passwd",
+		"ftp://gomunime.co/",
+		"/naruto-episode-1/",
+	}
+	for _, raw := range blocked {
+		if err := CheckSourceURL(raw); !errors.Is(err, ErrHostNotAllowed) {
+			t.Errorf("%s seharusnya ditolak dengan ErrHostNotAllowed, didapat %v", raw, err)
+		}
+	}
+}
+
+func TestScraperRedirectStaysOnSourceHosts(t *testing.T) {
+	var internalHits int32
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&internalHits, 1)
+		fmt.Fprint(w, browseListingHTML)
+	}))
+	defer internal.Close()
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/anime/" {
+			fmt.Fprint(w, browseListingHTML)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer mirror.Close()
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("order") {
+		case "internal":
+			http.Redirect(w, r, internal.URL+"/anime/", http.StatusFound)
+		case "mirror":
+			http.Redirect(w, r, mirror.URL+"/anime/", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer source.Close()
+	useStubSource(t, source.URL, mirror.URL)
+
+	if page := ScrapeBrowse(BrowseOptions{Order: "internal"}); len(page.Items) != 0 {
+		t.Errorf("redirect ke host lain tidak boleh diikuti, didapat %d item", len(page.Items))
+	}
+	if hits := atomic.LoadInt32(&internalHits); hits != 0 {
+		t.Errorf("host di luar domain sumber tidak boleh dihubungi, didapat %d request", hits)
+	}
+	if page := ScrapeBrowse(BrowseOptions{Order: "mirror"}); len(page.Items) != 2 {
+		t.Errorf("redirect ke mirror yang dikonfigurasi seharusnya diikuti, didapat %d item", len(page.Items))
+	}
+}
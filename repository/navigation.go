@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Pola nomor episode di slug (misalnya "naruto-episode-12" atau "one-piece-ep-1071-5") dan di label daftar episode.
+var (
+	episodeSlugNumber  = regexp.MustCompile(`(?:^|-)(?:episode|eps|ep)-(\d+)(?:-(\d+))?(?:-|$)`)
+	trailingSlugNumber = regexp.MustCompile(`-(\d+)$`)
+	episodeTextNumber  = regexp.MustCompile(`\d+(?:\.\d+)?`)
+)
+
+// EpisodeNumberFromSlug mengembalikan nomor episode dari slug, misalnya "12" atau "12.5" untuk "-episode-12-5".
+// String kosong dikembalikan jika slug tidak bernomor.
+func EpisodeNumberFromSlug(slug string) string {
+	if match := episodeSlugNumber.FindStringSubmatch(slug); match != nil {
+		if match[2] != "" {
+			return match[1] + "." + match[2]
+		}
+		return match[1]
+	}
+	if match := trailingSlugNumber.FindStringSubmatch(slug); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// NewEpisodeLink membuat link navigasi dari URL halaman episode. Judul kosong diganti judul dari slug.
+func NewEpisodeLink(episodeURL, episode, title string) *EpisodeLink {
+	if episodeURL == "" {
+		return nil
+	}
+	slug := GetSlugFromURL(episodeURL)
+	if episode == "" {
+		episode = EpisodeNumberFromSlug(slug)
+	}
+	return &EpisodeLink{
+		Episode:     episode,
+		EpisodeSlug: slug,
+		Title:       FillStrIfEmpty(strings.TrimSpace(title), SlugToTitle(slug)),
+		URL:         episodeURL,
+	}
+}
+
+// parseEpisodeNavigation membaca tombol navigasi episode berdasarkan struktur halaman, bukan teks tombol,
+// sehingga label terjemahan seperti "Sebelumnya" atau "Selanjutnya" tetap terbaca:
+//
+//   - .naveps .nvs: kotak sebelum .nvsc adalah episode sebelumnya, .nvsc daftar episode, sesudahnya episode berikutnya
+//   - a[rel=prev] dan a[rel=next] di mana pun sebagai cadangan
+//
+// Tombol yang nonaktif (tanpa href) diabaikan.
+func parseEpisodeNavigation(doc *goquery.Selection, absolute func(string) string) EpisodeNavigation {
+	var nav EpisodeNavigation
+	href := func(s *goquery.Selection) string {
+		value := strings.TrimSpace(s.AttrOr("href", ""))
+		if value == "" || strings.HasPrefix(value, "#") || strings.HasPrefix(strings.ToLower(value), "javascript:") {
+			return ""
+		}
+		return absolute(value)
+	}
+
+	doc.Find(".naveps").First().Each(func(_ int, box *goquery.Selection) {
+		afterAll := false
+		box.Find(".nvs").Each(func(_ int, cell *goquery.Selection) {
+			link := href(cell.Find("a[href]").First())
+			switch {
+			case cell.HasClass("nvsc"):
+				nav.AllEpisodesURL = link
+				afterAll = true
+			case link == "":
+			case afterAll:
+				nav.NextEpisodeURL = link
+			default:
+				nav.PreviousEpisodeURL = link
+			}
+		})
+	})
+
+	if nav.PreviousEpisodeURL == "" {
+		nav.PreviousEpisodeURL = href(doc.Find(`a[rel~="prev"]`).First())
+	}
+	if nav.NextEpisodeURL == "" {
+		nav.NextEpisodeURL = href(doc.Find(`a[rel~="next"]`).First())
+	}
+	return nav
+}
+
+// completeEpisodeNavigation mengisi objek previous/next dan anime_slug. Jika tombol navigasi tidak ada,
+// tetangga diambil dari daftar episode di halaman yang sama. Daftar itu biasanya dimulai dari episode
+// terbaru; arahnya ditentukan dari nomor episode pertama dan terakhir jika keduanya ada.
+func completeEpisodeNavigation(nav EpisodeNavigation, currentURL string, episodes []OtherEpisode) EpisodeNavigation {
+	currentSlug := GetSlugFromURL(currentURL)
+	index := -1
+	for i, ep := range episodes {
+		if GetSlugFromURL(ep.URL) == currentSlug {
+			index = i
+			break
+		}
+	}
+
+	if index >= 0 {
+		newestFirst := true
+		if len(episodes) > 1 {
+			first, okFirst := otherEpisodeNumber(episodes[0])
+			last, okLast := otherEpisodeNumber(episodes[len(episodes)-1])
+			if okFirst && okLast {
+				newestFirst = first > last
+			}
+		}
+		prev, next := index+1, index-1
+		if !newestFirst {
+			prev, next = index-1, index+1
+		}
+		if nav.PreviousEpisodeURL == "" && prev >= 0 && prev < len(episodes) {
+			nav.PreviousEpisodeURL = episodes[prev].URL
+		}
+		if nav.NextEpisodeURL == "" && next >= 0 && next < len(episodes) {
+			nav.NextEpisodeURL = episodes[next].URL
+		}
+	}
+
+	nav.Previous = episodeLinkFromList(nav.PreviousEpisodeURL, episodes)
+	nav.Next = episodeLinkFromList(nav.NextEpisodeURL, episodes)
+
+	switch {
+	case strings.Contains(nav.AllEpisodesURL, "/anime/"):
+		nav.AnimeSlug = GetSlugFromURL(nav.AllEpisodesURL)
+	case currentSlug != "":
+		if animeSlug, ok := SanitizeEpisodeSlug(currentSlug); ok {
+			nav.AnimeSlug = animeSlug
+		}
+	}
+	if nav.AllEpisodesURL == "" && nav.AnimeSlug != "" {
+		nav.AllEpisodesURL = AnimeDetailURL(nav.AnimeSlug)
+	}
+	return nav
+}
+
+// episodeLinkFromList membuat link navigasi, memakai nomor dan judul dari daftar episode jika URL-nya ada di sana.
+func episodeLinkFromList(episodeURL string, episodes []OtherEpisode) *EpisodeLink {
+	if episodeURL == "" {
+		return nil
+	}
+	slug := GetSlugFromURL(episodeURL)
+	for _, ep := range episodes {
+		if GetSlugFromURL(ep.URL) == slug {
+			return NewEpisodeLink(episodeURL, episodeTextNumber.FindString(ep.Episode), ep.Title)
+		}
+	}
+	return NewEpisodeLink(episodeURL, "", "")
+}
+
+// otherEpisodeNumber mengambil nomor episode dari label daftar episode, atau dari slug URL-nya.
+func otherEpisodeNumber(ep OtherEpisode) (float64, bool) {
+	raw := episodeTextNumber.FindString(ep.Episode)
+	if raw == "" {
+		raw = EpisodeNumberFromSlug(GetSlugFromURL(ep.URL))
+	}
+	n, err := strconv.ParseFloat(raw, 64)
+	return n, err == nil
+}
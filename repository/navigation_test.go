@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func parseNavigationHTML(t *testing.T, html string) EpisodeNavigation {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parseEpisodeNavigation(doc.Selection, func(href string) string {
+		if strings.HasPrefix(href, "/") {
+			return "https://gomunime.co" + href
+		}
+		return href
+	})
+}
+
+func TestEpisodeNumberFromSlug(t *testing.T) {
+	cases := map[string]string{
+		"naruto-episode-12":          "12",
+		"one-piece-ep-1071-5":        "1071.5",
+		"bleach-eps-3-subtitle-indo": "3",
+		"kimetsu-no-yaiba-26":        "26",
+		"haikyuu-gomisuteba-movie":   "",
+	}
+	for slug, want := range cases {
+		if got := EpisodeNumberFromSlug(slug); got != want {
+			t.Errorf("%s: didapat %q, seharusnya %q", slug, got, want)
+		}
+	}
+}
+
+func TestParseEpisodeNavigationIgnoresLabels(t *testing.T) {
+	useMirrorConfig(t, "https://gomunime.co")
+
+	nav := parseNavigationHTML(t, `<div class="naveps bignav">
+<div class="nvs"><a href="/naruto-episode-1/"><span>Sebelumnya</span></a></div>
+<div class="nvs nvsc"><a href="/anime/naruto/"><span>Semua Episode</span></a></div>
+<div class="nvs"><a href="/naruto-episode-3/"><span>Selanjutnya</span></a></div></div>
+<a href="/lain/">prev</a>`)
+	nav = completeEpisodeNavigation(nav, "https://gomunime.co/naruto-episode-2/", nil)
+
+	if nav.Previous == nil || nav.Previous.Episode != "1" || nav.Previous.EpisodeSlug != "naruto-episode-1" || nav.Previous.Title != "Naruto Episode 1" {
+		t.Errorf("episode sebelumnya tidak sesuai: %+v", nav.Previous)
+	}
+	if nav.Next == nil || nav.Next.URL != "https://gomunime.co/naruto-episode-3/" || nav.NextEpisodeURL != nav.Next.URL {
+		t.Errorf("episode berikutnya tidak sesuai: %+v", nav.Next)
+	}
+	if nav.AnimeSlug != "naruto" || nav.AllEpisodesURL != "https://gomunime.co/anime/naruto/" {
+		t.Errorf("anime induk tidak sesuai: %s %s", nav.AnimeSlug, nav.AllEpisodesURL)
+	}
+}
+
+func TestParseEpisodeNavigationDisabledButtonsAndRel(t *testing.T) {
+	nav := parseNavigationHTML(t, `<div class="naveps"><div class="nvs"><span class="nolink">Prev</span></div>
+<div class="nvs nvsc"><a href="/anime/naruto/">All</a></div><div class="nvs"><a href="/naruto-episode-2/">Next</a></div></div>`)
+	if nav.PreviousEpisodeURL != "" || nav.NextEpisodeURL != "https://gomunime.co/naruto-episode-2/" {
+		t.Errorf("tombol nonaktif seharusnya diabaikan: %+v", nav)
+	}
+
+	nav = parseNavigationHTML(t, `<div class="entry-content"><a rel="prev" href="/naruto-episode-4/">←</a><a rel="next" href="/naruto-episode-6/">→</a></div>`)
+	if nav.PreviousEpisodeURL != "https://gomunime.co/naruto-episode-4/" || nav.NextEpisodeURL != "https://gomunime.co/naruto-episode-6/" {
+		t.Errorf("a[rel] seharusnya dipakai sebagai cadangan: %+v", nav)
+	}
+}
+
+func TestCompleteEpisodeNavigationFromEpisodeList(t *testing.T) {
+	useMirrorConfig(t, "https://gomunime.co")
+	newestFirst := []OtherEpisode{
+		{Episode: "10", Title: "Naruto Episode 10", URL: "https://gomunime.co/naruto-episode-10/"},
+		{Episode: "9", Title: "Naruto Episode 9", URL: "https://gomunime.co/naruto-episode-9/"},
+		{Episode: "8", Title: "Naruto Episode 8", URL: "https://gomunime.co/naruto-episode-8/"},
+	}
+
+	nav := completeEpisodeNavigation(EpisodeNavigation{}, "https://gomunime.co/naruto-episode-9/", newestFirst)
+	if nav.Previous == nil || nav.Previous.Episode != "8" || nav.Next == nil || nav.Next.Episode != "10" {
+		t.Fatalf("tetangga dari daftar episode (terbaru dulu) tidak sesuai: %+v %+v", nav.Previous, nav.Next)
+	}
+	if nav.AnimeSlug != "naruto" || nav.AllEpisodesURL != "https://gomunime.co/anime/naruto/" {
+		t.Errorf("anime induk seharusnya diambil dari slug episode: %s %s", nav.AnimeSlug, nav.AllEpisodesURL)
+	}
+
+	oldestFirst := []OtherEpisode{newestFirst[2], newestFirst[1], newestFirst[0]}
+	nav = completeEpisodeNavigation(EpisodeNavigation{}, "https://gomunime.co/naruto-episode-10/", oldestFirst)
+	if nav.Previous == nil || nav.Previous.Episode != "9" || nav.Next != nil {
+		t.Errorf("daftar urut lama ke baru seharusnya dikenali, didapat %+v %+v", nav.Previous, nav.Next)
+	}
+
+	// Tombol dari halaman tetap diutamakan, tapi judul dan nomornya diambil dari daftar
+	nav = completeEpisodeNavigation(EpisodeNavigation{NextEpisodeURL: "https://gomunime.co/naruto-episode-10/"}, "https://gomunime.co/naruto-episode-8/", newestFirst)
+	if nav.Next == nil || nav.Next.Title != "Naruto Episode 10" || nav.Previous != nil {
+		t.Errorf("tombol halaman seharusnya diutamakan: %+v %+v", nav.Previous, nav.Next)
+	}
+}
+
+func TestScrapeEpisodeDetailNavigation(t *testing.T) {
+	server := episodeStubServer(t, `<div class="naveps"><div class="nvs"><a href="/naruto-episode-0/">Prev</a></div>
+<div class="nvs nvsc"><a href="/anime/naruto/">Semua</a></div><div class="nvs"><span class="nolink">Next</span></div></div>`)
+	useStubSource(t, server.URL)
+
+	nav := ScrapeEpisodeDetail(server.URL + "/naruto-episode-1/").Navigation
+	if nav.AnimeSlug != "naruto" || nav.Previous == nil || nav.Previous.URL != server.URL+"/naruto-episode-0/" || nav.Next != nil {
+		t.Errorf("navigasi hasil scrape tidak sesuai: %+v", nav)
+	}
+}
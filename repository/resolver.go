@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoResolver dikembalikan jika host embed belum punya resolver.
+var ErrNoResolver = errors.New("host embed belum didukung")
+
+// Tipe MIME media yang dikembalikan resolver.
+const (
+	MimeMP4  = "video/mp4"
+	MimeHLS  = "application/vnd.apple.mpegurl"
+	MimeWebM = "video/webm"
+)
+
+// MediaSource adalah satu URL media yang bisa langsung diputar player (mp4 atau HLS).
+type MediaSource struct {
+	URL      string `json:"url" example:"https://pixeldrain.com/api/file/Ra5A3rtj"`
+	Quality  string `json:"quality,omitempty" example:"1080p"`
+	MimeType string `json:"mime_type" example:"video/mp4"`
+	// ExpiresAt kosong berarti URL tidak punya masa berlaku yang diketahui.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// Headers berisi header yang wajib dikirim player, misalnya Referer untuk host yang memeriksanya.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// Resolver mengubah URL embed dari satu host menjadi URL media langsung.
+type Resolver interface {
+	// Name adalah nama resolver yang ditampilkan di response, misalnya "pixeldrain".
+	Name() string
+	// Match mengecek apakah URL embed ditangani resolver ini.
+	Match(embed *url.URL) bool
+	// Resolve mengambil URL media langsung. client hanya mengikuti redirect di host yang sama.
+	Resolve(ctx context.Context, client *http.Client, embed *url.URL) ([]MediaSource, error)
+}
+
+type resolvedEntry struct {
+	resolver string
+	sources  []MediaSource
+	until    time.Time
+}
+
+// ResolverRegistry memilih resolver berdasarkan host embed dan menyimpan hasilnya sementara
+// sampai URL media mendekati masa berlakunya.
+type ResolverRegistry struct {
+	client     *http.Client
+	cacheTTL   time.Duration
+	cacheLimit int
+
+	mu        sync.RWMutex
+	resolvers []Resolver
+	cache     map[string]resolvedEntry
+}
+
+// NewResolverRegistry membuat registry dengan resolver yang dicoba berurutan; yang pertama cocok dipakai.
+func NewResolverRegistry(resolvers ...Resolver) *ResolverRegistry {
+	return &ResolverRegistry{
+		client: &http.Client{
+			Timeout: 15 * time.Second,
+			// Resolver hanya boleh berbicara dengan host embed-nya sendiri
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= 5 {
+					return errors.New("terlalu banyak redirect")
+				}
+				if !matchHost(req.URL.Host, via[0].URL.Host) {
+					return fmt.Errorf("redirect ke host lain (%s) tidak diikuti", req.URL.Host)
+				}
+				return nil
+			},
+		},
+		cacheTTL:   10 * time.Minute,
+		cacheLimit: 1000,
+		resolvers:  resolvers,
+		cache:      make(map[string]resolvedEntry),
+	}
+}
+
+// DefaultResolvers adalah registry yang dipakai API untuk semua host embed yang dikenal.
+var DefaultResolvers = NewResolverRegistry(
+	NewPixeldrainResolver(),
+	NewBloggerResolver(),
+	NewMp4uploadResolver(),
+	NewKrakenfilesResolver(),
+	DirectResolver{},
+)
+
+// Register menambahkan resolver dengan prioritas di atas resolver yang sudah ada.
+func (r *ResolverRegistry) Register(resolver Resolver) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.resolvers = append([]Resolver{resolver}, r.resolvers...)
+}
+
+// Names mengembalikan nama semua resolver sesuai urutan prioritas.
+func (r *ResolverRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.resolvers))
+	for _, resolver := range r.resolvers {
+		names = append(names, resolver.Name())
+	}
+	return names
+}
+
+func (r *ResolverRegistry) find(embed *url.URL) Resolver {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, resolver := range r.resolvers {
+		if resolver.Match(embed) {
+			return resolver
+		}
+	}
+	return nil
+}
+
+// Resolve mengubah URL embed menjadi URL media langsung beserta nama resolver yang dipakai.
+// Host yang belum didukung menghasilkan ErrNoResolver.
+func (r *ResolverRegistry) Resolve(ctx context.Context, embedURL string) (string, []MediaSource, error) {
+	embed, err := url.Parse(strings.TrimSpace(embedURL))
+	if err != nil || (embed.Scheme != "http" && embed.Scheme != "https") || embed.Host == "" {
+		return "", nil, fmt.Errorf("URL embed tidak valid: %q", embedURL)
+	}
+
+	now := time.Now()
+	r.mu.RLock()
+	entry, ok := r.cache[embed.String()]
+	r.mu.RUnlock()
+	if ok && now.Before(entry.until) {
+		return entry.resolver, entry.sources, nil
+	}
+
+	resolver := r.find(embed)
+	if resolver == nil {
+		return "", nil, fmt.Errorf("%w: %s", ErrNoResolver, embed.Hostname())
+	}
+	sources, err := resolver.Resolve(ctx, r.client, embed)
+	if err != nil {
+		return resolver.Name(), nil, fmt.Errorf("%s: %w", resolver.Name(), err)
+	}
+	if len(sources) == 0 {
+		return resolver.Name(), nil, fmt.Errorf("%s: URL media tidak ditemukan", resolver.Name())
+	}
+
+	// Simpan sampai satu menit sebelum URL media pertama kedaluwarsa
+	until := now.Add(r.cacheTTL)
+	for _, source := range sources {
+		if source.ExpiresAt != nil && source.ExpiresAt.Add(-time.Minute).Before(until) {
+			until = source.ExpiresAt.Add(-time.Minute)
+		}
+	}
+	r.mu.Lock()
+	r.pruneCache(now)
+	r.cache[embed.String()] = resolvedEntry{resolver: resolver.Name(), sources: sources, until: until}
+	r.mu.Unlock()
+	return resolver.Name(), sources, nil
+}
+
+// pruneCache membuang entri yang kedaluwarsa, lalu entri yang paling cepat kedaluwarsa
+// sampai masih ada ruang untuk satu entri baru di bawah cacheLimit. mu harus dipegang pemanggil.
+func (r *ResolverRegistry) pruneCache(now time.Time) {
+	for key, old := range r.cache {
+		if now.After(old.until) {
+			delete(r.cache, key)
+		}
+	}
+	if r.cacheLimit <= 0 {
+		return
+	}
+	for len(r.cache) >= r.cacheLimit {
+		oldestKey, oldest := "", time.Time{}
+		for key, entry := range r.cache {
+			if oldestKey == "" || entry.until.Before(oldest) {
+				oldestKey, oldest = key, entry.until
+			}
+		}
+		delete(r.cache, oldestKey)
+	}
+}
+
+// ResolveServers mengembalikan salinan daftar server dengan URL media langsung terisi, paling banyak
+// empat server di-resolve bersamaan. Server yang gagal tetap dikembalikan dengan ResolveError,
+// sehingga player masih bisa memakai streaming_url sebagai iframe.
+func (r *ResolverRegistry) ResolveServers(ctx context.Context, servers []StreamingServer) []StreamingServer {
+	resolved := make([]StreamingServer, len(servers))
+	copy(resolved, servers)
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 4)
+	for i := range resolved {
+		wg.Add(1)
+		go func(server *StreamingServer) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			name, sources, err := r.Resolve(ctx, server.StreamingURL)
+			server.Resolver = name
+			if err != nil {
+				server.ResolveError = err.Error()
+				return
+			}
+			fallback := QualityFromText(server.ServerName)
+			server.Sources = make([]MediaSource, len(sources))
+			for j, source := range sources {
+				source.Quality = FillStrIfEmpty(source.Quality, fallback)
+				server.Sources[j] = source
+			}
+		}(&resolved[i])
+	}
+	wg.Wait()
+	return resolved
+}
+
+// --- Helper untuk resolver ---
+
+var qualityPattern = regexp.MustCompile(`(?i)\b(2160|1440|1080|720|480|360|240)p?\b`)
+
+// QualityFromText mengambil kualitas video dari teks seperti "Nakama 1080p" atau "[720] Episode 1.mp4".
+func QualityFromText(text string) string {
+	if match := qualityPattern.FindStringSubmatch(text); match != nil {
+		return match[1] + "p"
+	}
+	return ""
+}
+
+// mimeFromPath menebak tipe MIME media dari ekstensi path URL.
+func mimeFromPath(p string) string {
+	switch strings.ToLower(path.Ext(p)) {
+	case ".m3u8":
+		return MimeHLS
+	case ".webm":
+		return MimeWebM
+	case ".mp4", ".m4v":
+		return MimeMP4
+	}
+	return ""
+}
+
+// matchHost mengecek apakah host sama dengan salah satu hosts menurut sameSourceHost.
+func matchHost(host string, hosts ...string) bool {
+	for _, candidate := range hosts {
+		if sameSourceHost(host, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchEmbed mengambil halaman embed (maksimal 2 MB) dengan User-Agent scraper.
+func fetchEmbed(ctx context.Context, client *http.Client, target string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent())
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return nil, errors.New("file sudah tidak ada di host embed")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("host embed membalas status HTTP %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 2<<20))
+}
@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// --- Pixeldrain ---
+
+var pixeldrainPathPattern = regexp.MustCompile(`^/(?:u|api/file)/([A-Za-z0-9]+)`)
+
+// PixeldrainResolver menangani pixeldrain.com/u/{id} dan /api/file/{id}. File pixeldrain bisa diputar langsung
+// dari /api/file/{id}; nama file dan tipe MIME diambil dari /api/file/{id}/info.
+type PixeldrainResolver struct {
+	Hosts []string
+}
+
+// NewPixeldrainResolver membuat resolver pixeldrain dengan host bawaan.
+func NewPixeldrainResolver() PixeldrainResolver {
+	return PixeldrainResolver{Hosts: []string{"pixeldrain.com", "pixeldra.in"}}
+}
+
+func (p PixeldrainResolver) Name() string { return "pixeldrain" }
+
+func (p PixeldrainResolver) Match(embed *url.URL) bool {
+	return matchHost(embed.Host, p.Hosts...) && pixeldrainPathPattern.MatchString(embed.Path)
+}
+
+func (p PixeldrainResolver) Resolve(ctx context.Context, client *http.Client, embed *url.URL) ([]MediaSource, error) {
+	id := pixeldrainPathPattern.FindStringSubmatch(embed.Path)[1]
+	fileURL := embed.Scheme + "://" + embed.Host + "/api/file/" + id
+
+	raw, err := fetchEmbed(ctx, client, fileURL+"/info", nil)
+	if err != nil {
+		return nil, err
+	}
+	var info struct {
+		Name     string `json:"name"`
+		MimeType string `json:"mime_type"`
+	}
+	if err := json.Unmarshal(raw, &info); err != nil {
+		return nil, fmt.Errorf("info file tidak valid: %w", err)
+	}
+	if info.MimeType != "" && !strings.HasPrefix(info.MimeType, "video/") && info.MimeType != MimeHLS {
+		return nil, fmt.Errorf("file bukan video (%s)", info.MimeType)
+	}
+	return []MediaSource{{
+		URL:      fileURL,
+		Quality:  QualityFromText(info.Name),
+		MimeType: FillStrIfEmpty(info.MimeType, MimeMP4),
+	}}, nil
+}
+
+// --- Blogger ---
+
+var bloggerConfigPattern = regexp.MustCompile(`(?s)VIDEO_CONFIG\s*=\s*(\{.*?\})\s*(?:;|</script>)`)
+
+// bloggerQualities memetakan format_id (itag) Google Video ke kualitas.
+var bloggerQualities = map[int]string{18: "360p", 59: "480p", 22: "720p", 37: "1080p"}
+
+// BloggerResolver menangani blogger.com/video.g?token=...; halaman embed memuat VIDEO_CONFIG berisi
+// URL googlevideo per kualitas yang berlaku sampai parameter expire.
+type BloggerResolver struct {
+	Hosts []string
+}
+
+// NewBloggerResolver membuat resolver Blogger dengan host bawaan.
+func NewBloggerResolver() BloggerResolver {
+	return BloggerResolver{Hosts: []string{"blogger.com", "draft.blogger.com"}}
+}
+
+func (b BloggerResolver) Name() string { return "blogger" }
+
+func (b BloggerResolver) Match(embed *url.URL) bool {
+	return matchHost(embed.Host, b.Hosts...) && embed.Path == "/video.g" && embed.Query().Get("token") != ""
+}
+
+func (b BloggerResolver) Resolve(ctx context.Context, client *http.Client, embed *url.URL) ([]MediaSource, error) {
+	page, err := fetchEmbed(ctx, client, embed.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	match := bloggerConfigPattern.FindSubmatch(page)
+	if match == nil {
+		return nil, errors.New("VIDEO_CONFIG tidak ditemukan")
+	}
+	var config struct {
+		Streams []struct {
+			PlayURL  string `json:"play_url"`
+			FormatID int    `json:"format_id"`
+		} `json:"streams"`
+	}
+	if err := json.Unmarshal(match[1], &config); err != nil {
+		return nil, fmt.Errorf("VIDEO_CONFIG tidak valid: %w", err)
+	}
+
+	var sources []MediaSource
+	for _, stream := range config.Streams {
+		if stream.PlayURL == "" {
+			continue
+		}
+		source := MediaSource{URL: stream.PlayURL, Quality: bloggerQualities[stream.FormatID], MimeType: MimeMP4}
+		if u, err := url.Parse(stream.PlayURL); err == nil {
+			if expire, err := strconv.ParseInt(u.Query().Get("expire"), 10, 64); err == nil {
+				at := time.Unix(expire, 0)
+				source.ExpiresAt = &at
+			}
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+// --- Mp4upload ---
+
+var (
+	mp4uploadPathPattern = regexp.MustCompile(`^/embed-([A-Za-z0-9]+)\.html$`)
+	mp4uploadSrcPattern  = regexp.MustCompile(`player\.src\(\s*\{\s*type:\s*"([^"]+)",\s*src:\s*"([^"]+)"`)
+)
+
+// Mp4uploadResolver menangani mp4upload.com/embed-{id}.html. URL media hanya bisa diputar dengan
+// Referer halaman embed, jadi header itu ikut dikembalikan.
+type Mp4uploadResolver struct {
+	Hosts []string
+}
+
+// NewMp4uploadResolver membuat resolver mp4upload dengan host bawaan.
+func NewMp4uploadResolver() Mp4uploadResolver {
+	return Mp4uploadResolver{Hosts: []string{"mp4upload.com"}}
+}
+
+func (m Mp4uploadResolver) Name() string { return "mp4upload" }
+
+func (m Mp4uploadResolver) Match(embed *url.URL) bool {
+	return matchHost(embed.Host, m.Hosts...) && mp4uploadPathPattern.MatchString(embed.Path)
+}
+
+func (m Mp4uploadResolver) Resolve(ctx context.Context, client *http.Client, embed *url.URL) ([]MediaSource, error) {
+	page, err := fetchEmbed(ctx, client, embed.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	match := mp4uploadSrcPattern.FindSubmatch(page)
+	if match == nil {
+		return nil, errors.New("player.src tidak ditemukan")
+	}
+	return []MediaSource{{
+		URL:      string(match[2]),
+		MimeType: FillStrIfEmpty(string(match[1]), MimeMP4),
+		Headers:  map[string]string{"Referer": embed.Scheme + "://" + embed.Host + "/"},
+	}}, nil
+}
+
+// --- Krakenfiles ---
+
+// KrakenfilesResolver menangani krakenfiles.com/embed-video/{id}, yang memuat tag <video> HTML5 biasa.
+type KrakenfilesResolver struct {
+	Hosts []string
+}
+
+// NewKrakenfilesResolver membuat resolver krakenfiles dengan host bawaan.
+func NewKrakenfilesResolver() KrakenfilesResolver {
+	return KrakenfilesResolver{Hosts: []string{"krakenfiles.com"}}
+}
+
+func (k KrakenfilesResolver) Name() string { return "krakenfiles" }
+
+func (k KrakenfilesResolver) Match(embed *url.URL) bool {
+	return matchHost(embed.Host, k.Hosts...) && strings.HasPrefix(embed.Path, "/embed-video/")
+}
+
+func (k KrakenfilesResolver) Resolve(ctx context.Context, client *http.Client, embed *url.URL) ([]MediaSource, error) {
+	page, err := fetchEmbed(ctx, client, embed.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return html5VideoSources(page, embed)
+}
+
+// html5VideoSources membaca <source> di dalam <video>; src relatif diselesaikan terhadap URL embed.
+func html5VideoSources(page []byte, embed *url.URL) ([]MediaSource, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(page))
+	if err != nil {
+		return nil, err
+	}
+	var sources []MediaSource
+	doc.Find("video source[src], video[src]").Each(func(_ int, s *goquery.Selection) {
+		ref, err := url.Parse(strings.TrimSpace(s.AttrOr("src", "")))
+		if err != nil {
+			return
+		}
+		target := embed.ResolveReference(ref)
+		mime := s.AttrOr("type", "")
+		if mime == "" {
+			mime = FillStrIfEmpty(mimeFromPath(target.Path), MimeMP4)
+		}
+		quality := QualityFromText(s.AttrOr("label", "") + " " + s.AttrOr("res", "") + " " + s.AttrOr("size", ""))
+		sources = append(sources, MediaSource{URL: target.String(), Quality: quality, MimeType: mime})
+	})
+	return sources, nil
+}
+
+// --- Direct ---
+
+// DirectResolver menangani embed yang sudah berupa file media (.mp4, .m3u8, .webm) di host mana pun.
+// Resolver ini tidak melakukan request apa pun.
+type DirectResolver struct{}
+
+func (DirectResolver) Name() string { return "direct" }
+
+func (DirectResolver) Match(embed *url.URL) bool {
+	return mimeFromPath(embed.Path) != ""
+}
+
+func (DirectResolver) Resolve(_ context.Context, _ *http.Client, embed *url.URL) ([]MediaSource, error) {
+	return []MediaSource{{
+		URL:      embed.String(),
+		Quality:  QualityFromText(embed.Path),
+		MimeType: mimeFromPath(embed.Path),
+	}}, nil
+}
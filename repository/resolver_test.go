@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// stubHost menjalankan server uji dan mengembalikan host:port-nya untuk dipasang sebagai host resolver.
+func stubHost(t *testing.T, handler http.HandlerFunc) (string, *httptest.Server) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	u, _ := url.Parse(server.URL)
+	return u.Host, server
+}
+
+func TestPixeldrainResolver(t *testing.T) {
+	host, server := stubHost(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/file/abc123/info":
+			fmt.Fprint(w, `{"id":"abc123","name":"[Gomunime] Naruto - 01 [1080p].mp4","mime_type":"video/mp4"}`)
+		case "/api/file/zip999/info":
+			fmt.Fprint(w, `{"id":"zip999","name":"batch.zip","mime_type":"application/zip"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	registry := NewResolverRegistry(PixeldrainResolver{Hosts: []string{host}})
+
+	name, sources, err := registry.Resolve(context.Background(), server.URL+"/u/abc123")
+	if err != nil {
+		t.Fatalf("resolve gagal: %v", err)
+	}
+	want := MediaSource{URL: server.URL + "/api/file/abc123", Quality: "1080p", MimeType: MimeMP4}
+	if name != "pixeldrain" || len(sources) != 1 || sources[0].URL != want.URL || sources[0].Quality != want.Quality || sources[0].MimeType != want.MimeType {
+		t.Errorf("didapat %s %+v, seharusnya %+v", name, sources, want)
+	}
+
+	if _, _, err := registry.Resolve(context.Background(), server.URL+"/api/file/zip999"); err == nil || !strings.Contains(err.Error(), "bukan video") {
+		t.Errorf("file non-video seharusnya ditolak, didapat %v", err)
+	}
+	if _, _, err := registry.Resolve(context.Background(), server.URL+"/u/gone"); err == nil || !strings.Contains(err.Error(), "sudah tidak ada") {
+		t.Errorf("file yang dihapus seharusnya dilaporkan, didapat %v", err)
+	}
+}
+
+func TestBloggerResolver(t *testing.T) {
+	expire := time.Now().Add(6 * time.Hour).Unix()
+	host, server := stubHost(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/video.g" || r.URL.Query().Get("token") != "tok" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `<html><script>var VIDEO_CONFIG = {"thumbnail":"x","streams":[`+
+			`{"play_url":"https://rr1.googlevideo.com/videoplayback?expire=%d&itag=22","format_id":22},`+
+			`{"play_url":"https://rr1.googlevideo.com/videoplayback?expire=%d&itag=18","format_id":18}]}</script></html>`, expire, expire)
+	})
+	registry := NewResolverRegistry(BloggerResolver{Hosts: []string{host}})
+
+	_, sources, err := registry.Resolve(context.Background(), server.URL+"/video.g?token=tok")
+	if err != nil {
+		t.Fatalf("resolve gagal: %v", err)
+	}
+	if len(sources) != 2 || sources[0].Quality != "720p" || sources[1].Quality != "360p" {
+		t.Fatalf("kualitas dari format_id tidak sesuai: %+v", sources)
+	}
+	if sources[0].ExpiresAt == nil || sources[0].ExpiresAt.Unix() != expire {
+		t.Errorf("masa berlaku seharusnya diambil dari parameter expire, didapat %v", sources[0].ExpiresAt)
+	}
+	if (BloggerResolver{Hosts: []string{host}}).Match(&url.URL{Host: host, Path: "/video.g"}) {
+		t.Error("URL Blogger tanpa token tidak boleh cocok")
+	}
+}
+
+func TestMp4uploadResolver(t *testing.T) {
+	host, server := stubHost(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/embed-x1y2z3.html" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `<script>player.src({ type: "video/mp4", src: "https://a4.mp4upload.com:183/d/abc/video.mp4" });</script>`)
+	})
+	registry := NewResolverRegistry(Mp4uploadResolver{Hosts: []string{host}})
+
+	_, sources, err := registry.Resolve(context.Background(), server.URL+"/embed-x1y2z3.html")
+	if err != nil {
+		t.Fatalf("resolve gagal: %v", err)
+	}
+	if len(sources) != 1 || sources[0].URL != "https://a4.mp4upload.com:183/d/abc/video.mp4" || sources[0].MimeType != MimeMP4 {
+		t.Fatalf("URL media tidak sesuai: %+v", sources)
+	}
+	if sources[0].Headers["Referer"] != server.URL+"/" {
+		t.Errorf("Referer halaman embed seharusnya ikut dikembalikan, didapat %v", sources[0].Headers)
+	}
+}
+
+func TestKrakenfilesResolver(t *testing.T) {
+	host, server := stubHost(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/embed-video/k9" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `<video id="my-video"><source src="/play/video/k9hash" type="video/mp4" label="720p"></video>`)
+	})
+	registry := NewResolverRegistry(KrakenfilesResolver{Hosts: []string{host}})
+
+	_, sources, err := registry.Resolve(context.Background(), server.URL+"/embed-video/k9")
+	if err != nil {
+		t.Fatalf("resolve gagal: %v", err)
+	}
+	if len(sources) != 1 || sources[0].URL != server.URL+"/play/video/k9hash" || sources[0].Quality != "720p" {
+		t.Errorf("src relatif seharusnya diselesaikan terhadap URL embed: %+v", sources)
+	}
+}
+
+func TestResolverRegistryUnknownAndDirect(t *testing.T) {
+	registry := NewResolverRegistry(NewPixeldrainResolver(), DirectResolver{})
+
+	if _, _, err := registry.Resolve(context.Background(), "https://unknown-host.example/embed/1"); !errors.Is(err, ErrNoResolver) {
+		t.Errorf("host tak dikenal seharusnya ErrNoResolver, didapat %v", err)
+	}
+	if _, _, err := registry.Resolve(context.Background(), "javascript:alert(1)"); err == nil {
+		t.Error("URL bukan http/https seharusnya ditolak")
+	}
+
+	name, sources, err := registry.Resolve(context.Background(), "https://cdn.example/hls/ep1-480p/index.m3u8")
+	if err != nil || name != "direct" || sources[0].MimeType != MimeHLS || sources[0].Quality != "480p" {
+		t.Errorf("URL HLS langsung seharusnya dikenali, didapat %s %+v %v", name, sources, err)
+	}
+}
+
+func TestResolveServersKeepsFailuresAndCaches(t *testing.T) {
+	var hits int32
+	host, server := stubHost(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		fmt.Fprint(w, `{"name":"episode.mp4","mime_type":"video/mp4"}`)
+	})
+	registry := NewResolverRegistry(PixeldrainResolver{Hosts: []string{host}})
+
+	servers := []StreamingServer{
+		{ServerName: "Nakama 1080p", StreamingURL: server.URL + "/u/abc"},
+		{ServerName: "Lain 720p", StreamingURL: "https://unknown-host.example/e/1"},
+	}
+	resolved := registry.ResolveServers(context.Background(), servers)
+	if resolved[0].Resolver != "pixeldrain" || len(resolved[0].Sources) != 1 || resolved[0].Sources[0].Quality != "1080p" {
+		t.Errorf("kualitas seharusnya diambil dari nama server jika resolver tidak tahu: %+v", resolved[0])
+	}
+	if resolved[1].ResolveError == "" || resolved[1].StreamingURL != servers[1].StreamingURL {
+		t.Errorf("server gagal seharusnya tetap ada dengan resolve_error: %+v", resolved[1])
+	}
+	if servers[0].Sources != nil {
+		t.Error("daftar server asli tidak boleh diubah")
+	}
+
+	registry.ResolveServers(context.Background(), servers)
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Errorf("hasil resolve seharusnya di-cache, didapat %d request", got)
+	}
+}
+
+func TestResolverDoesNotFollowRedirectToOtherHost(t *testing.T) {
+	var otherHits int32
+	_, other := stubHost(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&otherHits, 1)
+		fmt.Fprint(w, `{"name":"x.mp4","mime_type":"video/mp4"}`)
+	})
+	host, server := stubHost(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, other.URL+r.URL.Path, http.StatusFound)
+	})
+	registry := NewResolverRegistry(PixeldrainResolver{Hosts: []string{host}})
+
+	if _, _, err := registry.Resolve(context.Background(), server.URL+"/u/abc"); err == nil {
+		t.Error("redirect ke host lain seharusnya gagal")
+	}
+	if atomic.LoadInt32(&otherHits) != 0 {
+		t.Error("host lain tidak boleh dihubungi")
+	}
+}
+
+func TestResolverCacheIsBounded(t *testing.T) {
+	registry := NewResolverRegistry(DirectResolver{})
+	registry.cacheLimit = 3
+
+	for i := 0; i < 10; i++ {
+		if _, _, err := registry.Resolve(context.Background(), fmt.Sprintf("https://cdn.example/ep%d.mp4", i)); err != nil {
+			t.Fatalf("resolve gagal: %v", err)
+		}
+	}
+	registry.mu.RLock()
+	size := len(registry.cache)
+	_, newest := registry.cache["https://cdn.example/ep9.mp4"]
+	registry.mu.RUnlock()
+	if size != 3 || !newest {
+		t.Errorf("cache seharusnya dibatasi 3 entri dan menyimpan yang terbaru, didapat %d (terbaru ada: %v)", size, newest)
+	}
+}
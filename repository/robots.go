@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/temoto/robotstxt"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultRobotsRefreshInterval = 6 * time.Hour
+	robotsRetryInterval          = 5 * time.Minute
+	robotsFetchTimeout           = 10 * time.Second
+)
+
+// RobotsDisallowedError dikembalikan ketika robots.txt situs sumber melarang path yang diminta.
+type RobotsDisallowedError struct {
+	URL  string
+	Host string
+	Path string
+}
+
+func (e *RobotsDisallowedError) Error() string {
+	return fmt.Sprintf("robots.txt %s melarang akses ke %s", e.Host, e.Path)
+}
+
+type robotsEntry struct {
+	data      *robotstxt.RobotsData
+	fetchedAt time.Time
+	ttl       time.Duration
+}
+
+// robotsManager menyimpan robots.txt per host beserta override dari admin.
+type robotsManager struct {
+	mu              sync.Mutex
+	entries         map[string]*robotsEntry
+	overrides       []string
+	refreshInterval time.Duration
+	retryInterval   time.Duration
+	client          *http.Client
+	// inflight menggabungkan fetch robots.txt bersamaan untuk host yang sama.
+	inflight singleflight.Group
+}
+
+var robots = &robotsManager{
+	entries:         make(map[string]*robotsEntry),
+	refreshInterval: defaultRobotsRefreshInterval,
+	retryInterval:   robotsRetryInterval,
+	client:          &http.Client{Timeout: robotsFetchTimeout},
+}
+
+// SetRobotsOverrides mengatur pola path yang boleh diakses walaupun dilarang robots.txt.
+// Pola berakhiran "*" dicocokkan sebagai prefix, selain itu memakai path.Match.
+func SetRobotsOverrides(patterns []string) {
+	robots.mu.Lock()
+	defer robots.mu.Unlock()
+
+	robots.overrides = robots.overrides[:0]
+	for _, p := range patterns {
+		if p = strings.TrimSpace(p); p != "" {
+			robots.overrides = append(robots.overrides, p)
+		}
+	}
+}
+
+// SetRobotsRefreshInterval mengatur seberapa lama robots.txt disimpan sebelum diambil ulang.
+func SetRobotsRefreshInterval(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	robots.mu.Lock()
+	robots.refreshInterval = d
+	robots.mu.Unlock()
+}
+
+// CheckRobots memeriksa apakah URL boleh di-scrape menurut robots.txt host-nya.
+// Query ikut dicocokkan, sehingga aturan seperti "Disallow: /?s=" berlaku untuk halaman pencarian.
+// Mengembalikan *RobotsDisallowedError jika path dilarang dan tidak ada override.
+func CheckRobots(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return nil
+	}
+
+	target := u.EscapedPath()
+	if target == "" {
+		target = "/"
+	}
+	if u.RawQuery != "" {
+		target += "?" + u.RawQuery
+	}
+	if robots.isOverridden(target) {
+		return nil
+	}
+
+	data := robots.get(u)
+	if data == nil || data.TestAgent(target, userAgent()) {
+		return nil
+	}
+	return &RobotsDisallowedError{URL: rawURL, Host: u.Host, Path: target}
+}
+
+// CrawlDelay mengembalikan Crawl-delay dari robots.txt untuk host URL tersebut.
+func CrawlDelay(rawURL string) time.Duration {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return 0
+	}
+	data := robots.get(u)
+	if data == nil {
+		return 0
+	}
+	if group := data.FindGroup(userAgent()); group != nil {
+		return group.CrawlDelay
+	}
+	return 0
+}
+
+func (m *robotsManager) isOverridden(target string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, pattern := range m.overrides {
+		if strings.HasSuffix(pattern, "*") {
+			if strings.HasPrefix(target, strings.TrimSuffix(pattern, "*")) {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(pattern, target); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// get mengambil robots.txt dari cache, atau dari host jika sudah kedaluwarsa.
+func (m *robotsManager) get(u *url.URL) *robotstxt.RobotsData {
+	key := u.Scheme + "://" + u.Host
+
+	m.mu.Lock()
+	entry, ok := m.entries[key]
+	m.mu.Unlock()
+	if ok && time.Since(entry.fetchedAt) < entry.ttl {
+		return entry.data
+	}
+
+	result, _, _ := m.inflight.Do(key, func() (interface{}, error) {
+		data, err := m.fetch(key + "/robots.txt")
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if err != nil {
+			log.Printf("Gagal mengambil robots.txt %s: %v", key, err)
+			// Salinan terakhir yang berhasil tetap dipakai, dan fetch diulang setelah retryInterval,
+			// bukan di setiap request.
+			var previous *robotstxt.RobotsData
+			if old, ok := m.entries[key]; ok {
+				previous = old.data
+			}
+			m.entries[key] = &robotsEntry{data: previous, fetchedAt: time.Now(), ttl: m.retryInterval}
+			return previous, nil
+		}
+		m.entries[key] = &robotsEntry{data: data, fetchedAt: time.Now(), ttl: m.refreshInterval}
+		return data, nil
+	})
+	return result.(*robotstxt.RobotsData)
+}
+
+func (m *robotsManager) fetch(robotsURL string) (*robotstxt.RobotsData, error) {
+	req, err := http.NewRequest(http.MethodGet, robotsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent())
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	// robotstxt menganggap 5xx sebagai "disallow all"; gangguan sementara di sumber tidak boleh
+	// memblokir scraping selama refreshInterval, jadi diperlakukan sebagai gagal fetch.
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 512*1024))
+	if err != nil {
+		return nil, err
+	}
+	return robotstxt.FromStatusAndBytes(resp.StatusCode, body)
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCheckRobots(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			fmt.Fprint(w, "User-agent: *\nDisallow: /wp-admin/\nDisallow: /private/\nDisallow: /?s=\nDisallow: /*?page=\nCrawl-delay: 2\n")
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer SetRobotsOverrides(nil)
+
+	if err := CheckRobots(server.URL + "/anime/naruto/"); err != nil {
+		t.Fatalf("path publik seharusnya diizinkan, didapat: %v", err)
+	}
+
+	err := CheckRobots(server.URL + "/wp-admin/admin-ajax.php")
+	var disallowed *RobotsDisallowedError
+	if !errors.As(err, &disallowed) {
+		t.Fatalf("seharusnya RobotsDisallowedError, didapat: %v", err)
+	}
+	if disallowed.Path != "/wp-admin/admin-ajax.php" {
+		t.Errorf("path salah: %s", disallowed.Path)
+	}
+
+	if err := CheckRobots(server.URL + "/?s=naruto"); err == nil {
+		t.Error("query pencarian seharusnya dilarang oleh Disallow: /?s=")
+	}
+	if err := CheckRobots(server.URL + "/anime/?page=2"); err == nil {
+		t.Error("query page seharusnya dilarang oleh Disallow: /*?page=")
+	}
+	if err := CheckRobots(server.URL + "/anime/?order=update"); err != nil {
+		t.Errorf("query lain seharusnya diizinkan, didapat: %v", err)
+	}
+
+	SetRobotsOverrides([]string{"/wp-admin/admin-ajax.php", "/private/*"})
+	if err := CheckRobots(server.URL + "/wp-admin/admin-ajax.php"); err != nil {
+		t.Errorf("override glob seharusnya mengizinkan, didapat: %v", err)
+	}
+	if err := CheckRobots(server.URL + "/private/a/b"); err != nil {
+		t.Errorf("override prefix seharusnya mengizinkan, didapat: %v", err)
+	}
+	if err := CheckRobots(server.URL + "/wp-admin/options.php"); err == nil {
+		t.Error("path di luar override seharusnya tetap dilarang")
+	}
+
+	if delay := CrawlDelay(server.URL + "/"); delay != 2*time.Second {
+		t.Errorf("crawl-delay seharusnya 2s, didapat %v", delay)
+	}
+}
+
+func TestRobotsFetchDeduplicatesConcurrentMisses(t *testing.T) {
+	var fetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			atomic.AddInt32(&fetches, 1)
+			time.Sleep(50 * time.Millisecond)
+			fmt.Fprint(w, "User-agent: *\nDisallow: /private/\n")
+		}
+	}))
+	defer server.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := CheckRobots(server.URL + "/private/x"); err == nil {
+				t.Error("path /private/ seharusnya dilarang")
+			}
+		}()
+	}
+	wg.Wait()
+	if n := atomic.LoadInt32(&fetches); n != 1 {
+		t.Errorf("robots.txt seharusnya diambil sekali untuk cache miss bersamaan, didapat %d", n)
+	}
+}
+
+func TestRobotsServerErrorRetriesSoon(t *testing.T) {
+	var failing atomic.Bool
+	failing.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			if failing.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, "User-agent: *\nDisallow: /private/\n")
+		}
+	}))
+	defer server.Close()
+
+	robots.mu.Lock()
+	robots.retryInterval = 20 * time.Millisecond
+	robots.mu.Unlock()
+	defer func() {
+		robots.mu.Lock()
+		robots.retryInterval = robotsRetryInterval
+		robots.mu.Unlock()
+	}()
+
+	// 503 tidak boleh dianggap "disallow all"
+	if err := CheckRobots(server.URL + "/anime/naruto/"); err != nil {
+		t.Fatalf("robots.txt 503 seharusnya tidak memblokir, didapat: %v", err)
+	}
+
+	failing.Store(false)
+	time.Sleep(30 * time.Millisecond)
+	if err := CheckRobots(server.URL + "/private/x"); err == nil {
+		t.Error("setelah retryInterval robots.txt seharusnya diambil ulang dan /private/ dilarang")
+	}
+
+	// Gangguan berikutnya tetap memakai salinan terakhir yang berhasil
+	failing.Store(true)
+	robots.mu.Lock()
+	robots.entries[server.URL].fetchedAt = time.Time{}
+	robots.mu.Unlock()
+	if err := CheckRobots(server.URL + "/private/x"); err == nil {
+		t.Error("salinan robots.txt terakhir seharusnya tetap dipakai saat sumber 503")
+	}
+}
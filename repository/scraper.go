@@ -1,10 +1,16 @@
 package repository
 
 import (
+	"crypto/sha1"
 	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -13,45 +19,181 @@ import (
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/gocolly/colly/v2"
-)
 
-const (
-	baseURL     = "https://gomunime.co/"
-	ajaxURL     = "https://gomunime.co/wp-admin/admin-ajax.php"
-	scheduleURL = "https://gomunime.co/schedule/"
-	userAgent   = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/115.0.0.0 Safari/537.36"
+	"multiplescrape/config"
 )
 
+// baseURL mengembalikan domain sumber (mirror aktif), selalu diakhiri "/".
+func baseURL() string {
+	return ActiveBaseURL()
+}
+
+func ajaxURL() string {
+	return baseURL() + strings.TrimPrefix(config.Current().Source.AjaxPath, "/")
+}
+
+func scheduleURL() string {
+	return baseURL() + strings.TrimPrefix(config.Current().Source.SchedulePath, "/")
+}
+
+func userAgent() string {
+	return config.Current().Source.UserAgent
+}
+
+// domainGlob membatasi LimitRule colly ke host sumber.
+func domainGlob() string {
+	if u, err := url.Parse(baseURL()); err == nil && u.Host != "" {
+		return "*" + u.Hostname() + "*"
+	}
+	return "*"
+}
+
+// SourceName mengembalikan nama sumber untuk field `source` di response.
+// Jika sedang memakai mirror cadangan, host mirror tersebut yang dikembalikan.
+func SourceName() string {
+	src := config.Current().Source
+	active := ActiveBaseURL()
+	if active == normalizeBaseURL(src.BaseDomain) {
+		return src.Name
+	}
+	if u, err := url.Parse(active); err == nil {
+		return u.Hostname()
+	}
+	return src.Name
+}
+
+// AnimeDetailURL membangun URL halaman detail anime dari slug.
+func AnimeDetailURL(animeSlug string) string {
+	return fmt.Sprintf("%sanime/%s/", baseURL(), animeSlug)
+}
+
+// EpisodeURL membangun URL halaman episode dari slug; halaman episode ada langsung di root domain.
+func EpisodeURL(episodeSlug string) string {
+	return fmt.Sprintf("%s%s/", baseURL(), episodeSlug)
+}
+
+// LatestPageURL membangun URL halaman rilis terbaru untuk nomor halaman tertentu.
+func LatestPageURL(page int) string {
+	if page > 1 {
+		return fmt.Sprintf("%spage/%d/", baseURL(), page)
+	}
+	return baseURL()
+}
+
+// SearchURL membangun URL pencarian situs sumber.
+func SearchURL(query string) string {
+	return fmt.Sprintf("%s?s=%s", baseURL(), url.QueryEscape(query))
+}
+
+// ScheduleURL mengembalikan URL halaman jadwal rilis.
+func ScheduleURL() string {
+	return scheduleURL()
+}
+
+// outboundDelay memilih jeda antar request: minimal, atau Crawl-delay robots.txt jika lebih besar.
+func outboundDelay(minimum time.Duration) time.Duration {
+	if delay := CrawlDelay(baseURL()); delay > minimum {
+		return delay
+	}
+	return minimum
+}
+
+// applySourcePolicies membatalkan request ke luar domain sumber atau yang dilarang robots.txt,
+// dan melaporkan error ke mirror manager agar failover bisa terjadi.
+func applySourcePolicies(c *colly.Collector) {
+	c.OnRequest(func(r *colly.Request) {
+		if err := CheckSourceURL(r.URL.String()); err != nil {
+			log.Printf("Request dibatalkan: %v", err)
+			r.Abort()
+			return
+		}
+		if err := CheckRobots(r.URL.String()); err != nil {
+			log.Printf("Request dibatalkan: %v", err)
+			r.Abort()
+		}
+	})
+	c.OnResponse(func(r *colly.Response) {
+		reportSourceSuccess(r.Request.URL)
+	})
+	c.OnError(func(r *colly.Response, err error) {
+		reportSourceError(r.StatusCode, r.Request.URL)
+	})
+	// Redirect juga harus tetap di domain sumber, jika tidak halaman sumber bisa mengarahkan ke alamat internal
+	c.SetRedirectHandler(func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return errors.New("terlalu banyak redirect")
+		}
+		return CheckSourceURL(req.URL.String())
+	})
+}
+
+// ScrapeOption mengubah perilaku satu pemanggilan scraper.
+type ScrapeOption func(*scrapeOptions)
+
+type scrapeOptions struct {
+	fresh bool
+}
+
+// FreshFetch membuang cache disk colly untuk halaman yang diminta,
+// sehingga halaman diambil ulang dari sumber dan cache-nya diperbarui.
+func FreshFetch() ScrapeOption {
+	return func(o *scrapeOptions) { o.fresh = true }
+}
+
+// cacheFile mengikuti skema nama file cache colly: sha1(url) di subfolder dua karakter pertama.
+func cacheFile(cacheDir, rawURL string) string {
+	sum := sha1.Sum([]byte(rawURL))
+	hash := hex.EncodeToString(sum[:])
+	return filepath.Join(cacheDir, hash[:2], hash)
+}
+
 // Optimized collector configuration
-func createOptimizedCollector(async bool, parallelism int) *colly.Collector {
+func createOptimizedCollector(async bool, parallelism int, opts ...ScrapeOption) *colly.Collector {
+	cfg := config.Current().Scraper
+	var options scrapeOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	var c *colly.Collector
 	if async {
 		c = colly.NewCollector(colly.Async(true))
 		c.Limit(&colly.LimitRule{
-			DomainGlob:  "*gomunime.co*",
+			DomainGlob:  domainGlob(),
 			Parallelism: parallelism,
-			Delay:       100 * time.Millisecond, // Reduced delay
+			Delay:       outboundDelay(cfg.MinDelay.Std()), // Reduced delay
 		})
 	} else {
 		c = colly.NewCollector()
+		if delay := outboundDelay(0); delay > 0 {
+			c.Limit(&colly.LimitRule{DomainGlob: domainGlob(), Delay: delay})
+		}
 	}
+	applySourcePolicies(c)
 
-	c.UserAgent = userAgent
+	c.UserAgent = userAgent()
 	// Set timeout to prevent hanging
-	c.SetRequestTimeout(30 * time.Second)
+	c.SetRequestTimeout(cfg.RequestTimeout.Std())
 
 	// Enable caching for repeated requests
-	c.CacheDir = "./cache"
+	c.CacheDir = cfg.CacheDir
+	if options.fresh && c.CacheDir != "" {
+		c.OnRequest(func(r *colly.Request) {
+			if r.Method == "GET" {
+				os.Remove(cacheFile(c.CacheDir, r.URL.String()))
+			}
+		})
+	}
 
 	return c
 }
 
 // ScrapeLatestAnime mengambil daftar anime yang baru diperbarui dengan optimasi.
-func ScrapeLatestAnime() []ScrapedLatestAnime {
+func ScrapeLatestAnime(opts ...ScrapeOption) []ScrapedLatestAnime {
 	var allAnime []ScrapedLatestAnime
 	var mu sync.Mutex
 
-	c := createOptimizedCollector(true, 12) // Increased parallelism
+	c := createOptimizedCollector(true, config.Current().Scraper.LatestParallelism, opts...)
 
 	// Use channels for better coordination
 	detailCh := make(chan ScrapedLatestAnime, 100)
@@ -116,7 +258,7 @@ func ScrapeLatestAnime() []ScrapedLatestAnime {
 		formData := fmt.Sprintf("action=tooltip_action&id=%s", postID)
 
 		// Make AJAX request with error handling
-		if err := c.Request("POST", ajaxURL, strings.NewReader(formData), ctx, nil); err != nil {
+		if err := c.Request("POST", ajaxURL(), strings.NewReader(formData), ctx, nil); err != nil {
 			log.Printf("Failed AJAX request for ID %s: %v", postID, err)
 		}
 	})
@@ -125,19 +267,21 @@ func ScrapeLatestAnime() []ScrapedLatestAnime {
 		log.Printf("Error scraping latest: %v | URL: %s", err, r.Request.URL)
 	})
 
-	c.Visit(baseURL)
+	c.Visit(baseURL())
 	c.Wait()
 
 	close(detailCh)
 	<-done
 
+	allAnime = rewriteLatestURLs(allAnime)
+	catalogRecordLatest(allAnime)
 	return allAnime
 }
 
 // ScrapeSchedule dengan optimasi minimal karena sudah cukup efisien.
-func ScrapeSchedule() []ScrapedDaySchedule {
+func ScrapeSchedule(opts ...ScrapeOption) []ScrapedDaySchedule {
 	var fullSchedule []ScrapedDaySchedule
-	c := createOptimizedCollector(false, 1)
+	c := createOptimizedCollector(false, 1, opts...)
 
 	c.OnHTML("div.bixbox.schedulepage", func(e *colly.HTMLElement) {
 		day := ScrapedDaySchedule{
@@ -169,17 +313,19 @@ func ScrapeSchedule() []ScrapedDaySchedule {
 		log.Printf("Error scraping schedule: %v | URL: %s", err, r.Request.URL)
 	})
 
-	c.Visit(scheduleURL)
+	c.Visit(scheduleURL())
 	c.Wait()
+	fullSchedule = rewriteScheduleURLs(fullSchedule)
+	catalogRecordSchedule(fullSchedule)
 	return fullSchedule
 }
 
 // ScrapeLatestByPage dengan optimasi parallelism yang lebih baik.
-func ScrapeLatestByPage(page int) []ScrapedLatestAnime {
+func ScrapeLatestByPage(page int, opts ...ScrapeOption) []ScrapedLatestAnime {
 	var allAnime []ScrapedLatestAnime
 	var mu sync.Mutex
 
-	c := createOptimizedCollector(true, 15) // Increased parallelism
+	c := createOptimizedCollector(true, config.Current().Scraper.PageParallelism, opts...)
 
 	// Use buffered channel for better performance
 	resultCh := make(chan ScrapedLatestAnime, 50)
@@ -245,7 +391,7 @@ func ScrapeLatestByPage(page int) []ScrapedLatestAnime {
 		ctx.Put("anime", anime)
 		formData := fmt.Sprintf("action=tooltip_action&id=%s", postID)
 
-		if err := c.Request("POST", ajaxURL, strings.NewReader(formData), ctx, nil); err != nil {
+		if err := c.Request("POST", ajaxURL(), strings.NewReader(formData), ctx, nil); err != nil {
 			log.Printf("Failed AJAX request: %v", err)
 		}
 	})
@@ -254,10 +400,7 @@ func ScrapeLatestByPage(page int) []ScrapedLatestAnime {
 		log.Printf("Error scraping page: %v | URL: %s", err, r.Request.URL)
 	})
 
-	targetURL := baseURL
-	if page > 1 {
-		targetURL = fmt.Sprintf("%spage/%d/", baseURL, page)
-	}
+	targetURL := LatestPageURL(page)
 
 	log.Printf("Visiting page: %s", targetURL)
 	c.Visit(targetURL)
@@ -266,12 +409,14 @@ func ScrapeLatestByPage(page int) []ScrapedLatestAnime {
 	close(resultCh)
 	<-done
 
+	allAnime = rewriteLatestURLs(allAnime)
+	catalogRecordLatest(allAnime)
 	return allAnime
 }
 
 // ScrapeAnimeDetail dengan optimasi selector dan pre-allocation.
-func ScrapeAnimeDetail(animeSlug string) ScrapedAnimeDetails {
-	targetURL := fmt.Sprintf("https://gomunime.co/anime/%s/", animeSlug)
+func ScrapeAnimeDetail(animeSlug string, opts ...ScrapeOption) ScrapedAnimeDetails {
+	targetURL := AnimeDetailURL(animeSlug)
 	animeData := ScrapedAnimeDetails{
 		Details:     make(map[string]string, 10), // Pre-allocate capacity
 		Genre:       make([]string, 0, 10),
@@ -279,7 +424,7 @@ func ScrapeAnimeDetail(animeSlug string) ScrapedAnimeDetails {
 		Rekomendasi: make([]ScrapedRecommendation, 0, 20),
 	}
 
-	c := createOptimizedCollector(false, 1)
+	c := createOptimizedCollector(false, 1, opts...)
 
 	// Main info scraper for article.post-180 structure (detailed anime pages)
 	c.OnHTML("article.post-180", func(e *colly.HTMLElement) {
@@ -309,51 +454,9 @@ func ScrapeAnimeDetail(animeSlug string) ScrapedAnimeDetails {
 			animeData.Genre = append(animeData.Genre, g.Text())
 		})
 
-		// Extract details from .spe table
-		spe := bigContent.Find(".spe")
-		spe.Find("span").Each(func(_ int, s *goquery.Selection) {
-			text := s.Text()
-			switch {
-			case strings.HasPrefix(text, "Status:"):
-				animeData.Details["Status"] = strings.TrimSpace(strings.TrimPrefix(text, "Status:"))
-			case strings.HasPrefix(text, "Studio:"):
-				studioName := s.Find("a").Text()
-				if studioName != "" {
-					animeData.Details["Studio"] = studioName
-				}
-			case strings.HasPrefix(text, "Released:"):
-				animeData.Details["Released:"] = strings.TrimSpace(strings.TrimPrefix(text, "Released:"))
-			case strings.HasPrefix(text, "Duration:"):
-				animeData.Details["Duration"] = strings.TrimSpace(strings.TrimPrefix(text, "Duration:"))
-			case strings.HasPrefix(text, "Season:"):
-				seasonName := s.Find("a").Text()
-				if seasonName != "" {
-					animeData.Details["Season"] = seasonName
-				}
-			case strings.HasPrefix(text, "Type:"):
-				animeData.Details["Type"] = strings.TrimSpace(strings.TrimPrefix(text, "Type:"))
-			case strings.HasPrefix(text, "Episodes:"):
-				animeData.Details["Total Episode"] = strings.TrimSpace(strings.TrimPrefix(text, "Episodes:"))
-			case strings.HasPrefix(text, "Released on:"):
-				timeText := s.Find("time").Text()
-				if timeText != "" {
-					animeData.Details["Released on"] = timeText
-				}
-			case strings.HasPrefix(text, "Updated on:"):
-				timeText := s.Find("time").Text()
-				if timeText != "" {
-					animeData.Details["Updated on"] = timeText
-				}
-			case strings.HasPrefix(text, "Producers:"):
-				var producers []string
-				s.Find("a").Each(func(_ int, p *goquery.Selection) {
-					producers = append(producers, p.Text())
-				})
-				if len(producers) > 0 {
-					animeData.Details["Producers"] = strings.Join(producers, ", ")
-				}
-			}
-		})
+		// Extract alternative titles and details from .spe table
+		parseAlternativeTitles(bigContent.Find("span.alter").First().Text(), animeData.Details)
+		parseAnimeInfo(bigContent.Find(".spe").First(), animeData.Details)
 	})
 
 	// Alternative scraper for different page structure (like tougen-anki)
@@ -513,8 +616,12 @@ func ScrapeAnimeDetail(animeSlug string) ScrapedAnimeDetails {
 		// Extract released year with better regex
 		releasedRegex := regexp.MustCompile(`Released:\s*(\d{4})`)
 		if matches := releasedRegex.FindStringSubmatch(pageText); len(matches) > 1 {
-			animeData.Details["Released:"] = strings.TrimSpace(matches[1])
+			animeData.Details[DetailReleased] = strings.TrimSpace(matches[1])
 		}
+
+		// Tabel .spe dan span.alter tetap diutamakan jika ada di struktur ini
+		parseAlternativeTitles(e.DOM.Find("span.alter").First().Text(), animeData.Details)
+		parseAnimeInfo(e.DOM.Find(".spe").First(), animeData.Details)
 	})
 
 	// Optimized episode list scraper
@@ -532,21 +639,12 @@ func ScrapeAnimeDetail(animeSlug string) ScrapedAnimeDetails {
 
 	// Recommendation scraper using correct selector from test
 	c.OnHTML(`div.bixbox:has(h3 > span:contains("Recommended Series"))`, func(e *colly.HTMLElement) {
-		e.ForEach(".listupd article.bs", func(_ int, el *colly.HTMLElement) {
-			// Handle lazy-loading for thumbnail
-			thumbURL := el.ChildAttr("img", "data-src")
-			if thumbURL == "" {
-				thumbURL = el.ChildAttr("img", "src")
-			}
+		animeData.Rekomendasi = append(animeData.Rekomendasi, scrapeSeriesBox(e)...)
+	})
 
-			rec := ScrapedRecommendation{
-				Judul:     el.ChildAttr("a.tip", "title"),
-				URL:       e.Request.AbsoluteURL(el.ChildAttr("a.tip", "href")),
-				Episode:   el.ChildText("span.epx"),
-				Thumbnail: thumbURL,
-			}
-			animeData.Rekomendasi = append(animeData.Rekomendasi, rec)
-		})
+	// Related series box (season lain, movie, OVA) jika tema situs menampilkannya
+	c.OnHTML(`div.bixbox:has(h3 > span:contains("Related")), div.bixbox:has(h3 > span:contains("Terkait"))`, func(e *colly.HTMLElement) {
+		animeData.Terkait = append(animeData.Terkait, scrapeSeriesBox(e)...)
 	})
 
 	// Post-processing with minimal fallbacks to preserve real data
@@ -573,16 +671,35 @@ func ScrapeAnimeDetail(animeSlug string) ScrapedAnimeDetails {
 	log.Printf("Visiting detail page: %s", targetURL)
 	c.Visit(targetURL)
 	c.Wait()
+	animeData = rewriteAnimeDetailURLs(animeData)
+	catalogRecordAnimeDetail(animeSlug, animeData)
 	return animeData
 }
 
+// scrapeSeriesBox membaca kartu anime (.listupd article.bs) di kotak rekomendasi atau seri terkait.
+func scrapeSeriesBox(e *colly.HTMLElement) []ScrapedRecommendation {
+	var items []ScrapedRecommendation
+	e.ForEach(".listupd article.bs", func(_ int, el *colly.HTMLElement) {
+		// Handle lazy-loading for thumbnail
+		thumbURL := el.ChildAttr("img", "data-src")
+		if thumbURL == "" {
+			thumbURL = el.ChildAttr("img", "src")
+		}
+
+		items = append(items, ScrapedRecommendation{
+			Judul:     el.ChildAttr("a.tip", "title"),
+			URL:       e.Request.AbsoluteURL(el.ChildAttr("a.tip", "href")),
+			Episode:   el.ChildText("span.epx"),
+			Thumbnail: thumbURL,
+		})
+	})
+	return items
+}
+
 // ScrapeEpisodeDetail dengan optimasi dan pre-compiled regex.
-func ScrapeEpisodeDetail(episodeURL string) ScrapedEpisodeDetails {
+func ScrapeEpisodeDetail(episodeURL string, opts ...ScrapeOption) ScrapedEpisodeDetails {
 	// Pre-compile regex for better performance
-	var (
-		srcRegex = regexp.MustCompile(`src="([^"]+)"`)
-		resRegex = regexp.MustCompile(`(\d{3,4}p)`)
-	)
+	srcRegex := regexp.MustCompile(`src="([^"]+)"`)
 
 	data := ScrapedEpisodeDetails{
 		StreamingServers: make([]StreamingServer, 0, 10),
@@ -596,7 +713,7 @@ func ScrapeEpisodeDetail(episodeURL string) ScrapedEpisodeDetails {
 	var mainThumbnail string
 	var seriesTitle string
 
-	c := createOptimizedCollector(false, 1)
+	c := createOptimizedCollector(false, 1, opts...)
 
 	// Optimized anime info scraper
 	c.OnHTML(".bixbox.single-info", func(e *colly.HTMLElement) {
@@ -655,24 +772,10 @@ func ScrapeEpisodeDetail(episodeURL string) ScrapedEpisodeDetails {
 		})
 	})
 
-	// Navigation - Updated selectors based on actual HTML structure
+	// Navigasi episode dan bagian download halaman episode
 	c.OnHTML("body", func(e *colly.HTMLElement) {
-		// Look for navigation links in the page
-		e.ForEach("a", func(_ int, el *colly.HTMLElement) {
-			linkText := strings.ToLower(strings.TrimSpace(el.Text))
-			href := el.Attr("href")
-			
-			if href != "" {
-				switch linkText {
-				case "prev":
-					data.Navigation.PreviousEpisodeURL = e.Request.AbsoluteURL(href)
-				case "all episodes":
-					data.Navigation.AllEpisodesURL = e.Request.AbsoluteURL(href)
-				case "next":
-					data.Navigation.NextEpisodeURL = e.Request.AbsoluteURL(href)
-				}
-			}
-		})
+		data.Navigation = parseEpisodeNavigation(e.DOM, e.Request.AbsoluteURL)
+		data.DownloadLinks = parseDownloadSection(e.DOM, e.Request.AbsoluteURL)
 	})
 
 	// Optimized episode list processing
@@ -696,6 +799,7 @@ func ScrapeEpisodeDetail(episodeURL string) ScrapedEpisodeDetails {
 			}
 
 			data.OtherEpisodes = append(data.OtherEpisodes, OtherEpisode{
+				Episode:      episodeNumber,
 				Title:        episodeTitle,
 				URL:          episodeURL,
 				ReleaseDate:  el.ChildText(".epl-date"),
@@ -729,6 +833,7 @@ func ScrapeEpisodeDetail(episodeURL string) ScrapedEpisodeDetails {
 				}
 
 				data.OtherEpisodes = append(data.OtherEpisodes, OtherEpisode{
+					Episode:      episodeTextNumber.FindString(episodeInfo),
 					Title:        episodeTitle,
 					URL:          episodeURL,
 					ReleaseDate:  "",
@@ -740,20 +845,15 @@ func ScrapeEpisodeDetail(episodeURL string) ScrapedEpisodeDetails {
 
 	// Post-processing optimization
 	c.OnScraped(func(r *colly.Response) {
-		// Generate download links efficiently
-		if len(data.StreamingServers) > 0 && len(data.DownloadLinks) == 0 {
-			downloadMap := make(map[string][]DownloadProvider)
-			for _, server := range data.StreamingServers {
-				resolution := "HD"
-				if matches := resRegex.FindStringSubmatch(strings.ToLower(server.ServerName)); len(matches) > 1 {
-					resolution = matches[1]
-				}
-				downloadMap[resolution] = append(downloadMap[resolution], DownloadProvider{
-					Provider: server.ServerName,
-					URL:      server.StreamingURL,
-				})
-			}
-			data.DownloadLinks["MP4 (from Stream)"] = downloadMap
+		// Link dari server streaming hanya dipakai jika halaman tidak punya bagian download
+		switch {
+		case len(data.DownloadLinks) > 0:
+			data.DownloadSource = DownloadSourcePage
+		case len(data.StreamingServers) > 0:
+			data.DownloadLinks = streamFallbackDownloads(data.StreamingServers)
+			data.DownloadSource = DownloadSourceStream
+		default:
+			data.DownloadSource = DownloadSourceNone
 		}
 
 		// Final title processing
@@ -775,14 +875,19 @@ func ScrapeEpisodeDetail(episodeURL string) ScrapedEpisodeDetails {
 				)
 			}
 		}
+
+		// Objek previous/next, dengan daftar episode sebagai cadangan jika tombol navigasi tidak ada
+		data.Navigation = completeEpisodeNavigation(data.Navigation, r.Request.URL.String(), data.OtherEpisodes)
 	})
 
 	c.OnError(func(r *colly.Response, err error) {
 		log.Printf("Error scraping episode detail: %v | URL: %s", err, r.Request.URL)
 	})
 
-	c.Visit(episodeURL)
+	c.Visit(RewriteURL(episodeURL))
 	c.Wait()
+	data = rewriteEpisodeDetailURLs(data)
+	catalogRecordEpisodeDetail(episodeURL, data)
 	return data
 }
 
@@ -828,14 +933,18 @@ func generateEpisodeTitle(episodeURL, seriesTitle, episodeNumber string) string
 }
 
 func ScrapeSearch(query string) []ScrapedSearchResult {
-	searchURL := fmt.Sprintf("https://gomunime.co/?s=%s", url.QueryEscape(query))
-	ajaxURL := "https://gomunime.co/wp-admin/admin-ajax.php"
+	searchURL := SearchURL(query)
 	var searchResults []ScrapedSearchResult
 	var mu sync.Mutex
 
 	c := colly.NewCollector(colly.Async(true))
-	c.UserAgent = userAgent
-	c.Limit(&colly.LimitRule{DomainGlob: "*gomunime.co*", Parallelism: 4})
+	c.UserAgent = userAgent()
+	c.Limit(&colly.LimitRule{
+		DomainGlob:  domainGlob(),
+		Parallelism: config.Current().Scraper.SearchParallelism,
+		Delay:       outboundDelay(0),
+	})
+	applySourcePolicies(c)
 
 	// Callback untuk memproses detail dari AJAX (info hover)
 	c.OnHTML("div.ingfo", func(e *colly.HTMLElement) {
@@ -886,7 +995,7 @@ func ScrapeSearch(query string) []ScrapedSearchResult {
 		ctx := colly.NewContext()
 		ctx.Put("result", result)
 		payload := fmt.Sprintf("action=tooltip_action&id=%s", postID)
-		c.Request("POST", ajaxURL, strings.NewReader(payload), ctx, nil)
+		c.Request("POST", ajaxURL(), strings.NewReader(payload), ctx, nil)
 	})
 
 	c.OnError(func(r *colly.Response, err error) {
@@ -894,5 +1003,7 @@ func ScrapeSearch(query string) []ScrapedSearchResult {
 	})
 	c.Visit(searchURL)
 	c.Wait()
+	searchResults = rewriteSearchURLs(searchResults)
+	catalogRecordSearch(searchResults)
 	return searchResults
 }
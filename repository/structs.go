@@ -1,5 +1,7 @@
 package repository
 
+import "time"
+
 type SearchResultItem struct {
 	Judul     string   `json:"judul" example:"Naruto Kecil"`
 	URLAnime  string   `json:"url_anime" example:"https://v1.samehadaku.how/anime/naruto-kecil/"`
@@ -17,10 +19,53 @@ type SearchResultItem struct {
 type SearchResponse struct {
 	ConfidenceScore float64            `json:"confidence_score" example:"1.0"`
 	Data            []SearchResultItem `json:"data"`
+	Pagination      Pagination         `json:"pagination"`
 	Message         string             `json:"message"`
 	Source          string             `json:"source"`
 }
 
+// BrowseResponse adalah struct untuk output endpoint /anime.
+type BrowseResponse struct {
+	ConfidenceScore float64            `json:"confidence_score" example:"1.0"`
+	Data            []SearchResultItem `json:"data"`
+	Pagination      BrowsePagination   `json:"pagination"`
+	Message         string             `json:"message"`
+	Source          string             `json:"source"`
+}
+
+// BrowsePagination adalah paginasi halaman arsip situs sumber. Situs sumber tidak memberi tahu
+// jumlah total anime, jadi hanya ada penanda halaman berikutnya dan sebelumnya.
+type BrowsePagination struct {
+	Page        int  `json:"page" example:"1"`
+	HasNextPage bool `json:"has_next_page" example:"true"`
+	HasPrevPage bool `json:"has_prev_page" example:"false"`
+}
+
+// SuggestItem adalah satu saran judul untuk autocomplete.
+type SuggestItem struct {
+	Slug  string `json:"slug" example:"kimetsu-no-yaiba"`
+	Title string `json:"title" example:"Kimetsu no Yaiba"`
+	Cover string `json:"cover" example:"https://v1.samehadaku.how/wp-content/uploads/2024/08/142503.jpg"`
+	Type  string `json:"type" example:"TV"`
+}
+
+// SuggestResponse adalah struct untuk output endpoint /suggest.
+type SuggestResponse struct {
+	Data    []SuggestItem `json:"data"`
+	Message string        `json:"message"`
+	Source  string        `json:"source"`
+}
+
+// Pagination adalah metadata paginasi untuk daftar yang dipotong per halaman.
+type Pagination struct {
+	Page        int  `json:"page" example:"1"`
+	PageSize    int  `json:"page_size" example:"20"`
+	TotalItems  int  `json:"total_items" example:"42"`
+	TotalPages  int  `json:"total_pages" example:"3"`
+	HasNextPage bool `json:"has_next_page" example:"true"`
+	HasPrevPage bool `json:"has_prev_page" example:"false"`
+}
+
 // --- Struct untuk DATA SCRAPER ---
 
 type ScrapedSearchResult struct {
@@ -184,6 +229,13 @@ type AnimeDetailData struct {
 	Genre           []string             `json:"genre" example:"School,Sports"`
 	Details         Details              `json:"details"`
 	Rating          RatingInfo           `json:"rating"`
+	// AlternativeTitles berisi judul Jepang, Inggris, sinonim, dan judul alternatif lain tanpa duplikat.
+	AlternativeTitles []string `json:"alternative_titles" example:"ハイキュー!! ゴミ捨て場の決戦,Haikyu!! The Dumpster Battle"`
+	Director          string   `json:"director" example:"Susumu Mitsunaka"`
+	Casts             []string `json:"casts" example:"Ayumu Murase,Kaito Ishikawa"`
+	// ReleasedOn dan UpdatedOn berformat RFC 3339, atau "2006-01-02" jika situs hanya menampilkan tanggal.
+	ReleasedOn string `json:"released_on,omitempty" example:"2024-10-05T10:00:00+07:00"`
+	UpdatedOn  string `json:"updated_on,omitempty" example:"2024-11-01T08:30:00+07:00"`
 }
 
 // EpisodeListItem merepresentasikan satu episode dalam daftar.
@@ -203,6 +255,9 @@ type RecommendationItem struct {
 	CoverURL  string `json:"cover_url" example:"https://v1.samehadaku.how/wp-content/uploads/2024/07/93473.jpg"`
 	Rating    string `json:"rating" example:"8"`
 	Episode   string `json:"episode" example:"Eps 12"`
+	// Similarity dan Explanation hanya terisi untuk rekomendasi dari katalog lokal.
+	Similarity  float64 `json:"similarity,omitempty" example:"0.72"`
+	Explanation string  `json:"explanation,omitempty" example:"genre sama: Action, Fantasy (67%); studio sama: Madhouse; skor 8.10"`
 }
 
 // Details adalah objek untuk metadata detail.
@@ -218,7 +273,7 @@ type Details struct {
 	Season       string `json:"Season,omitempty"`
 	Studio       string `json:"Studio,omitempty"`
 	Producers    string `json:"Producers,omitempty"`
-	Released     string `json:"Released:,omitempty"`
+	Released     string `json:"Released,omitempty"`
 }
 
 // RatingInfo adalah objek untuk informasi rating.
@@ -251,6 +306,7 @@ type ScrapedAnimeDetails struct {
 	Genre       []string
 	EpisodeList []ScrapedEpisode
 	Rekomendasi []ScrapedRecommendation
+	Terkait     []ScrapedRecommendation // Kotak seri terkait (season lain, movie, OVA), jika ada
 	Details     map[string]string // Menggunakan map untuk fleksibilitas
 }
 
@@ -267,6 +323,8 @@ type EpisodeDetailData struct {
 	StreamingServers []StreamingServer                        `json:"streaming_servers"`
 	ReleaseInfo      string                                   `json:"release_info" example:"9 months yang lalu"`
 	DownloadLinks    map[string]map[string][]DownloadProvider `json:"download_links"`
+	// Asal download_links: page, stream_fallback (diturunkan dari server streaming), atau none
+	DownloadSource   string                                   `json:"download_source" example:"page"`
 	Navigation       EpisodeNavigation                        `json:"navigation"`
 	AnimeInfo        AnimeInfo                                `json:"anime_info"`
 	OtherEpisodes    []OtherEpisode                           `json:"other_episodes"`
@@ -276,6 +334,13 @@ type EpisodeDetailData struct {
 type StreamingServer struct {
 	ServerName   string `json:"server_name" example:"Nakama 1080p"`
 	StreamingURL string `json:"streaming_url" example:"https://pixeldrain.com/api/file/Ra5A3rtj"`
+	// Field berikut hanya terisi jika diminta dengan resolve=true dan tidak pernah disimpan di katalog.
+	Resolver     string        `json:"resolver,omitempty" example:"pixeldrain"`
+	Sources      []MediaSource `json:"sources,omitempty"`
+	ResolveError string        `json:"resolve_error,omitempty"`
+	// Alive dan CheckedAt berasal dari hasil cek link terakhir; kosong jika server belum pernah dicek.
+	Alive     *bool      `json:"alive,omitempty" example:"true"`
+	CheckedAt *time.Time `json:"checked_at,omitempty"`
 }
 
 // DownloadProvider merepresentasikan satu link download dari satu provider.
@@ -286,9 +351,20 @@ type DownloadProvider struct {
 
 // EpisodeNavigation berisi link navigasi antar episode.
 type EpisodeNavigation struct {
-	PreviousEpisodeURL string `json:"previous_episode_url,omitempty"`
-	AllEpisodesURL     string `json:"all_episodes_url,omitempty"`
-	NextEpisodeURL     string `json:"next_episode_url,omitempty"`
+	PreviousEpisodeURL string       `json:"previous_episode_url,omitempty"`
+	AllEpisodesURL     string       `json:"all_episodes_url,omitempty"`
+	NextEpisodeURL     string       `json:"next_episode_url,omitempty"`
+	AnimeSlug          string       `json:"anime_slug,omitempty" example:"naruto"`
+	Previous           *EpisodeLink `json:"previous,omitempty"`
+	Next               *EpisodeLink `json:"next,omitempty"`
+}
+
+// EpisodeLink adalah episode sebelum atau sesudah episode yang sedang dibuka.
+type EpisodeLink struct {
+	Episode     string `json:"episode" example:"2"`
+	EpisodeSlug string `json:"episode_slug" example:"naruto-episode-2"`
+	Title       string `json:"title" example:"Naruto Episode 2"`
+	URL         string `json:"url" example:"https://gomunime.co/naruto-episode-2/"`
 }
 
 // AnimeInfo berisi detail dari seri anime induknya.
@@ -301,6 +377,7 @@ type AnimeInfo struct {
 
 // OtherEpisode merepresentasikan episode lain dari seri yang sama.
 type OtherEpisode struct {
+	Episode      string `json:"episode,omitempty" example:"1"`
 	Title        string `json:"title" example:"Haikyuu Movie: Gomisuteba no Kessen"`
 	URL          string `json:"url" example:"https://v1.samehadaku.how/haikyuu-gomisuteba-no-kessen/"`
 	ThumbnailURL string `json:"thumbnail_url" example:"https://v1.samehadaku.how/wp-content/uploads/2024/10/Haikyu.The_.Dumpster.Battle.jpg"`
@@ -314,6 +391,7 @@ type ScrapedEpisodeDetails struct {
 	ReleaseInfo      string
 	StreamingServers []StreamingServer
 	DownloadLinks    map[string]map[string][]DownloadProvider
+	DownloadSource   string
 	Navigation       EpisodeNavigation
 	AnimeInfo        AnimeInfo
 	OtherEpisodes    []OtherEpisode
@@ -0,0 +1,90 @@
+package search
+
+import (
+	"sync"
+	"time"
+
+	"multiplescrape/repository"
+)
+
+// CatalogIndex menjaga indeks yang dibangun dari katalog dan membangunnya ulang saat katalog berubah,
+// paling sering sekali setiap minInterval agar scrape beruntun tidak memicu rebuild terus-menerus.
+// Rebuild berjalan di background; selama itu request tetap dilayani dari indeks sebelumnya.
+type CatalogIndex struct {
+	minInterval time.Duration
+	// load dan catalogVersion bisa diganti di test; defaultnya membaca katalog repository.
+	load           func() []repository.CatalogAnime
+	catalogVersion func() uint64
+
+	mu         sync.Mutex
+	index      *Index
+	version    uint64
+	builtAt    time.Time
+	rebuilding bool
+}
+
+// Default adalah indeks katalog yang dipakai handler API.
+var Default = NewCatalogIndex(10 * time.Second)
+
+// NewCatalogIndex membuat indeks katalog dengan jeda rebuild minimum.
+func NewCatalogIndex(minInterval time.Duration) *CatalogIndex {
+	return &CatalogIndex{
+		minInterval:    minInterval,
+		load:           loadCatalog,
+		catalogVersion: repository.CatalogVersion,
+	}
+}
+
+func loadCatalog() []repository.CatalogAnime {
+	var docs []repository.CatalogAnime
+	repository.CatalogAllAnime(func(anime repository.CatalogAnime) bool {
+		docs = append(docs, anime)
+		return true
+	})
+	return docs
+}
+
+// Current mengembalikan indeks terbaru. Hanya pemanggilan pertama yang menunggu indeks dibangun;
+// jika katalog sudah berubah, indeks lama dikembalikan dan rebuild dijalankan di background.
+func (c *CatalogIndex) Current() *Index {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	version := c.catalogVersion()
+	if c.index == nil {
+		c.store(Build(c.load()), version)
+	} else if version != c.version && !c.rebuilding && time.Since(c.builtAt) >= c.minInterval {
+		c.rebuilding = true
+		go c.rebuild(version)
+	}
+	return c.index
+}
+
+func (c *CatalogIndex) rebuild(version uint64) {
+	index := Build(c.load())
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.store(index, version)
+	c.rebuilding = false
+}
+
+// store memasang indeks baru; mu harus dipegang pemanggil.
+func (c *CatalogIndex) store(index *Index, version uint64) {
+	c.index = index
+	c.version = version
+	c.builtAt = time.Now()
+}
+
+// Stats mengembalikan jumlah anime di indeks dan waktu terakhir dibangun untuk monitoring.
+func (c *CatalogIndex) Stats() map[string]interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := map[string]interface{}{"documents": 0, "rebuilding": c.rebuilding}
+	if c.index != nil {
+		stats["documents"] = c.index.Len()
+		stats["terms"] = len(c.index.terms)
+		stats["suggest_keys"] = len(c.index.suggest)
+		stats["built_at"] = c.builtAt.Format(time.RFC3339)
+	}
+	return stats
+}
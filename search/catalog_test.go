@@ -0,0 +1,51 @@
+package search
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"multiplescrape/repository"
+)
+
+func TestCatalogIndexRebuildsInBackground(t *testing.T) {
+	var version uint64 = 1
+	release := make(chan struct{})
+	catalog := testCatalog()
+	loads := 0
+
+	ix := NewCatalogIndex(0)
+	ix.catalogVersion = func() uint64 { return atomic.LoadUint64(&version) }
+	ix.load = func() []repository.CatalogAnime {
+		loads++
+		if loads > 1 {
+			<-release
+		}
+		return catalog[:loads]
+	}
+
+	if n := ix.Current().Len(); n != 1 {
+		t.Fatalf("indeks pertama seharusnya berisi 1 anime, didapat %d", n)
+	}
+
+	atomic.StoreUint64(&version, 2)
+	done := make(chan *Index)
+	go func() { done <- ix.Current() }()
+	select {
+	case stale := <-done:
+		if stale.Len() != 1 {
+			t.Errorf("selama rebuild indeks lama seharusnya dilayani, didapat %d anime", stale.Len())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Current tidak boleh menunggu rebuild selesai")
+	}
+
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for ix.Current().Len() != 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("indeks baru tidak pernah dipasang")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
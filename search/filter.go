@@ -0,0 +1,151 @@
+package search
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"multiplescrape/repository"
+)
+
+// Urutan hasil yang didukung. SortRelevance mempertahankan urutan asal (skor indeks atau urutan situs sumber).
+const (
+	SortRelevance = "relevance"
+	SortScore     = "score"
+	SortTitle     = "title"
+	SortLatest    = "latest"
+)
+
+// SortOptions adalah semua nilai parameter sort yang valid.
+var SortOptions = []string{SortRelevance, SortScore, SortTitle, SortLatest}
+
+// ValidSort mengecek apakah nilai sort dikenal.
+func ValidSort(by string) bool {
+	for _, option := range SortOptions {
+		if by == option {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter menyaring anime berdasarkan metadata. Field kosong berarti tidak disaring.
+// Genre harus dimiliki semua; type dan status harus sama persis, season dan studio cukup memuat teksnya
+// (semuanya tidak peka huruf besar/kecil).
+type Filter struct {
+	Genres   []string
+	Type     string
+	Status   string
+	Season   string
+	Studio   string
+	MinScore float64
+}
+
+// Match mengecek apakah anime lolos filter.
+func (f Filter) Match(anime repository.CatalogAnime) bool {
+	for _, genre := range f.Genres {
+		if !containsFold(anime.Genres, genre) {
+			return false
+		}
+	}
+	if f.Type != "" && !strings.EqualFold(strings.TrimSpace(anime.Type), f.Type) {
+		return false
+	}
+	if f.Status != "" && !strings.EqualFold(strings.TrimSpace(anime.Status), f.Status) {
+		return false
+	}
+	if f.Season != "" && !containsText(anime.Details["Season"], f.Season) {
+		return false
+	}
+	if f.Studio != "" && !containsText(anime.Details["Studio"], f.Studio) {
+		return false
+	}
+	if f.MinScore > 0 {
+		score, ok := ParseScore(anime.Score)
+		if !ok || score < f.MinScore {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply mengembalikan anime yang lolos filter dengan urutan yang sama.
+func (f Filter) Apply(list []repository.CatalogAnime) []repository.CatalogAnime {
+	filtered := make([]repository.CatalogAnime, 0, len(list))
+	for _, anime := range list {
+		if f.Match(anime) {
+			filtered = append(filtered, anime)
+		}
+	}
+	return filtered
+}
+
+// ParseScore membaca skor anime seperti "8.84". Skor kosong atau "N/A" dianggap tidak ada.
+func ParseScore(raw string) (float64, bool) {
+	score, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	return score, err == nil
+}
+
+// Sort mengurutkan anime di tempat. Anime tanpa skor atau tanpa episode yang tercatat
+// ditaruh di akhir untuk urutan score dan latest.
+func Sort(list []repository.CatalogAnime, by string) {
+	switch by {
+	case SortScore:
+		sort.SliceStable(list, func(i, j int) bool {
+			si, okI := ParseScore(list[i].Score)
+			sj, okJ := ParseScore(list[j].Score)
+			if okI != okJ {
+				return okI
+			}
+			return si > sj
+		})
+	case SortTitle:
+		sort.SliceStable(list, func(i, j int) bool {
+			return strings.ToLower(list[i].Title) < strings.ToLower(list[j].Title)
+		})
+	case SortLatest:
+		sort.SliceStable(list, func(i, j int) bool {
+			return list[i].LatestEpisodeAt.After(list[j].LatestEpisodeAt)
+		})
+	}
+}
+
+// Paginate menghitung potongan [start, end) untuk halaman page (mulai dari 1) beserta metadata paginasi.
+func Paginate(total, page, pageSize int) (int, int, repository.Pagination) {
+	if pageSize < 1 {
+		pageSize = 1
+	}
+	if page < 1 {
+		page = 1
+	}
+	totalPages := (total + pageSize - 1) / pageSize
+	start := (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+	return start, end, repository.Pagination{
+		Page:        page,
+		PageSize:    pageSize,
+		TotalItems:  total,
+		TotalPages:  totalPages,
+		HasNextPage: page < totalPages,
+		HasPrevPage: page > 1,
+	}
+}
+
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(strings.TrimSpace(item), strings.TrimSpace(value)) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsText(text, part string) bool {
+	return strings.Contains(strings.ToLower(text), strings.ToLower(strings.TrimSpace(part)))
+}
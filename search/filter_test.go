@@ -0,0 +1,56 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilterMatch(t *testing.T) {
+	cases := []struct {
+		filter Filter
+		want   []string
+	}{
+		{Filter{}, animeSlugs(testCatalog())},
+		{Filter{Genres: []string{"action", "comedy"}}, []string{"one-punch-man"}},
+		{Filter{Type: "tv"}, []string{"kimetsu-no-yaiba", "one-piece", "one-punch-man", "kaguya-sama"}},
+		{Filter{Status: "Completed"}, []string{"kimetsu-no-yaiba", "kimetsu-no-yaiba-movie", "one-punch-man"}},
+		{Filter{Season: "2019"}, []string{"kimetsu-no-yaiba", "kaguya-sama"}},
+		{Filter{Studio: "UFO"}, []string{"kimetsu-no-yaiba", "kimetsu-no-yaiba-movie"}},
+		{Filter{MinScore: 8.6}, []string{"kimetsu-no-yaiba-movie", "one-piece"}},
+	}
+	for _, tc := range cases {
+		if got := animeSlugs(tc.filter.Apply(testCatalog())); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%+v: didapat %v, seharusnya %v", tc.filter, got, tc.want)
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	cases := map[string][]string{
+		SortRelevance: animeSlugs(testCatalog()),
+		// Tanpa skor di akhir dengan urutan semula
+		SortScore: {"kimetsu-no-yaiba-movie", "one-piece", "kimetsu-no-yaiba", "kaguya-sama", "one-punch-man", "naruto", "naruto-shippuden", "boruto"},
+		// Tidak peka huruf besar/kecil: "ONE PUNCH MAN" setelah "One Piece"
+		SortTitle:  {"boruto", "kaguya-sama", "kimetsu-no-yaiba", "kimetsu-no-yaiba-movie", "naruto", "naruto-shippuden", "one-piece", "one-punch-man"},
+		SortLatest: {"one-piece", "kaguya-sama", "kimetsu-no-yaiba", "kimetsu-no-yaiba-movie", "one-punch-man", "naruto", "naruto-shippuden", "boruto"},
+	}
+	for by, want := range cases {
+		list := testCatalog()
+		Sort(list, by)
+		if got := animeSlugs(list); !reflect.DeepEqual(got, want) {
+			t.Errorf("sort %s: didapat %v, seharusnya %v", by, got, want)
+		}
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	start, end, meta := Paginate(45, 3, 20)
+	if start != 40 || end != 45 || meta.TotalPages != 3 || meta.HasNextPage || !meta.HasPrevPage {
+		t.Errorf("halaman terakhir tidak sesuai: %d-%d %+v", start, end, meta)
+	}
+
+	start, end, meta = Paginate(5, 4, 20)
+	if start != 5 || end != 5 || meta.HasNextPage {
+		t.Errorf("halaman di luar jangkauan seharusnya kosong: %d-%d %+v", start, end, meta)
+	}
+}
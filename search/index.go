@@ -0,0 +1,300 @@
+// Package search menyediakan indeks full-text lokal di atas data katalog, sehingga
+// pencarian tidak perlu memanggil situs sumber untuk anime yang sudah pernah di-scrape.
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"multiplescrape/repository"
+)
+
+// Bobot per field; judul paling menentukan, sinopsis paling lemah.
+const (
+	weightTitle    = 3.0
+	weightAltTitle = 2.5
+	weightGenre    = 1.5
+	weightSynopsis = 1.0
+)
+
+// Kemiripan term kueri dengan term di indeks.
+const (
+	simExact  = 1.0
+	simPrefix = 0.8
+	simTypo1  = 0.7
+	simTypo2  = 0.5
+)
+
+// AltTitleKeys adalah kunci map Details yang berisi judul alternatif.
+var AltTitleKeys = repository.AltTitleKeys
+
+// Result adalah satu anime hasil pencarian beserta skor relevansinya.
+type Result struct {
+	Anime repository.CatalogAnime
+	Score float64
+}
+
+type posting struct {
+	doc    int
+	weight float64
+}
+
+// Index adalah indeks terbalik yang tidak berubah setelah dibangun; aman dipakai bersamaan.
+type Index struct {
+	docs     []repository.CatalogAnime
+	compact  [][]string // judul (dan judul alternatif) tanpa spasi per dokumen
+	postings map[string][]posting
+	terms    []string     // kosakata terurut untuk pencarian prefix
+	suggest  []suggestKey // kunci judul terurut untuk autocomplete
+}
+
+// Tokenize memecah teks menjadi term huruf kecil berisi huruf dan angka.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Compact menghapus semua karakter selain huruf dan angka, misalnya "Kimetsu no Yaiba" menjadi "kimetsunoyaiba".
+func Compact(text string) string {
+	return strings.Join(Tokenize(text), "")
+}
+
+// AltTitles mengembalikan judul alternatif anime dari map Details.
+func AltTitles(anime repository.CatalogAnime) []string {
+	return repository.AlternativeTitles(anime.Details)
+}
+
+// Build membangun indeks dari daftar anime.
+func Build(docs []repository.CatalogAnime) *Index {
+	ix := &Index{
+		docs:     docs,
+		compact:  make([][]string, len(docs)),
+		postings: make(map[string][]posting),
+	}
+	for id, anime := range docs {
+		weights := make(map[string]float64)
+		add := func(text string, weight float64) {
+			for _, term := range Tokenize(text) {
+				if weight > weights[term] {
+					weights[term] = weight
+				}
+			}
+		}
+		add(anime.Title, weightTitle)
+		ix.compact[id] = append(ix.compact[id], Compact(anime.Title))
+		for _, title := range AltTitles(anime) {
+			add(title, weightAltTitle)
+			ix.compact[id] = append(ix.compact[id], Compact(title))
+		}
+		for _, genre := range anime.Genres {
+			add(genre, weightGenre)
+		}
+		add(anime.Synopsis, weightSynopsis)
+
+		for term, weight := range weights {
+			ix.postings[term] = append(ix.postings[term], posting{doc: id, weight: weight})
+		}
+	}
+	for term := range ix.postings {
+		ix.terms = append(ix.terms, term)
+	}
+	sort.Strings(ix.terms)
+	ix.buildSuggest()
+	return ix
+}
+
+// Len mengembalikan jumlah anime di indeks.
+func (ix *Index) Len() int {
+	return len(ix.docs)
+}
+
+// Docs mengembalikan semua anime di indeks.
+func (ix *Index) Docs() []repository.CatalogAnime {
+	return ix.docs
+}
+
+// match mengembalikan term di indeks yang cocok dengan term kueri beserta kemiripannya:
+// sama persis, diawali term kueri, atau berbeda satu-dua huruf (salah ketik).
+func (ix *Index) match(q string) map[string]float64 {
+	matches := make(map[string]float64)
+	if _, ok := ix.postings[q]; ok {
+		matches[q] = simExact
+	}
+	if len([]rune(q)) >= 2 {
+		for i := sort.SearchStrings(ix.terms, q); i < len(ix.terms) && strings.HasPrefix(ix.terms[i], q); i++ {
+			if ix.terms[i] != q {
+				matches[ix.terms[i]] = simPrefix
+			}
+		}
+	}
+
+	maxDist := maxTypos(q)
+	if maxDist == 0 {
+		return matches
+	}
+	qr := []rune(q)
+	for _, term := range ix.terms {
+		if _, ok := matches[term]; ok {
+			continue
+		}
+		tr := []rune(term)
+		if abs(len(tr)-len(qr)) > maxDist {
+			continue
+		}
+		switch d := levenshtein(qr, tr, maxDist); {
+		case d > maxDist:
+		case d == 1:
+			matches[term] = simTypo1
+		default:
+			matches[term] = simTypo2
+		}
+	}
+	return matches
+}
+
+// maxTypos menentukan jumlah salah ketik yang ditoleransi berdasarkan panjang term.
+func maxTypos(q string) int {
+	switch n := len([]rune(q)); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// scoreUnit menghitung skor setiap dokumen untuk satu term kueri (bobot field x kemiripan x idf).
+func (ix *Index) scoreUnit(q string) map[int]float64 {
+	scores := make(map[int]float64)
+	n := float64(len(ix.docs))
+	for term, sim := range ix.match(q) {
+		list := ix.postings[term]
+		idf := math.Log(1 + n/float64(len(list)))
+		for _, p := range list {
+			if s := p.weight * sim * idf; s > scores[p.doc] {
+				scores[p.doc] = s
+			}
+		}
+	}
+	return scores
+}
+
+// Search mencari anime yang cocok dengan kueri, diurutkan dari yang paling relevan.
+// Term kueri yang berdekatan juga dicoba digabung, sehingga "kimet su no" tetap menemukan "Kimetsu no Yaiba".
+// Anime yang hanya cocok dengan kurang dari separuh term kueri tidak dikembalikan. limit <= 0 berarti tanpa batas.
+func (ix *Index) Search(query string, limit int) []Result {
+	tokens := Tokenize(query)
+	if len(tokens) == 0 || len(ix.docs) == 0 {
+		return nil
+	}
+
+	single := make([]map[int]float64, len(tokens))
+	joined := make([]map[int]float64, len(tokens))
+	candidates := make(map[int]bool)
+	for i, token := range tokens {
+		single[i] = ix.scoreUnit(token)
+		for doc := range single[i] {
+			candidates[doc] = true
+		}
+		if i+1 < len(tokens) {
+			joined[i] = ix.scoreUnit(token + tokens[i+1])
+			for doc := range joined[i] {
+				candidates[doc] = true
+			}
+		}
+	}
+
+	compactQuery := Compact(query)
+	var results []Result
+	for doc := range candidates {
+		// Pilih pembagian term terbaik: term tunggal atau gabungan dua term yang berdekatan
+		best := make([]float64, len(tokens)+1)
+		covered := make([]int, len(tokens)+1)
+		for i := 1; i <= len(tokens); i++ {
+			best[i], covered[i] = best[i-1]+single[i-1][doc], covered[i-1]
+			if single[i-1][doc] > 0 {
+				covered[i]++
+			}
+			if i >= 2 {
+				if s, ok := joined[i-2][doc]; ok && best[i-2]+s > best[i] {
+					best[i], covered[i] = best[i-2]+s, covered[i-2]+2
+				}
+			}
+		}
+		if covered[len(tokens)]*2 < len(tokens) {
+			continue
+		}
+
+		score := best[len(tokens)] * float64(covered[len(tokens)]) / float64(len(tokens))
+		for _, title := range ix.compact[doc] {
+			if strings.HasPrefix(title, compactQuery) {
+				score += 2 * weightTitle
+				break
+			} else if strings.Contains(title, compactQuery) {
+				score += weightTitle
+				break
+			}
+		}
+		results = append(results, Result{Anime: ix.docs[doc], Score: score})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Anime.Title < results[j].Anime.Title
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// levenshtein menghitung jarak edit a dan b, berhenti lebih awal jika melebihi max (mengembalikan max+1).
+func levenshtein(a, b []rune, max int) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if curr[j] < rowMin {
+				rowMin = curr[j]
+			}
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,101 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"multiplescrape/repository"
+)
+
+// testCatalog adalah katalog contoh yang dipakai semua test di package ini.
+// Tiga entri terakhir hanya berisi judul, untuk menguji autocomplete.
+func testCatalog() []repository.CatalogAnime {
+	now := time.Now()
+	return []repository.CatalogAnime{
+		{
+			Slug:     "kimetsu-no-yaiba",
+			Title:    "Kimetsu no Yaiba",
+			Type:     "TV",
+			Status:   "Completed",
+			Score:    "8.5",
+			Genres:   []string{"Action", "Supernatural"},
+			Synopsis: "Tanjirou menjadi pemburu iblis setelah keluarganya dibunuh.",
+			Details: map[string]string{
+				"English": "Demon Slayer", "Synonyms": "KnY", "Season": "Spring 2019", "Studio": "ufotable",
+			},
+			LatestEpisodeAt: now.Add(-72 * time.Hour),
+		},
+		{Slug: "kimetsu-no-yaiba-movie", Title: "Kimetsu no Yaiba Movie: Mugen Ressha-hen", Type: "Movie", Status: "Completed",
+			Score: "8.9", Genres: []string{"Action"}, Details: map[string]string{"Studio": "ufotable"}},
+		{Slug: "one-piece", Title: "One Piece", Type: "TV", Status: "Ongoing", Score: "8.7", Genres: []string{"Action", "Adventure"},
+			Synopsis: "Luffy mencari harta karun.", Details: map[string]string{"Season": "Fall 1999", "Studio": "Toei Animation"},
+			LatestEpisodeAt: now},
+		{Slug: "one-punch-man", Title: "ONE PUNCH MAN", Type: "TV", Status: "Completed", Score: "N/A", Genres: []string{"Action", "Comedy"},
+			Details: map[string]string{"Season": "Fall 2015", "Studio": "Madhouse"}},
+		{Slug: "kaguya-sama", Title: "Kaguya-sama wa Kokurasetai", Type: "TV", Status: "Ongoing", Score: "8.1", Genres: []string{"Romance", "Comedy"},
+			Details: map[string]string{"Season": "Winter 2019", "Studio": "A-1 Pictures"}, LatestEpisodeAt: now.Add(-24 * time.Hour)},
+		{Slug: "naruto", Title: "Naruto"},
+		{Slug: "naruto-shippuden", Title: "Naruto: Shippuuden"},
+		{Slug: "boruto", Title: "Boruto: Naruto Next Generations"},
+	}
+}
+
+func testIndex() *Index {
+	return Build(testCatalog())
+}
+
+func slugs(results []Result) []string {
+	out := make([]string, 0, len(results))
+	for _, r := range results {
+		out = append(out, r.Anime.Slug)
+	}
+	return out
+}
+
+func animeSlugs(list []repository.CatalogAnime) []string {
+	out := make([]string, 0, len(list))
+	for _, anime := range list {
+		out = append(out, anime.Slug)
+	}
+	return out
+}
+
+func TestSearchTypoTolerant(t *testing.T) {
+	ix := testIndex()
+	cases := map[string]string{
+		"kimet su no":      "kimetsu-no-yaiba", // kata terpotong
+		"kimetsu no yaiba": "kimetsu-no-yaiba",
+		"kimetsu no yaeba": "kimetsu-no-yaiba", // salah ketik
+		"demon slayer":     "kimetsu-no-yaiba", // judul alternatif
+		"one pice":         "one-piece",
+		"kaguya":           "kaguya-sama",
+		"pemburu iblis":    "kimetsu-no-yaiba", // sinopsis
+	}
+	for query, want := range cases {
+		got := ix.Search(query, 10)
+		if len(got) == 0 || got[0].Anime.Slug != want {
+			t.Errorf("%q: hasil teratas seharusnya %s, didapat %v", query, want, slugs(got))
+		}
+	}
+}
+
+func TestSearchRanksTitleAboveGenre(t *testing.T) {
+	got := testIndex().Search("comedy", 0)
+	if len(got) != 2 {
+		t.Fatalf("seharusnya 2 anime komedi, didapat %v", slugs(got))
+	}
+
+	got = testIndex().Search("one", 0)
+	if len(got) != 2 || got[0].Score < got[1].Score {
+		t.Errorf("hasil seharusnya urut dari skor tertinggi: %+v", got)
+	}
+}
+
+func TestSearchMisses(t *testing.T) {
+	ix := testIndex()
+	for _, query := range []string{"", "xyzzy", "shingeki no kyojin"} {
+		if got := ix.Search(query, 10); len(got) != 0 {
+			t.Errorf("%q seharusnya tidak menemukan apa pun, didapat %v", query, slugs(got))
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package search
+
+import (
+	"sort"
+	"strings"
+
+	"multiplescrape/repository"
+)
+
+// MaxSuggestions adalah jumlah saran maksimum per permintaan autocomplete.
+const MaxSuggestions = 10
+
+// Peringkat kunci saran; makin kecil makin diutamakan.
+const (
+	rankTitle    = 0 // awal judul utama
+	rankAltTitle = 1 // awal judul alternatif
+	rankWord     = 2 // awal kata di tengah judul, ditambahkan ke peringkat judulnya
+)
+
+type suggestKey struct {
+	key  string
+	doc  int
+	rank int
+}
+
+// suggestKeys membuat kunci prefix untuk satu judul: judul utuh dan setiap akhiran yang dimulai di awal kata,
+// sehingga "yaiba" juga menyarankan "Kimetsu no Yaiba".
+func suggestKeys(title string, doc, rank int) []suggestKey {
+	words := Tokenize(title)
+	keys := make([]suggestKey, 0, len(words))
+	for i := range words {
+		r := rank
+		if i > 0 {
+			r += rankWord
+		}
+		keys = append(keys, suggestKey{key: strings.Join(words[i:], " "), doc: doc, rank: r})
+	}
+	return keys
+}
+
+// buildSuggest menyusun kunci saran terurut dari judul dan judul alternatif semua dokumen.
+func (ix *Index) buildSuggest() {
+	for id, anime := range ix.docs {
+		ix.suggest = append(ix.suggest, suggestKeys(anime.Title, id, rankTitle)...)
+		for _, title := range AltTitles(anime) {
+			ix.suggest = append(ix.suggest, suggestKeys(title, id, rankAltTitle)...)
+		}
+	}
+	sort.Slice(ix.suggest, func(i, j int) bool { return ix.suggest[i].key < ix.suggest[j].key })
+}
+
+// Suggest mengembalikan anime yang judul atau judul alternatifnya diawali prefix (per kata, tidak peka huruf
+// besar/kecil dan tanda baca). Awal judul utama diutamakan, lalu judul yang lebih pendek.
+func (ix *Index) Suggest(prefix string, limit int) []repository.CatalogAnime {
+	prefix = strings.Join(Tokenize(prefix), " ")
+	if prefix == "" {
+		return nil
+	}
+	if limit <= 0 || limit > MaxSuggestions {
+		limit = MaxSuggestions
+	}
+
+	best := make(map[int]int)
+	start := sort.Search(len(ix.suggest), func(i int) bool { return ix.suggest[i].key >= prefix })
+	for i := start; i < len(ix.suggest) && strings.HasPrefix(ix.suggest[i].key, prefix); i++ {
+		k := ix.suggest[i]
+		if rank, ok := best[k.doc]; !ok || k.rank < rank {
+			best[k.doc] = k.rank
+		}
+	}
+
+	docs := make([]int, 0, len(best))
+	for doc := range best {
+		docs = append(docs, doc)
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		a, b := docs[i], docs[j]
+		if best[a] != best[b] {
+			return best[a] < best[b]
+		}
+		ta, tb := ix.docs[a].Title, ix.docs[b].Title
+		if len(ta) != len(tb) {
+			return len(ta) < len(tb)
+		}
+		return ta < tb
+	})
+	if len(docs) > limit {
+		docs = docs[:limit]
+	}
+
+	suggestions := make([]repository.CatalogAnime, 0, len(docs))
+	for _, doc := range docs {
+		suggestions = append(suggestions, ix.docs[doc])
+	}
+	return suggestions
+}
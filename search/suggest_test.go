@@ -0,0 +1,34 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSuggest(t *testing.T) {
+	ix := testIndex()
+	cases := []struct {
+		prefix string
+		want   []string
+	}{
+		// Awal judul utama dulu, judul lebih pendek dulu, lalu kata di tengah judul
+		{"naru", []string{"naruto", "naruto-shippuden", "boruto"}},
+		{"Naruto: S", []string{"naruto-shippuden"}},
+		{"kimetsu no", []string{"kimetsu-no-yaiba", "kimetsu-no-yaiba-movie"}},
+		{"demon", []string{"kimetsu-no-yaiba"}},
+		{"yaiba", []string{"kimetsu-no-yaiba", "kimetsu-no-yaiba-movie"}},
+		{"shingeki", []string{}},
+		{"  ", []string{}},
+	}
+	for _, tc := range cases {
+		if got := animeSlugs(ix.Suggest(tc.prefix, 0)); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q: didapat %v, seharusnya %v", tc.prefix, got, tc.want)
+		}
+	}
+}
+
+func TestSuggestLimit(t *testing.T) {
+	if got := testIndex().Suggest("n", 1); len(got) != 1 || got[0].Slug != "naruto" {
+		t.Errorf("limit 1 seharusnya hanya naruto, didapat %v", animeSlugs(got))
+	}
+}
@@ -0,0 +1,70 @@
+package watchlist
+
+import (
+	"regexp"
+	"strconv"
+
+	"multiplescrape/repository"
+)
+
+var episodeNumberPattern = regexp.MustCompile(`\d+(?:\.\d+)?`)
+
+// EpisodeNumber mengambil nomor episode dari teks seperti "12", "12.5", atau "12 END".
+func EpisodeNumber(raw string) (float64, bool) {
+	match := episodeNumberPattern.FindString(raw)
+	if match == "" {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(match, 64)
+	return n, err == nil
+}
+
+// episodeAfter mengecek apakah episode a lebih baru dari b. Jika salah satu tidak bernomor,
+// a dianggap lebih baru agar penandaan eksplisit dari pengguna tetap tersimpan.
+func episodeAfter(a, b string) bool {
+	na, okA := EpisodeNumber(a)
+	nb, okB := EpisodeNumber(b)
+	if !okA || !okB {
+		return a != b
+	}
+	return na > nb
+}
+
+// Unwatched mengembalikan episode yang dirilis setelah episode terakhir yang ditonton,
+// dengan urutan sama seperti daftar episode sumber. Jika belum ada yang ditonton, semua episode
+// dikembalikan. Episode tanpa nomor (misalnya "OVA") dilewati setelah ada episode yang ditonton
+// karena posisinya tidak bisa dibandingkan; begitu juga jika episode terakhir yang ditonton tidak bernomor.
+func (e Entry) Unwatched(episodes []repository.ScrapedEpisode) []repository.ScrapedEpisode {
+	if e.LastWatched == "" {
+		return episodes
+	}
+	last, ok := EpisodeNumber(e.LastWatched)
+	if !ok {
+		return nil
+	}
+	var unwatched []repository.ScrapedEpisode
+	for _, ep := range episodes {
+		if n, ok := EpisodeNumber(ep.Episode); ok && n > last {
+			unwatched = append(unwatched, ep)
+		}
+	}
+	return unwatched
+}
+
+// UnwatchedAnime adalah episode yang belum ditonton untuk satu anime di watchlist.
+type UnwatchedAnime struct {
+	AnimeSlug   string                       `json:"anime_slug" example:"one-piece"`
+	Title       string                       `json:"title" example:"One Piece"`
+	LastWatched string                       `json:"last_watched_episode" example:"1100"`
+	Episodes    []repository.EpisodeListItem `json:"episodes"`
+	// Source menunjukkan asal daftar episode: catalog, scrape, catalog_stale, atau unavailable.
+	Source string `json:"source" example:"catalog"`
+}
+
+// UnwatchedResponse adalah response GET /api/v1/watchlists/:id/unwatched.
+type UnwatchedResponse struct {
+	WatchlistID    string           `json:"watchlist_id"`
+	TotalUnwatched int              `json:"total_unwatched" example:"3"`
+	Data           []UnwatchedAnime `json:"data"`
+	Message        string           `json:"message"`
+}
@@ -0,0 +1,271 @@
+// Package watchlist menyimpan watchlist pengguna (daftar anime yang diikuti beserta
+// episode terakhir yang ditonton) dan menghitung episode yang belum ditonton.
+package watchlist
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNotFound dikembalikan jika ID watchlist tidak dikenal.
+	ErrNotFound = errors.New("watchlist tidak ditemukan")
+	// ErrAnimeNotFound dikembalikan jika anime tidak ada di watchlist.
+	ErrAnimeNotFound = errors.New("anime tidak ada di watchlist")
+	// ErrLimit dikembalikan jika watchlist sudah berisi jumlah anime maksimum.
+	ErrLimit = errors.New("jumlah anime di watchlist sudah mencapai batas")
+)
+
+// Watchlist adalah daftar anime milik satu pengguna. ID sekaligus menjadi token akses,
+// jadi hanya pemegang ID yang bisa membaca atau mengubahnya.
+type Watchlist struct {
+	ID        string    `json:"id" example:"9f2c4e7a1b3d5f6e8a0c2e4f6a8b0d1e"`
+	Name      string    `json:"name,omitempty" example:"Musim ini"`
+	Anime     []Entry   `json:"anime"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Entry adalah satu anime di watchlist. LastWatched kosong berarti belum ada episode yang ditonton.
+type Entry struct {
+	AnimeSlug   string    `json:"anime_slug" example:"one-piece"`
+	LastWatched string    `json:"last_watched_episode,omitempty" example:"1100"`
+	WatchedAt   time.Time `json:"watched_at,omitempty"`
+	AddedAt     time.Time `json:"added_at"`
+}
+
+// CreateInput adalah body request untuk membuat watchlist.
+type CreateInput struct {
+	Name string `json:"name" example:"Musim ini"`
+}
+
+// AnimeInput adalah body request opsional saat menambahkan anime ke watchlist.
+type AnimeInput struct {
+	LastWatched string `json:"last_watched_episode" example:"1100"`
+}
+
+// WatchedInput adalah body request untuk menandai episode sudah ditonton.
+type WatchedInput struct {
+	Episode string `json:"episode" example:"1101"`
+}
+
+func (w *Watchlist) find(slug string) int {
+	for i, entry := range w.Anime {
+		if entry.AnimeSlug == slug {
+			return i
+		}
+	}
+	return -1
+}
+
+// NormalizeSlug menyeragamkan slug anime dan menolak slug yang tidak valid.
+func NormalizeSlug(raw string) (string, error) {
+	slug := strings.ToLower(strings.TrimSpace(raw))
+	if slug == "" || len(slug) > 200 || strings.ContainsAny(slug, "/?#& ") {
+		return "", fmt.Errorf("anime_slug tidak valid: %q", raw)
+	}
+	return slug, nil
+}
+
+// Store menyimpan watchlist di file JSON lokal.
+type Store struct {
+	mu       sync.RWMutex
+	path     string
+	maxAnime int
+	lists    map[string]Watchlist
+}
+
+// NewStore memuat watchlist dari file. File yang belum ada dianggap kosong.
+// maxAnime membatasi jumlah anime per watchlist (0 = tanpa batas).
+func NewStore(path string, maxAnime int) (*Store, error) {
+	s := &Store{path: path, maxAnime: maxAnime, lists: make(map[string]Watchlist)}
+	raw, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		var list []Watchlist
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return nil, fmt.Errorf("file watchlist %s tidak valid: %w", path, err)
+		}
+		for _, w := range list {
+			s.lists[w.ID] = w
+		}
+	case os.IsNotExist(err):
+	default:
+		return nil, fmt.Errorf("gagal membaca file watchlist %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Count mengembalikan jumlah watchlist yang tersimpan.
+func (s *Store) Count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.lists)
+}
+
+// Get mengembalikan satu watchlist berdasarkan ID.
+func (s *Store) Get(id string) (Watchlist, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	w, ok := s.lists[id]
+	if !ok {
+		return Watchlist{}, ErrNotFound
+	}
+	return w, nil
+}
+
+// Create membuat watchlist kosong dengan ID acak.
+func (s *Store) Create(name string) (Watchlist, error) {
+	now := time.Now()
+	w := Watchlist{
+		ID:        randomHex(16),
+		Name:      strings.TrimSpace(name),
+		Anime:     []Entry{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lists[w.ID] = w
+	if err := s.save(); err != nil {
+		delete(s.lists, w.ID)
+		return Watchlist{}, err
+	}
+	return w, nil
+}
+
+// Delete menghapus watchlist.
+func (s *Store) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous, ok := s.lists[id]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(s.lists, id)
+	if err := s.save(); err != nil {
+		s.lists[id] = previous
+		return err
+	}
+	return nil
+}
+
+// AddAnime menambahkan anime ke watchlist. lastWatched opsional, untuk anime yang sudah
+// ditonton sebagian. Menambahkan anime yang sudah ada hanya memperbarui lastWatched jika diisi.
+func (s *Store) AddAnime(id, slug, lastWatched string) (Watchlist, error) {
+	return s.modify(id, func(w *Watchlist, now time.Time) error {
+		i := w.find(slug)
+		if i < 0 {
+			if s.maxAnime > 0 && len(w.Anime) >= s.maxAnime {
+				return ErrLimit
+			}
+			w.Anime = append(w.Anime, Entry{AnimeSlug: slug, AddedAt: now})
+			i = len(w.Anime) - 1
+		}
+		if lastWatched = strings.TrimSpace(lastWatched); lastWatched != "" {
+			w.Anime[i].LastWatched = lastWatched
+			w.Anime[i].WatchedAt = now
+		}
+		return nil
+	})
+}
+
+// RemoveAnime menghapus anime dari watchlist.
+func (s *Store) RemoveAnime(id, slug string) (Watchlist, error) {
+	return s.modify(id, func(w *Watchlist, now time.Time) error {
+		i := w.find(slug)
+		if i < 0 {
+			return ErrAnimeNotFound
+		}
+		w.Anime = append(w.Anime[:i], w.Anime[i+1:]...)
+		return nil
+	})
+}
+
+// MarkWatched menandai episode sudah ditonton. Episode terakhir yang ditonton hanya bergerak maju,
+// jadi menandai episode lama (misalnya menonton ulang) tidak membuat episode baru dianggap sudah ditonton.
+func (s *Store) MarkWatched(id, slug, episode string) (Watchlist, error) {
+	episode = strings.TrimSpace(episode)
+	if episode == "" {
+		return Watchlist{}, fmt.Errorf("episode wajib diisi")
+	}
+	return s.modify(id, func(w *Watchlist, now time.Time) error {
+		i := w.find(slug)
+		if i < 0 {
+			return ErrAnimeNotFound
+		}
+		entry := &w.Anime[i]
+		if entry.LastWatched != "" && !episodeAfter(episode, entry.LastWatched) {
+			return nil
+		}
+		entry.LastWatched = episode
+		entry.WatchedAt = now
+		return nil
+	})
+}
+
+// modify menjalankan fn pada salinan watchlist lalu menyimpannya. Jika fn atau penyimpanan gagal,
+// watchlist tidak berubah.
+func (s *Store) modify(id string, fn func(w *Watchlist, now time.Time) error) (Watchlist, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous, ok := s.lists[id]
+	if !ok {
+		return Watchlist{}, ErrNotFound
+	}
+	w := previous
+	w.Anime = append([]Entry{}, previous.Anime...)
+	now := time.Now()
+	if err := fn(&w, now); err != nil {
+		return Watchlist{}, err
+	}
+	w.UpdatedAt = now
+
+	s.lists[id] = w
+	if err := s.save(); err != nil {
+		s.lists[id] = previous
+		return Watchlist{}, err
+	}
+	return w, nil
+}
+
+// save menulis semua watchlist ke file secara atomik. Dipanggil dengan mu terkunci.
+func (s *Store) save() error {
+	list := make([]Watchlist, 0, len(s.lists))
+	for _, w := range s.lists {
+		list = append(list, w)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	raw, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	// ID watchlist adalah token akses, jadi file hanya bisa dibaca pemilik.
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buf)
+}
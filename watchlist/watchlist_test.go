@@ -0,0 +1,93 @@
+package watchlist
+
+import (
+	"path/filepath"
+	"testing"
+
+	"multiplescrape/repository"
+)
+
+func TestStorePersistsEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watchlists.json")
+	store, err := NewStore(path, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := store.Create("Musim ini")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(w.ID) != 32 {
+		t.Errorf("ID watchlist seharusnya token acak 32 karakter, didapat %q", w.ID)
+	}
+	if _, err := store.AddAnime(w.ID, "naruto", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.AddAnime(w.ID, "bleach", "10"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.AddAnime(w.ID, "one-piece", ""); err != ErrLimit {
+		t.Errorf("anime ketiga seharusnya ditolak karena batas, didapat %v", err)
+	}
+	if _, err := store.MarkWatched(w.ID, "one-piece", "1"); err != ErrAnimeNotFound {
+		t.Errorf("anime yang tidak ada di watchlist seharusnya ErrAnimeNotFound, didapat %v", err)
+	}
+
+	// Episode terakhir yang ditonton hanya bergerak maju
+	store.MarkWatched(w.ID, "bleach", "12")
+	store.MarkWatched(w.ID, "bleach", "3")
+
+	reloaded, err := NewStore(path, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := reloaded.Get(w.ID)
+	if err != nil || len(got.Anime) != 2 || got.Anime[1].AnimeSlug != "bleach" || got.Anime[1].LastWatched != "12" {
+		t.Fatalf("watchlist seharusnya tersimpan ke file: %+v, %v", got, err)
+	}
+
+	if _, err := reloaded.RemoveAnime(w.ID, "naruto"); err != nil {
+		t.Fatal(err)
+	}
+	if err := reloaded.Delete(w.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reloaded.Get(w.ID); err != ErrNotFound {
+		t.Errorf("watchlist yang dihapus seharusnya tidak ditemukan, didapat %v", err)
+	}
+}
+
+func TestEntryUnwatched(t *testing.T) {
+	episodes := []repository.ScrapedEpisode{
+		{Episode: "13 END"},
+		{Episode: "12.5"},
+		{Episode: "12"},
+		{Episode: "OVA"},
+		{Episode: "11"},
+	}
+
+	if got := (Entry{}).Unwatched(episodes); len(got) != len(episodes) {
+		t.Errorf("tanpa riwayat tonton semua episode belum ditonton, didapat %d", len(got))
+	}
+
+	got := Entry{LastWatched: "12"}.Unwatched(episodes)
+	if len(got) != 2 || got[0].Episode != "13 END" || got[1].Episode != "12.5" {
+		t.Errorf("seharusnya episode 13 dan 12.5, didapat %+v", got)
+	}
+
+	if got := (Entry{LastWatched: "13"}).Unwatched(episodes); len(got) != 0 {
+		t.Errorf("semua episode sudah ditonton, didapat %+v", got)
+	}
+}
+
+func TestNormalizeSlug(t *testing.T) {
+	if slug, err := NormalizeSlug(" One-Piece "); err != nil || slug != "one-piece" {
+		t.Errorf("slug seharusnya dinormalisasi, didapat %q, %v", slug, err)
+	}
+	for _, raw := range []string{"", "a/b", "a b"} {
+		if _, err := NormalizeSlug(raw); err == nil {
+			t.Errorf("slug %q seharusnya ditolak", raw)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"multiplescrape/events"
+)
+
+// Header yang dikirim bersama setiap event.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderSignature = "X-Webhook-Signature"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+)
+
+// PingEvent adalah jenis event untuk uji coba webhook dari endpoint admin.
+const PingEvent = "ping"
+
+// Delivery adalah catatan satu percobaan pengiriman event ke webhook.
+type Delivery struct {
+	ID         string    `json:"id"`
+	WebhookID  string    `json:"webhook_id"`
+	EventID    uint64    `json:"event_id"`
+	EventType  string    `json:"event_type"`
+	URL        string    `json:"url"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Success    bool      `json:"success"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	Time       time.Time `json:"time"`
+}
+
+// Options mengatur pengiriman webhook.
+type Options struct {
+	Timeout      time.Duration
+	MaxAttempts  int
+	RetryBackoff time.Duration
+	Workers      int
+	LogSize      int
+}
+
+type task struct {
+	hook    Webhook
+	event   events.Event
+	attempt int
+}
+
+// Dispatcher mengirim event ke semua webhook aktif yang berlangganan.
+// Pengiriman gagal diulang dengan backoff eksponensial sampai MaxAttempts.
+type Dispatcher struct {
+	store  *Store
+	opts   Options
+	client *http.Client
+	queue  chan task
+	stop   chan struct{}
+
+	mu   sync.Mutex
+	log  []Delivery
+	next int
+	seq  uint64
+}
+
+// NewDispatcher membuat dispatcher untuk store tertentu.
+func NewDispatcher(store *Store, opts Options) *Dispatcher {
+	if opts.MaxAttempts < 1 {
+		opts.MaxAttempts = 1
+	}
+	if opts.Workers < 1 {
+		opts.Workers = 1
+	}
+	if opts.LogSize < 1 {
+		opts.LogSize = 100
+	}
+	return &Dispatcher{
+		store:  store,
+		opts:   opts,
+		client: &http.Client{Timeout: opts.Timeout},
+		queue:  make(chan task, 256),
+		stop:   make(chan struct{}),
+	}
+}
+
+// Start berlangganan ke bus dan menjalankan worker pengiriman. Fungsi yang dikembalikan menghentikannya.
+func (d *Dispatcher) Start(bus *events.Bus) func() {
+	cancel := bus.Subscribe(d.Dispatch)
+	for i := 0; i < d.opts.Workers; i++ {
+		go d.work()
+	}
+	return func() {
+		cancel()
+		close(d.stop)
+	}
+}
+
+// Dispatch mengantrekan event untuk semua webhook aktif yang berlangganan. Tidak blocking.
+func (d *Dispatcher) Dispatch(e events.Event) {
+	for _, hook := range d.store.List() {
+		if hook.Active && hook.Wants(e.Type) {
+			d.enqueue(task{hook: hook, event: e, attempt: 1})
+		}
+	}
+}
+
+func (d *Dispatcher) enqueue(t task) {
+	select {
+	case d.queue <- t:
+	case <-d.stop:
+	default:
+		log.Printf("Antrean webhook penuh, event %d ke %s dibuang", t.event.ID, t.hook.URL)
+	}
+}
+
+func (d *Dispatcher) work() {
+	for {
+		select {
+		case t := <-d.queue:
+			delivery := d.Send(t.hook, t.event, t.attempt)
+			if !delivery.Success && retryable(delivery.StatusCode) && t.attempt < d.opts.MaxAttempts {
+				t.attempt++
+				backoff := d.opts.RetryBackoff << (t.attempt - 2)
+				time.AfterFunc(backoff, func() { d.enqueue(t) })
+			}
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+// retryable bernilai true untuk error jaringan (status 0), 408, 429, dan 5xx.
+func retryable(status int) bool {
+	return status == 0 || status == http.StatusRequestTimeout || status == http.StatusTooManyRequests || status >= 500
+}
+
+// SignatureTolerance adalah selisih maksimum antara X-Webhook-Timestamp dan jam penerima
+// yang sebaiknya diterima. Pengiriman yang lebih tua dari ini patut ditolak sebagai replay.
+const SignatureTolerance = 5 * time.Minute
+
+// Sign menghitung tanda tangan HMAC-SHA256 dari timestamp + "." + body dengan format "sha256=<hex>".
+// Timestamp ikut ditandatangani agar pengiriman lama tidak bisa diputar ulang dengan timestamp baru.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify memeriksa tanda tangan dan memastikan timestamp masih dalam SignatureTolerance dari now.
+// Dipakai penerima yang ditulis dengan Go; penerima lain cukup mengikuti langkah yang sama.
+func Verify(secret, timestamp, signature string, body []byte, now time.Time) bool {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	age := now.Sub(time.Unix(unix, 0))
+	if age > SignatureTolerance || age < -SignatureTolerance {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body)))
+}
+
+// Send mengirim satu event ke satu webhook secara sinkron dan mencatatnya di log pengiriman.
+func (d *Dispatcher) Send(hook Webhook, e events.Event, attempt int) Delivery {
+	d.mu.Lock()
+	d.seq++
+	delivery := Delivery{
+		ID:        fmt.Sprintf("%d-%d", e.ID, d.seq),
+		WebhookID: hook.ID,
+		EventID:   e.ID,
+		EventType: e.Type,
+		URL:       hook.URL,
+		Attempt:   attempt,
+		Time:      time.Now(),
+	}
+	d.mu.Unlock()
+
+	body, err := json.Marshal(e)
+	if err == nil {
+		delivery.StatusCode, err = d.post(hook, e, delivery.ID, body)
+	}
+	delivery.DurationMs = time.Since(delivery.Time).Milliseconds()
+	switch {
+	case err != nil:
+		delivery.Error = err.Error()
+	case delivery.StatusCode < 200 || delivery.StatusCode >= 300:
+		delivery.Error = fmt.Sprintf("status HTTP %d", delivery.StatusCode)
+	default:
+		delivery.Success = true
+	}
+
+	d.record(delivery)
+	return delivery
+}
+
+func (d *Dispatcher) post(hook Webhook, e events.Event, deliveryID string, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "multiplescrape-webhook/1.0")
+	req.Header.Set(HeaderEvent, e.Type)
+	req.Header.Set(HeaderDelivery, deliveryID)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(hook.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// record menyimpan delivery ke ring buffer berukuran LogSize.
+func (d *Dispatcher) record(delivery Delivery) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.log) < d.opts.LogSize {
+		d.log = append(d.log, delivery)
+		return
+	}
+	d.log[d.next] = delivery
+	d.next = (d.next + 1) % d.opts.LogSize
+}
+
+// Deliveries mengembalikan log pengiriman terbaru lebih dulu. webhookID kosong berarti semua webhook.
+func (d *Dispatcher) Deliveries(webhookID string) []Delivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := make([]Delivery, 0, len(d.log))
+	for i := len(d.log) - 1; i >= 0; i-- {
+		delivery := d.log[(d.next+i)%len(d.log)]
+		if webhookID == "" || delivery.WebhookID == webhookID {
+			out = append(out, delivery)
+		}
+	}
+	return out
+}
+
+// Ping mengirim event uji coba ke webhook tanpa retry.
+func (d *Dispatcher) Ping(hook Webhook) Delivery {
+	return d.Send(hook, events.Event{Type: PingEvent, Time: time.Now()}, 1)
+}
@@ -0,0 +1,252 @@
+// Package webhook menyimpan webhook yang didaftarkan dan mengirim event perubahan
+// ke sana dengan tanda tangan HMAC-SHA256, retry, dan log pengiriman.
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"multiplescrape/events"
+)
+
+// ErrNotFound dikembalikan jika ID webhook tidak dikenal.
+var ErrNotFound = errors.New("webhook tidak ditemukan")
+
+// Webhook adalah satu URL penerima event. Events kosong berarti menerima semua event.
+type Webhook struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	Secret      string    `json:"secret,omitempty"`
+	Events      []string  `json:"events"`
+	Active      bool      `json:"active"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Wants mengecek apakah webhook berlangganan jenis event tertentu.
+func (w Webhook) Wants(eventType string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, t := range w.Events {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Redacted mengembalikan salinan webhook tanpa secret untuk ditampilkan di API.
+func (w Webhook) Redacted() Webhook {
+	w.Secret = ""
+	return w
+}
+
+// Input adalah body request untuk membuat atau mengubah webhook.
+// Field kosong pada update tidak mengubah nilai lama.
+type Input struct {
+	URL         string   `json:"url" example:"https://example.com/hooks/anime"`
+	Secret      string   `json:"secret" example:"rahasia-bersama"`
+	Events      []string `json:"events" example:"new_episode,stream_added"`
+	Active      *bool    `json:"active" example:"true"`
+	Description string   `json:"description" example:"Bot Discord"`
+}
+
+func (in Input) validate(requireURL bool) error {
+	if in.URL != "" || requireURL {
+		u, err := url.Parse(in.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("url webhook harus URL http/https yang lengkap")
+		}
+	}
+	for _, t := range in.Events {
+		if !knownEvent(t) {
+			return fmt.Errorf("jenis event tidak dikenal: %q", t)
+		}
+	}
+	return nil
+}
+
+func knownEvent(t string) bool {
+	for _, known := range events.Types {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Store menyimpan webhook di file JSON lokal.
+type Store struct {
+	mu    sync.RWMutex
+	path  string
+	hooks map[string]Webhook
+}
+
+// NewStore memuat webhook dari file. File yang belum ada dianggap kosong.
+func NewStore(path string) (*Store, error) {
+	s := &Store{path: path, hooks: make(map[string]Webhook)}
+	raw, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		var list []Webhook
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return nil, fmt.Errorf("file webhook %s tidak valid: %w", path, err)
+		}
+		for _, w := range list {
+			s.hooks[w.ID] = w
+		}
+	case os.IsNotExist(err):
+	default:
+		return nil, fmt.Errorf("gagal membaca file webhook %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// List mengembalikan semua webhook, diurutkan dari yang paling lama dibuat.
+func (s *Store) List() []Webhook {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := make([]Webhook, 0, len(s.hooks))
+	for _, w := range s.hooks {
+		list = append(list, w)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list
+}
+
+// Get mengembalikan satu webhook berdasarkan ID.
+func (s *Store) Get(id string) (Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	w, ok := s.hooks[id]
+	if !ok {
+		return Webhook{}, ErrNotFound
+	}
+	return w, nil
+}
+
+// Create mendaftarkan webhook baru. Secret dibuat acak jika tidak diisi.
+func (s *Store) Create(in Input) (Webhook, error) {
+	if err := in.validate(true); err != nil {
+		return Webhook{}, err
+	}
+	now := time.Now()
+	w := Webhook{
+		ID:          randomHex(8),
+		URL:         in.URL,
+		Secret:      in.Secret,
+		Events:      in.Events,
+		Active:      in.Active == nil || *in.Active,
+		Description: in.Description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if w.Secret == "" {
+		w.Secret = randomHex(32)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks[w.ID] = w
+	if err := s.save(); err != nil {
+		delete(s.hooks, w.ID)
+		return Webhook{}, err
+	}
+	return w, nil
+}
+
+// Update mengubah webhook yang sudah ada.
+func (s *Store) Update(id string, in Input) (Webhook, error) {
+	if err := in.validate(false); err != nil {
+		return Webhook{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous, ok := s.hooks[id]
+	if !ok {
+		return Webhook{}, ErrNotFound
+	}
+	w := previous
+	if in.URL != "" {
+		w.URL = in.URL
+	}
+	if in.Secret != "" {
+		w.Secret = in.Secret
+	}
+	if in.Events != nil {
+		w.Events = in.Events
+	}
+	if in.Active != nil {
+		w.Active = *in.Active
+	}
+	if in.Description != "" {
+		w.Description = in.Description
+	}
+	w.UpdatedAt = time.Now()
+
+	s.hooks[id] = w
+	if err := s.save(); err != nil {
+		s.hooks[id] = previous
+		return Webhook{}, err
+	}
+	return w, nil
+}
+
+// Delete menghapus webhook.
+func (s *Store) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous, ok := s.hooks[id]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(s.hooks, id)
+	if err := s.save(); err != nil {
+		s.hooks[id] = previous
+		return err
+	}
+	return nil
+}
+
+// save menulis semua webhook ke file secara atomik. Dipanggil dengan mu terkunci.
+func (s *Store) save() error {
+	list := make([]Webhook, 0, len(s.hooks))
+	for _, w := range s.hooks {
+		list = append(list, w)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	raw, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	// File berisi secret, jadi hanya bisa dibaca pemilik.
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buf)
+}
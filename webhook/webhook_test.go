@@ -0,0 +1,144 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"multiplescrape/events"
+)
+
+func TestStoreCRUDPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "webhooks.json")
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Create(Input{URL: "ftp://example.com"}); err == nil {
+		t.Error("URL non-http seharusnya ditolak")
+	}
+	if _, err := store.Create(Input{URL: "https://example.com", Events: []string{"unknown"}}); err == nil {
+		t.Error("jenis event tidak dikenal seharusnya ditolak")
+	}
+
+	created, err := store.Create(Input{URL: "https://example.com/hook", Events: []string{events.NewEpisode}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Secret == "" || !created.Active {
+		t.Errorf("secret seharusnya dibuat dan webhook aktif: %+v", created)
+	}
+
+	inactive := false
+	if _, err := store.Update(created.ID, Input{Active: &inactive}); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := NewStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := reloaded.Get(created.ID)
+	if err != nil || got.Active || got.URL != "https://example.com/hook" {
+		t.Errorf("webhook seharusnya tersimpan ke file: %+v, %v", got, err)
+	}
+
+	if err := reloaded.Delete(created.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reloaded.Get(created.ID); err != ErrNotFound {
+		t.Errorf("webhook yang dihapus seharusnya tidak ditemukan, didapat %v", err)
+	}
+}
+
+func TestDispatcherSignsAndRetries(t *testing.T) {
+	var calls int32
+	received := make(chan events.Event, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !Verify("rahasia", r.Header.Get(HeaderTimestamp), r.Header.Get(HeaderSignature), body, time.Now()) {
+			t.Errorf("tanda tangan tidak cocok: %s", r.Header.Get(HeaderSignature))
+		}
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var e events.Event
+		json.Unmarshal(body, &e)
+		received <- e
+	}))
+	defer receiver.Close()
+
+	store, _ := NewStore(filepath.Join(t.TempDir(), "webhooks.json"))
+	hook, err := store.Create(Input{URL: receiver.URL, Secret: "rahasia", Events: []string{events.NewEpisode}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bus := events.NewBus()
+	d := NewDispatcher(store, Options{Timeout: time.Second, MaxAttempts: 5, RetryBackoff: 10 * time.Millisecond, LogSize: 10})
+	defer d.Start(bus)()
+
+	bus.Publish(events.Event{Type: events.StreamAdded, AnimeSlug: "diabaikan"})
+	bus.Publish(events.Event{Type: events.NewEpisode, AnimeSlug: "naruto", Episode: "5"})
+
+	select {
+	case e := <-received:
+		if e.AnimeSlug != "naruto" || e.Episode != "5" {
+			t.Errorf("payload tidak sesuai: %+v", e)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("event tidak pernah diterima")
+	}
+
+	// Delivery dicatat setelah response diterima, tunggu sebentar
+	var deliveries []Delivery
+	for i := 0; i < 100; i++ {
+		if deliveries = d.Deliveries(hook.ID); len(deliveries) == 3 {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if len(deliveries) != 3 {
+		t.Fatalf("seharusnya ada 3 percobaan, didapat %d", len(deliveries))
+	}
+	if !deliveries[0].Success || deliveries[0].Attempt != 3 || deliveries[2].StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("log pengiriman tidak sesuai: %+v", deliveries)
+	}
+}
+
+func TestVerifyRejectsReplay(t *testing.T) {
+	body := []byte(`{"type":"new_anime"}`)
+	sent := time.Unix(1700000000, 0)
+	timestamp := strconv.FormatInt(sent.Unix(), 10)
+	signature := Sign("rahasia", timestamp, body)
+
+	if !Verify("rahasia", timestamp, signature, body, sent.Add(time.Minute)) {
+		t.Error("tanda tangan yang valid dan masih baru seharusnya diterima")
+	}
+	if Verify("rahasia", timestamp, signature, body, sent.Add(SignatureTolerance+time.Second)) {
+		t.Error("pengiriman di luar toleransi waktu seharusnya ditolak")
+	}
+	newer := strconv.FormatInt(sent.Add(time.Hour).Unix(), 10)
+	if Verify("rahasia", newer, signature, body, sent.Add(time.Hour)) {
+		t.Error("tanda tangan lama dengan timestamp baru seharusnya ditolak")
+	}
+}
+
+func TestDeliveryLogIsBounded(t *testing.T) {
+	d := NewDispatcher(nil, Options{LogSize: 2})
+	for i := 1; i <= 3; i++ {
+		d.record(Delivery{EventID: uint64(i)})
+	}
+	got := d.Deliveries("")
+	if len(got) != 2 || got[0].EventID != 3 || got[1].EventID != 2 {
+		t.Errorf("log seharusnya menyimpan 2 delivery terbaru, didapat %+v", got)
+	}
+}
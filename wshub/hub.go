@@ -0,0 +1,340 @@
+// Package wshub menyediakan API WebSocket untuk berlangganan event perubahan per anime.
+//
+// Protokol (JSON per pesan):
+//
+//	klien -> server: {"type":"subscribe","id":"1","anime_slugs":["one-piece"]}
+//	                 {"type":"unsubscribe","id":"2","anime_slugs":["one-piece"]}
+//	                 {"type":"list","id":"3"}
+//	                 {"type":"ping"}
+//	server -> klien: {"type":"welcome","max_subscriptions":50}
+//	                 {"type":"ack","id":"1","subscriptions":["one-piece"]}
+//	                 {"type":"error","id":"1","error":"..."}
+//	                 {"type":"event","event":{...}}
+//	                 {"type":"pong"}
+package wshub
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"multiplescrape/events"
+)
+
+// Jenis pesan.
+const (
+	TypeSubscribe   = "subscribe"
+	TypeUnsubscribe = "unsubscribe"
+	TypeList        = "list"
+	TypePing        = "ping"
+	TypePong        = "pong"
+	TypeWelcome     = "welcome"
+	TypeAck         = "ack"
+	TypeError       = "error"
+	TypeEvent       = "event"
+)
+
+// ClientMessage adalah pesan dari klien.
+type ClientMessage struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id,omitempty"`
+	AnimeSlugs []string `json:"anime_slugs,omitempty"`
+}
+
+// ServerMessage adalah pesan dari server.
+type ServerMessage struct {
+	Type             string        `json:"type"`
+	ID               string        `json:"id,omitempty"`
+	Subscriptions    []string      `json:"subscriptions,omitempty"`
+	MaxSubscriptions int           `json:"max_subscriptions,omitempty"`
+	Error            string        `json:"error,omitempty"`
+	Event            *events.Event `json:"event,omitempty"`
+}
+
+// Options mengatur batas per koneksi.
+type Options struct {
+	MaxSubscriptions int
+	SendBuffer       int
+	PingInterval     time.Duration
+	MaxMessageBytes  int64
+}
+
+// Stats adalah statistik hub untuk monitoring.
+type Stats struct {
+	Clients         int   `json:"clients"`
+	Subscriptions   int   `json:"subscriptions"`
+	SlowDisconnects int64 `json:"slow_disconnects"`
+}
+
+// Hub menyimpan semua koneksi WebSocket dan meneruskan event ke klien yang berlangganan.
+type Hub struct {
+	opts     Options
+	upgrader websocket.Upgrader
+
+	mu      sync.RWMutex
+	clients map[*client]struct{}
+	slow    int64
+}
+
+// NewHub membuat hub baru.
+func NewHub(opts Options) *Hub {
+	if opts.MaxSubscriptions < 1 {
+		opts.MaxSubscriptions = 50
+	}
+	if opts.SendBuffer < 1 {
+		opts.SendBuffer = 64
+	}
+	if opts.PingInterval <= 0 {
+		opts.PingInterval = 30 * time.Second
+	}
+	if opts.MaxMessageBytes <= 0 {
+		opts.MaxMessageBytes = 4096
+	}
+	h := &Hub{opts: opts, clients: make(map[*client]struct{})}
+	// Semua origin diizinkan, sama dengan CORS "*" di API HTTP
+	h.upgrader = websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+	return h
+}
+
+// Start berlangganan ke bus. Fungsi yang dikembalikan membatalkan langganan.
+func (h *Hub) Start(bus *events.Bus) func() {
+	return bus.Subscribe(h.Broadcast)
+}
+
+// Broadcast mengirim event ke semua klien yang berlangganan slug anime-nya. Tidak blocking.
+func (h *Hub) Broadcast(e events.Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for c := range h.clients {
+		if c.subscribed(e.AnimeSlug) {
+			event := e
+			c.enqueue(ServerMessage{Type: TypeEvent, Event: &event})
+		}
+	}
+}
+
+// Stats mengembalikan jumlah klien dan langganan aktif.
+func (h *Hub) Stats() Stats {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	stats := Stats{Clients: len(h.clients), SlowDisconnects: atomic.LoadInt64(&h.slow)}
+	for c := range h.clients {
+		stats.Subscriptions += len(c.list())
+	}
+	return stats
+}
+
+// ServeHTTP meng-upgrade request menjadi koneksi WebSocket dan melayaninya sampai ditutup.
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrader sudah menulis response error
+		return
+	}
+	c := newClient(h, conn)
+
+	h.mu.Lock()
+	h.clients[c] = struct{}{}
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		delete(h.clients, c)
+		h.mu.Unlock()
+	}()
+
+	c.enqueue(ServerMessage{Type: TypeWelcome, MaxSubscriptions: h.opts.MaxSubscriptions})
+	go c.writeLoop()
+	c.readLoop()
+}
+
+// --- Klien ---
+
+type client struct {
+	hub  *Hub
+	conn *websocket.Conn
+	send chan ServerMessage
+	done chan struct{}
+
+	closeOnce sync.Once
+	closeCode int
+	closeText string
+
+	mu   sync.RWMutex
+	subs map[string]bool
+}
+
+func newClient(h *Hub, conn *websocket.Conn) *client {
+	return &client{
+		hub:  h,
+		conn: conn,
+		send: make(chan ServerMessage, h.opts.SendBuffer),
+		done: make(chan struct{}),
+		subs: make(map[string]bool),
+	}
+}
+
+func (c *client) subscribed(slug string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.subs[strings.ToLower(slug)]
+}
+
+func (c *client) list() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	list := make([]string, 0, len(c.subs))
+	for slug := range c.subs {
+		list = append(list, slug)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// enqueue menaruh pesan di buffer kirim. Jika buffer penuh, klien dianggap terlalu lambat
+// dan koneksinya ditutup; klien bisa menyambung ulang dan berlangganan lagi.
+func (c *client) enqueue(msg ServerMessage) bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+	}
+	select {
+	case c.send <- msg:
+		return true
+	default:
+		atomic.AddInt64(&c.hub.slow, 1)
+		c.close(websocket.CloseTryAgainLater, "klien terlalu lambat")
+		return false
+	}
+}
+
+func (c *client) close(code int, text string) {
+	c.closeOnce.Do(func() {
+		c.closeCode = code
+		c.closeText = text
+		close(c.done)
+	})
+}
+
+func (c *client) readLoop() {
+	defer c.close(websocket.CloseNormalClosure, "")
+
+	pongWait := c.hub.opts.PingInterval * 2
+	c.conn.SetReadLimit(c.hub.opts.MaxMessageBytes)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+
+		var msg ClientMessage
+		reply := ServerMessage{Type: TypeError, Error: "Pesan harus JSON yang valid."}
+		if err := json.Unmarshal(data, &msg); err == nil {
+			reply = c.handle(msg)
+		}
+		if !c.enqueue(reply) {
+			return
+		}
+	}
+}
+
+// handle memproses satu pesan klien dan mengembalikan balasannya.
+func (c *client) handle(msg ClientMessage) ServerMessage {
+	switch msg.Type {
+	case TypePing:
+		return ServerMessage{Type: TypePong, ID: msg.ID}
+	case TypeList:
+		return ServerMessage{Type: TypeAck, ID: msg.ID, Subscriptions: c.list()}
+	case TypeSubscribe, TypeUnsubscribe:
+		slugs := normalizeSlugs(msg.AnimeSlugs)
+		if len(slugs) == 0 {
+			return ServerMessage{Type: TypeError, ID: msg.ID, Error: "anime_slugs wajib diisi."}
+		}
+		c.mu.Lock()
+		if msg.Type == TypeSubscribe {
+			added := 0
+			for _, slug := range slugs {
+				if !c.subs[slug] {
+					added++
+				}
+			}
+			if len(c.subs)+added > c.hub.opts.MaxSubscriptions {
+				c.mu.Unlock()
+				return ServerMessage{
+					Type:          TypeError,
+					ID:            msg.ID,
+					Error:         "Batas langganan per koneksi terlampaui.",
+					Subscriptions: c.list(),
+				}
+			}
+			for _, slug := range slugs {
+				c.subs[slug] = true
+			}
+		} else {
+			for _, slug := range slugs {
+				delete(c.subs, slug)
+			}
+		}
+		c.mu.Unlock()
+		return ServerMessage{Type: TypeAck, ID: msg.ID, Subscriptions: c.list()}
+	default:
+		return ServerMessage{Type: TypeError, ID: msg.ID, Error: "Jenis pesan tidak dikenal: " + msg.Type}
+	}
+}
+
+// normalizeSlugs menyeragamkan slug ke huruf kecil dan membuang yang kosong atau duplikat.
+func normalizeSlugs(raw []string) []string {
+	var slugs []string
+	seen := make(map[string]bool)
+	for _, slug := range raw {
+		slug = strings.ToLower(strings.TrimSpace(slug))
+		if slug != "" && !seen[slug] {
+			seen[slug] = true
+			slugs = append(slugs, slug)
+		}
+	}
+	return slugs
+}
+
+func (c *client) writeLoop() {
+	ticker := time.NewTicker(c.hub.opts.PingInterval)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	writeWait := 10 * time.Second
+	for {
+		select {
+		case msg := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteJSON(msg); err != nil {
+				c.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-ticker.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				c.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-c.done:
+			if c.closeCode != websocket.CloseAbnormalClosure {
+				message := websocket.FormatCloseMessage(c.closeCode, c.closeText)
+				c.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(writeWait))
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package wshub
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"multiplescrape/events"
+)
+
+func dialHub(t *testing.T, h *Hub) *websocket.Conn {
+	t.Helper()
+	server := httptest.NewServer(h)
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("gagal menyambung: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	if welcome := readMessage(t, conn); welcome.Type != TypeWelcome {
+		t.Fatalf("pesan pertama seharusnya welcome, didapat %+v", welcome)
+	}
+	return conn
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn) ServerMessage {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var msg ServerMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatalf("gagal membaca pesan: %v", err)
+	}
+	return msg
+}
+
+func request(t *testing.T, conn *websocket.Conn, msg ClientMessage) ServerMessage {
+	t.Helper()
+	if err := conn.WriteJSON(msg); err != nil {
+		t.Fatalf("gagal mengirim pesan: %v", err)
+	}
+	return readMessage(t, conn)
+}
+
+func TestHubDeliversSubscribedEvents(t *testing.T) {
+	bus := events.NewBus()
+	h := NewHub(Options{})
+	defer h.Start(bus)()
+	conn := dialHub(t, h)
+
+	ack := request(t, conn, ClientMessage{Type: TypeSubscribe, ID: "1", AnimeSlugs: []string{" Naruto ", "bleach"}})
+	if ack.Type != TypeAck || ack.ID != "1" || len(ack.Subscriptions) != 2 || ack.Subscriptions[1] != "naruto" {
+		t.Fatalf("ack subscribe tidak sesuai: %+v", ack)
+	}
+
+	bus.Publish(events.Event{Type: events.NewEpisode, AnimeSlug: "one-piece"})
+	bus.Publish(events.Event{Type: events.NewEpisode, AnimeSlug: "naruto", Episode: "5"})
+	got := readMessage(t, conn)
+	if got.Type != TypeEvent || got.Event == nil || got.Event.AnimeSlug != "naruto" || got.Event.Episode != "5" {
+		t.Fatalf("seharusnya hanya menerima event naruto, didapat %+v", got)
+	}
+
+	ack = request(t, conn, ClientMessage{Type: TypeUnsubscribe, ID: "2", AnimeSlugs: []string{"naruto"}})
+	if len(ack.Subscriptions) != 1 || ack.Subscriptions[0] != "bleach" {
+		t.Errorf("ack unsubscribe tidak sesuai: %+v", ack)
+	}
+	bus.Publish(events.Event{Type: events.StreamAdded, AnimeSlug: "naruto"})
+	if pong := request(t, conn, ClientMessage{Type: TypePing, ID: "3"}); pong.Type != TypePong {
+		t.Errorf("event setelah unsubscribe tidak boleh terkirim, didapat %+v", pong)
+	}
+	if stats := h.Stats(); stats.Clients != 1 || stats.Subscriptions != 1 {
+		t.Errorf("statistik hub tidak sesuai: %+v", stats)
+	}
+}
+
+func TestHubSubscriptionLimit(t *testing.T) {
+	conn := dialHub(t, NewHub(Options{MaxSubscriptions: 2}))
+
+	request(t, conn, ClientMessage{Type: TypeSubscribe, AnimeSlugs: []string{"a"}})
+	reply := request(t, conn, ClientMessage{Type: TypeSubscribe, ID: "x", AnimeSlugs: []string{"b", "c"}})
+	if reply.Type != TypeError || reply.ID != "x" || len(reply.Subscriptions) != 1 {
+		t.Errorf("langganan yang melewati batas seharusnya ditolak seluruhnya: %+v", reply)
+	}
+
+	if reply := request(t, conn, ClientMessage{Type: "unknown"}); reply.Type != TypeError {
+		t.Errorf("jenis pesan tidak dikenal seharusnya error, didapat %+v", reply)
+	}
+	if err := conn.WriteMessage(websocket.TextMessage, []byte("bukan json")); err != nil {
+		t.Fatal(err)
+	}
+	if reply := readMessage(t, conn); reply.Type != TypeError {
+		t.Errorf("pesan non-JSON seharusnya error, didapat %+v", reply)
+	}
+}
+
+func TestSlowClientIsDisconnected(t *testing.T) {
+	h := NewHub(Options{SendBuffer: 1})
+	c := newClient(h, nil)
+
+	if !c.enqueue(ServerMessage{Type: TypePong}) {
+		t.Fatal("pesan pertama seharusnya masuk buffer")
+	}
+	if c.enqueue(ServerMessage{Type: TypePong}) {
+		t.Fatal("buffer penuh seharusnya menolak pesan")
+	}
+	select {
+	case <-c.done:
+	default:
+		t.Fatal("klien lambat seharusnya ditutup")
+	}
+	if c.closeCode != websocket.CloseTryAgainLater || h.Stats().SlowDisconnects != 1 {
+		t.Errorf("penutupan klien lambat tidak sesuai: code=%d stats=%+v", c.closeCode, h.Stats())
+	}
+}